@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"jiraTimeWidget/jiraModels"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+)
+
+// runAuthCommand implements `jira-time auth login|logout`.
+func runAuthCommand(args []string) int {
+	getPassphrase = promptPassphrase
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	switch args[0] {
+	case "login":
+		return runAuthLogin(args[1:])
+	case "logout":
+		return runAuthLogout(args[1:])
+	default:
+		fmt.Fprintln(os.Stderr, "Usage: jira-time [--profile name] auth login|logout [-name secret-name]")
+		return 2
+	}
+}
+
+// runAuthLogin prompts for an API token, checks it against Jira and saves
+// it in the credential store, pointing the profile at it.
+func runAuthLogin(args []string) int {
+	flags := flag.NewFlagSet("auth login", flag.ContinueOnError)
+	name := flags.String("name", "", "store the token under `NAME` (default: the profile's tokenRef or name)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	config, profile, err := readAuthProfile()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	siteURL, err := normalizeSiteURL(profile.Site)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: profile %s needs a \"site\" in ~/.jirarc: %v\n", profile.Name, err)
+		return 1
+	}
+	secretName := tokenSecretName(profile, *name)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if profile.usesOAuth() {
+		return runOAuthLogin(ctx, config, profile, siteURL, secretName)
+	}
+
+	tokenKind := "API token"
+	if profile.isDataCenter() {
+		tokenKind = "Personal Access Token"
+	}
+	token, err := readSecret(fmt.Sprintf("%s for %s: ", tokenKind, siteURL))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if token == "" {
+		fmt.Fprintln(os.Stderr, "Error: no token entered")
+		return 1
+	}
+
+	// Make sure Jira accepts the token before saving it
+	candidate := *profile
+	candidate.APIKey = token
+	user, err := candidate.newClient().Myself(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Jira didn't accept the token: %s\n", describeJiraError(err))
+		return 1
+	}
+	store, err := openCredentialStore(config.CredentialStore)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening credential store: %v\n", err)
+		return 1
+	}
+	defer store.Close()
+	if err := store.Set(secretName, token); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving token: %v\n", err)
+		return 1
+	}
+	if err := setProfileTokenRef(profile.Name, secretName); err != nil {
+		fmt.Fprintf(os.Stderr, "Token saved, but updating ~/.jirarc failed: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Set \"tokenRef\": %q in profile %s by hand.\n", secretName, profile.Name)
+		return 1
+	}
+
+	fmt.Printf("✅ Logged in to %s as %s.\n", siteURL, describeUser(user))
+	fmt.Printf("Token saved as %q in %s.\n", secretName, store.Backend())
+	return 0
+}
+
+// runAuthLogout deletes the profile's stored token.
+func runAuthLogout(args []string) int {
+	flags := flag.NewFlagSet("auth logout", flag.ContinueOnError)
+	name := flags.String("name", "", "delete the token stored under `NAME` (default: the profile's tokenRef or name)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	config, profile, err := readAuthProfile()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	secretName := tokenSecretName(profile, *name)
+
+	store, err := openCredentialStore(config.CredentialStore)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening credential store: %v\n", err)
+		return 1
+	}
+	defer store.Close()
+	if err := store.Delete(secretName); err != nil {
+		if errors.Is(err, errCredentialNotFound) {
+			fmt.Fprintf(os.Stderr, "No token is stored as %q.\n", secretName)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error deleting token: %v\n", err)
+		}
+		return 1
+	}
+	fmt.Printf("Deleted token %q from %s.\n", secretName, store.Backend())
+	return 0
+}
+
+// readAuthProfile reads .jirarc and picks the --profile one, without
+// unlocking any stored tokens.
+func readAuthProfile() (*jiraConfig, *JiraProfile, error) {
+	config, err := readJiraConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	profile, err := config.Profile(selectedProfileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return config, profile, nil
+}
+
+// describeUser names a user for messages.
+func describeUser(user *jiraModels.User) string {
+	if user.EmailAddress != "" {
+		return fmt.Sprintf("%s <%s>", user.DisplayName, user.EmailAddress)
+	}
+	return user.DisplayName
+}
+
+func tokenSecretName(profile *JiraProfile, override string) string {
+	switch {
+	case override != "":
+		return override
+	case profile.TokenRef != "":
+		return profile.TokenRef
+	default:
+		return profile.Name
+	}
+}
+
+// setProfileTokenRef points a profile in .jirarc at a stored token and
+// removes any plain-text token it had.
+func setProfileTokenRef(profileName, tokenRef string) error {
+	return updateProfileConfig(profileName, func(fields map[string]interface{}) {
+		delete(fields, "jira")
+		fields["tokenRef"] = tokenRef
+	})
+}
+
+// updateProfileConfig rewrites a profile's settings in .jirarc with update,
+// keeping everything else in the file. The file is left alone when update
+// changes nothing.
+func updateProfileConfig(profileName string, update func(fields map[string]interface{})) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(homeDir, ".jirarc")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	original, _ := json.Marshal(raw)
+	target := raw
+	if profiles, ok := raw["profiles"].(map[string]interface{}); ok && len(profiles) > 0 {
+		profile, ok := profiles[profileName].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("no profile %q in .jirarc", profileName)
+		}
+		target = profile
+	}
+	update(target)
+	if changed, _ := json.Marshal(raw); string(changed) == string(original) {
+		return nil
+	}
+
+	updated, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, append(updated, '\n'), 0600)
+}
+
+// promptPassphrase asks for the credential file passphrase on the
+// terminal, unless JIRA_TIME_PASSPHRASE provides it.
+func promptPassphrase(creating bool) (string, error) {
+	if credentialPassphrase != "" {
+		return credentialPassphrase, nil
+	}
+	if passphrase := os.Getenv(credentialPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	passphrase, err := readSecret("Passphrase for the Jira credential file: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errPassphraseRequired
+	}
+	if creating {
+		again, err := readSecret("Repeat the passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", errors.New("the passphrases don't match")
+		}
+	}
+	credentialPassphrase = passphrase
+	return passphrase, nil
+}
+
+// stdinReader is shared so several prompts can read from the same input.
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// readSecret prints prompt and reads a line from the terminal without
+// echoing it.
+func readSecret(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	restore := disableEcho(os.Stdin)
+	line, err := stdinReader.ReadString('\n')
+	restore()
+	fmt.Fprintln(os.Stderr)
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,775 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"jiraTimeWidget/jiraApiFunctions"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cliCommand is a jira-time subcommand. The GUI and the commands share the
+// running timer, the time log and the outbox, so work started in one can be
+// finished in the other.
+type cliCommand struct {
+	name    string
+	args    string // usage after the name
+	summary string
+	run     func(args []string) int
+}
+
+func cliCommands() []cliCommand {
+	return []cliCommand{
+		{"start", "<KEY> [-m comment] [-at time] [-pause-others] [-switch]", "start a timer on an issue", runStartCommand},
+		{"stop", "[KEY] [-m comment] [-at time] [-discard]", "stop a timer and log its time to Jira", runStopCommand},
+		{"pause", "[KEY]", "pause a running timer", runPauseCommand},
+		{"resume", "[KEY]", "resume a paused timer", runResumeCommand},
+		{"status", "[-auto-pause on|off]", "show the timers", runStatusCommand},
+		{"log", "<KEY> <duration> [-m comment] [-at time]", "log time to an issue without a timer", runLogCommand},
+		{"issues", "[-n count]", "list your recent issues", runIssuesCommand},
+		{"transition", "<KEY> [status]", "list an issue's transitions, or move it to a status", runTransitionCommand},
+		{"comment", "<KEY> <text>", "add a comment to an issue", runCommentCommand},
+		{"report", "[-days N] [-from date] [-to date]", "summarise the local time log by day and issue", runReportCommand},
+		{"logs", "", "show today's local time log", func([]string) int {
+			loadActiveProfile()
+			viewLogs(mustOpenTimeLogStore())
+			return 0
+		}},
+		{"sync", "[-days N]", "compare the local time log with Jira's worklogs", func(args []string) int {
+			mustLoadJiraConfig()
+			return runSyncCommand(mustOpenTimeLogStore(), args)
+		}},
+		{"outbox", "list|retry [id]|drop <id>", "manage worklogs waiting to reach Jira", func(args []string) int {
+			mustLoadJiraConfig()
+			return runOutboxCommand(mustOpenTimeLogStore(), args)
+		}},
+		{"auth", "login|logout [-name secret-name]", "save or remove a Jira token", runAuthCommand},
+	}
+}
+
+// runCommand runs the subcommand named by args[0] and returns its exit code.
+func runCommand(args []string) int {
+	name := args[0]
+	if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		if len(args) > 1 {
+			for _, cmd := range cliCommands() {
+				if cmd.name == args[1] {
+					fmt.Printf("Usage: jira-time [--profile name] %s %s\n\n%s.\n", cmd.name, cmd.args, cmd.summary)
+					if cmd.args != "" {
+						fmt.Printf("Run \"jira-time %s -h\" for its flags.\n", cmd.name)
+					}
+					return 0
+				}
+			}
+		}
+		printUsage(os.Stdout)
+		return 0
+	}
+	for _, cmd := range cliCommands() {
+		if cmd.name == name {
+			return cmd.run(args[1:])
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", name)
+	printUsage(os.Stderr)
+	return 2
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: jira-time [--profile name] [command]")
+	fmt.Fprintln(w, "\nWithout a command the window opens. Commands:")
+	for _, cmd := range cliCommands() {
+		fmt.Fprintf(w, "  %-11s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w, "\nRun \"jira-time help <command>\" for a command's options.")
+}
+
+// newCommandFlags returns the flag set for a command, with usage that
+// shows its arguments.
+func newCommandFlags(name, args string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: jira-time [--profile name] %s %s\n", name, args)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseCommandArgs parses flags given before, between or after the
+// positional arguments and returns the positional ones.
+func parseCommandArgs(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseAtTime reads a time given with -at: a clock time today, a date and
+// time, or RFC 3339.
+func parseAtTime(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("15:04", s, now.Location()); err == nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location()), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", s, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, use e.g. 09:30, \"2006-01-02 09:30\" or RFC 3339", s)
+}
+
+// commandTime returns the -at time, or now when it wasn't given.
+func commandTime(at string, now time.Time) (time.Time, error) {
+	if at == "" {
+		return now, nil
+	}
+	return parseAtTime(at, now)
+}
+
+// commandContext returns a context that is cancelled on Ctrl-C, so
+// requests stop cleanly instead of hanging.
+func commandContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
+// mustOpenTimerStore opens the shared timer for CLI commands, exiting on
+// failure.
+func mustOpenTimerStore() *TimerStore {
+	store, err := openTimerStore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening timer: %v\n", err)
+		os.Exit(1)
+	}
+	return store
+}
+
+// runStartCommand implements `jira-time start`.
+func runStartCommand(args []string) int {
+	flags := newCommandFlags("start", "<KEY> [-m comment] [-at time] [-pause-others] [-switch]")
+	comment := flags.String("m", "", "worklog `comment` to use when the timer stops")
+	at := flags.String("at", "", "start at `time` instead of now, e.g. 09:30")
+	pauseOthers := flags.Bool("pause-others", false, "pause the other running timers, as -auto-pause does")
+	switchTimer := flags.Bool("switch", false, "stop and log the other timers first")
+	positional, err := parseCommandArgs(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 1 {
+		flags.Usage()
+		return 2
+	}
+	issueKey := strings.ToUpper(positional[0])
+	start, err := commandTime(*at, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	mustLoadJiraConfig()
+	timers := mustOpenTimerStore()
+	ctx, stop := commandContext()
+	defer stop()
+
+	// Check the issue exists before timing it, but don't insist on Jira
+	// being reachable
+	details, err := loadIssueDetails(ctx, issueKey)
+	if err != nil && !jiraApiFunctions.IsRetryable(err) {
+		fmt.Fprintf(os.Stderr, "Error: %s: %s\n", issueKey, describeJiraError(err))
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: couldn't check %s: %s\n", issueKey, describeJiraError(err))
+	}
+
+	if *switchTimer {
+		running, err := timers.Timers()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading timers: %v\n", err)
+			return 1
+		}
+		for i := range running {
+			if running[i].Issue == issueKey {
+				continue
+			}
+			if code := stopTimerInItsProfile(ctx, timers, &running[i], start); code != 0 {
+				return code
+			}
+		}
+	}
+
+	paused, err := timers.Start(ActiveTimer{Issue: issueKey, Start: start, Comment: *comment, Profile: activeProfile.Name}, *pauseOthers)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	for _, other := range paused {
+		fmt.Printf("⏸️ Paused %s\n", other)
+	}
+	if details != nil {
+		fmt.Printf("⏱️ Started %s at %s: %s\n", issueKey, start.Format("15:04"), details.Summary)
+	} else {
+		fmt.Printf("⏱️ Started %s at %s\n", issueKey, start.Format("15:04"))
+	}
+	return 0
+}
+
+// runStopCommand implements `jira-time stop`.
+func runStopCommand(args []string) int {
+	flags := newCommandFlags("stop", "[KEY] [-m comment] [-at time] [-discard]")
+	comment := flags.String("m", "", "worklog `comment`, replacing the one given to start")
+	at := flags.String("at", "", "stop at `time` instead of now, e.g. 17:30")
+	discard := flags.Bool("discard", false, "throw the timer away without logging it")
+	issueKey, ok := parseTimerArgs(flags, args)
+	if !ok {
+		return 2
+	}
+	end, err := commandTime(*at, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	loadActiveProfile()
+	timers := mustOpenTimerStore()
+	running, err := timers.Timer(issueKey)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", timerChoiceError(err))
+		return 1
+	}
+	if running == nil {
+		printNoTimer(issueKey)
+		return 1
+	}
+
+	if *discard {
+		if _, err := timers.Stop(running.Issue); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("🗑️ Discarded %s on %s\n", formatDurationForJira(running.Elapsed(end)), running.Issue)
+		return 0
+	}
+
+	// Log under the profile the timer was started in unless told otherwise
+	if selectedProfileName == "" {
+		selectedProfileName = running.Profile
+	}
+	mustLoadJiraConfig()
+	ctx, stop := commandContext()
+	defer stop()
+	return stopTimer(ctx, timers, running, *comment, end)
+}
+
+// stopTimer stops running at end and logs its time, printing the outcome.
+func stopTimer(ctx context.Context, timers *TimerStore, running *ActiveTimer, comment string, end time.Time) int {
+	if !end.After(running.Start) {
+		fmt.Fprintf(os.Stderr, "Error: the timer on %s started at %s, after %s\n", running.Issue, running.Start.Format("15:04"), end.Format("15:04"))
+		return 2
+	}
+	stopped, err := timers.Stop(running.Issue)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if stopped == nil || !stopped.Start.Equal(running.Start) {
+		fmt.Printf("The timer on %s was stopped elsewhere.\n", running.Issue)
+		return 1
+	}
+	return submitCommandWorklog(ctx, stopped.logEntry(end, comment))
+}
+
+// stopTimerInItsProfile stops running at end and logs its time under the
+// profile it was started in, as stop does, then goes back to the active
+// profile.
+func stopTimerInItsProfile(ctx context.Context, timers *TimerStore, running *ActiveTimer, end time.Time) int {
+	if jiraProfiles != nil && !activeProfile.owns(running.Profile) {
+		profile, err := jiraProfiles.Profile(running.Profile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: the timer on %s: %v\n", running.Issue, err)
+			return 1
+		}
+		active := activeProfile
+		applyProfile(profile)
+		defer applyProfile(active)
+	}
+	return stopTimer(ctx, timers, running, "", end)
+}
+
+// submitCommandWorklog logs entry to Jira and prints the outcome.
+func submitCommandWorklog(ctx context.Context, entry TimeLogEntry) int {
+	outbox, err := openOutbox()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening outbox: %v\n", err)
+		return 1
+	}
+	saved, queued, err := submitWorklog(ctx, mustOpenTimeLogStore(), outbox, entry)
+	switch {
+	case queued && err != nil:
+		fmt.Printf("📤 Interrupted, %s on %s queued for retry\n", entry.Duration, entry.JiraID)
+		return 1
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "❌ Failed to log %s to %s: %s\n", entry.Duration, entry.JiraID, describeJiraError(err))
+		fmt.Fprintln(os.Stderr, "The time is kept in the local log.")
+		return 1
+	case queued:
+		fmt.Printf("📤 Jira unavailable, %s on %s queued for retry\n", entry.Duration, entry.JiraID)
+	case saved.WorklogID == "":
+		fmt.Printf("✅ Logged %s to %s (Jira didn't confirm the worklog ID, \"jira-time sync\" will match it)\n", entry.Duration, entry.JiraID)
+	default:
+		fmt.Printf("✅ Logged %s to %s (worklog %s)\n", entry.Duration, entry.JiraID, saved.WorklogID)
+	}
+	return 0
+}
+
+// runPauseCommand implements `jira-time pause`.
+func runPauseCommand(args []string) int {
+	return changeTimerCommand("pause", args, (*ActiveTimer).Pause, "⏸️ Paused %s after %s\n", "The timer on %s is already paused.\n")
+}
+
+// runResumeCommand implements `jira-time resume`.
+func runResumeCommand(args []string) int {
+	return changeTimerCommand("resume", args, (*ActiveTimer).Resume, "▶️ Resumed %s, %s so far\n", "The timer on %s isn't paused.\n")
+}
+
+// changeTimerCommand pauses or resumes a timer with change, printing done
+// or unchanged with the issue key.
+func changeTimerCommand(name string, args []string, change func(*ActiveTimer, time.Time) bool, done, unchanged string) int {
+	flags := newCommandFlags(name, "[KEY]")
+	issueKey, ok := parseTimerArgs(flags, args)
+	if !ok {
+		return 2
+	}
+
+	now := time.Now()
+	changed := false
+	timer, err := mustOpenTimerStore().Update(issueKey, func(timer *ActiveTimer) {
+		changed = change(timer, now)
+	})
+	switch {
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", timerChoiceError(err))
+		return 1
+	case timer == nil:
+		printNoTimer(issueKey)
+		return 1
+	case !changed:
+		fmt.Printf(unchanged, timer.Issue)
+		return 1
+	}
+	fmt.Printf(done, timer.Issue, formatDurationForJira(timer.Elapsed(now)))
+	return 0
+}
+
+// parseTimerArgs parses the flags of a command that takes an optional
+// issue key naming the timer, returning the key in upper case.
+func parseTimerArgs(flags *flag.FlagSet, args []string) (string, bool) {
+	positional, err := parseCommandArgs(flags, args)
+	if err != nil {
+		return "", false
+	}
+	if len(positional) > 1 {
+		flags.Usage()
+		return "", false
+	}
+	if len(positional) == 0 {
+		return "", true
+	}
+	return strings.ToUpper(positional[0]), true
+}
+
+// timerChoiceError explains errSeveralTimers with how to pick one.
+func timerChoiceError(err error) error {
+	if errors.Is(err, errSeveralTimers) {
+		return fmt.Errorf("%w (name the issue)", err)
+	}
+	return err
+}
+
+func printNoTimer(issueKey string) {
+	if issueKey == "" {
+		fmt.Println("No timer is running.")
+	} else {
+		fmt.Printf("No timer is running on %s.\n", issueKey)
+	}
+}
+
+// runStatusCommand implements `jira-time status`.
+func runStatusCommand(args []string) int {
+	flags := newCommandFlags("status", "[-auto-pause on|off]")
+	autoPause := flags.String("auto-pause", "", "`on` to pause the other timers whenever one starts, off to keep them running")
+	if _, err := parseCommandArgs(flags, args); err != nil {
+		return 2
+	}
+
+	timers := mustOpenTimerStore()
+	switch *autoPause {
+	case "":
+	case "on", "off":
+		if err := timers.SetAutoPause(*autoPause == "on"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: -auto-pause takes on or off, not %q\n", *autoPause)
+		return 2
+	}
+
+	running, err := timers.Timers()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading timers: %v\n", err)
+		return 1
+	}
+	if len(running) == 0 {
+		fmt.Println("No timer is running.")
+	}
+	now := time.Now()
+	for _, timer := range running {
+		state := "running"
+		if timer.Paused() {
+			state = "paused"
+		}
+		fmt.Printf("⏱️ %s %s, %s so far (since %s)\n", timer.Issue, state, formatDurationForJira(timer.Elapsed(now)), timer.Start.Format("2006-01-02 15:04"))
+		if timer.Profile != "" {
+			fmt.Printf("   Profile: %s\n", timer.Profile)
+		}
+		if timer.Comment != "" {
+			fmt.Printf("   Comment: %s\n", timer.Comment)
+		}
+	}
+	if on, err := timers.AutoPause(); err == nil && on {
+		fmt.Println("Starting a timer pauses the others.")
+	}
+	return 0
+}
+
+// runLogCommand implements `jira-time log`.
+func runLogCommand(args []string) int {
+	flags := newCommandFlags("log", "<KEY> <duration> [-m comment] [-at time]")
+	comment := flags.String("m", "", "worklog `comment`")
+	at := flags.String("at", "", "when the work started, e.g. 09:30 (default: duration ago)")
+	positional, err := parseCommandArgs(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) < 2 {
+		flags.Usage()
+		return 2
+	}
+	issueKey := strings.ToUpper(positional[0])
+	timeSpent := strings.Join(positional[1:], " ")
+	duration := parseDuration(timeSpent)
+	if duration <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: invalid duration %q, use e.g. 1h 30m, 45m or 2h\n", timeSpent)
+		return 2
+	}
+	now := time.Now()
+	start, err := commandTime(*at, now.Add(-duration))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	mustLoadJiraConfig()
+	ctx, stop := commandContext()
+	defer stop()
+	return submitCommandWorklog(ctx, TimeLogEntry{
+		ID:         newTimeLogEntryID(),
+		JiraID:     issueKey,
+		StartTime:  start,
+		EndTime:    start.Add(duration),
+		Duration:   formatDurationForJira(duration),
+		Comment:    activeProfile.worklogComment(*comment),
+		LoggedAt:   now,
+		SyncStatus: SyncPending,
+		Profile:    activeProfile.Name,
+	})
+}
+
+// runIssuesCommand implements `jira-time issues`.
+func runIssuesCommand(args []string) int {
+	flags := newCommandFlags("issues", "[-n count]")
+	count := flags.Int("n", 20, "show at most `count` issues")
+	if _, err := parseCommandArgs(flags, args); err != nil {
+		return 2
+	}
+
+	mustLoadJiraConfig()
+	ctx, stop := commandContext()
+	defer stop()
+	issues := getRecentIssues(ctx, *count)
+	if len(issues) == 0 {
+		fmt.Println("No recent issues found.")
+		return 0
+	}
+
+	timed := make(map[string]bool)
+	if running, err := mustOpenTimerStore().Timers(); err == nil {
+		for _, timer := range running {
+			timed[timer.Issue] = true
+		}
+	}
+	for _, issue := range issues {
+		marker := " "
+		if timed[issue.Key] {
+			marker = "⏱️"
+		}
+		fmt.Printf("%s %-12s %-14s %s\n", marker, issue.Key, "["+issue.Status+"]", issue.Summary)
+	}
+	return 0
+}
+
+// runTransitionCommand implements `jira-time transition`.
+func runTransitionCommand(args []string) int {
+	flags := newCommandFlags("transition", "<KEY> [status]")
+	positional, err := parseCommandArgs(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) < 1 {
+		flags.Usage()
+		return 2
+	}
+	issueKey := strings.ToUpper(positional[0])
+	target := strings.Join(positional[1:], " ")
+
+	mustLoadJiraConfig()
+	ctx, stop := commandContext()
+	defer stop()
+	details, err := loadIssueDetails(ctx, issueKey)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s: %s\n", issueKey, describeJiraError(err))
+		return 1
+	}
+
+	if target == "" {
+		fmt.Printf("%s is %s.\n", issueKey, details.Status.Name)
+		if len(details.Transitions) == 0 {
+			fmt.Println("No transitions available.")
+			return 0
+		}
+		for _, t := range details.Transitions {
+			icon := "←"
+			if t.IsForward {
+				icon = "→"
+			}
+			fmt.Printf("  %s %-20s (%s, id %s)\n", icon, t.To.Name, t.Name, t.ID)
+		}
+		return 0
+	}
+
+	transition, ok := findTransition(details.Transitions, target)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: %s can't move from %s to %q\n", issueKey, details.Status.Name, target)
+		return 1
+	}
+	if err := ExecuteStatusTransition(ctx, issueKey, transition.ID); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to transition %s: %s\n", issueKey, describeJiraError(err))
+		return 1
+	}
+	fmt.Printf("✅ %s: %s → %s\n", issueKey, details.Status.Name, transition.To.Name)
+	return 0
+}
+
+// findTransition picks the transition named by target, which may be the
+// status it leads to, the transition's name or its ID.
+func findTransition(transitions []Transition, target string) (Transition, bool) {
+	for _, t := range transitions {
+		if strings.EqualFold(t.To.Name, target) {
+			return t, true
+		}
+	}
+	for _, t := range transitions {
+		if strings.EqualFold(t.Name, target) || t.ID == target {
+			return t, true
+		}
+	}
+	return Transition{}, false
+}
+
+// runCommentCommand implements `jira-time comment`.
+func runCommentCommand(args []string) int {
+	flags := newCommandFlags("comment", "<KEY> <text>")
+	positional, err := parseCommandArgs(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) < 2 {
+		flags.Usage()
+		return 2
+	}
+	issueKey := strings.ToUpper(positional[0])
+	text := strings.Join(positional[1:], " ")
+
+	mustLoadJiraConfig()
+	ctx, stop := commandContext()
+	defer stop()
+	client := jiraApiFunctions.DefaultClient
+	if _, err := client.AddCommentContext(ctx, issueKey, map[string]interface{}{"body": client.TextBody(text)}); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to comment on %s: %s\n", issueKey, describeJiraError(err))
+		return 1
+	}
+	fmt.Printf("✅ Commented on %s\n", issueKey)
+	return 0
+}
+
+// runReportCommand implements `jira-time report`.
+func runReportCommand(args []string) int {
+	flags := newCommandFlags("report", "[-days N] [-from date] [-to date]")
+	days := flags.Int("days", 7, "report the last `N` days, including today")
+	from := flags.String("from", "", "first `date` to report, e.g. 2024-03-04")
+	to := flags.String("to", "", "last `date` to report (default: today)")
+	if _, err := parseCommandArgs(flags, args); err != nil {
+		return 2
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	last, first := today, today.AddDate(0, 0, 1-*days)
+	for _, date := range []struct {
+		text string
+		into *time.Time
+	}{{*from, &first}, {*to, &last}} {
+		if date.text == "" {
+			continue
+		}
+		t, err := time.ParseInLocation("2006-01-02", date.text, now.Location())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid date %q, use e.g. 2024-03-04\n", date.text)
+			return 2
+		}
+		*date.into = t
+	}
+
+	loadActiveProfile()
+	entries, err := mustOpenTimeLogStore().Entries()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading time logs: %v\n", err)
+		return 1
+	}
+	// With --profile, only report that profile's entries
+	if selectedProfileName != "" {
+		var own []TimeLogEntry
+		for _, entry := range entries {
+			if activeProfile.owns(entry.Profile) {
+				own = append(own, entry)
+			}
+		}
+		entries = own
+	}
+	printReport(os.Stdout, buildReport(entries, first, last.AddDate(0, 0, 1)))
+	return 0
+}
+
+// timeReport totals logged time by day and by issue.
+type timeReport struct {
+	first, end time.Time // end is exclusive
+	days       []reportDay
+	issues     []reportLine
+	total      time.Duration
+	unsynced   int
+}
+
+type reportDay struct {
+	date   time.Time
+	total  time.Duration
+	issues []reportLine
+}
+
+type reportLine struct {
+	issue string
+	total time.Duration
+}
+
+// buildReport totals the entries that started in [first, end).
+func buildReport(entries []TimeLogEntry, first, end time.Time) timeReport {
+	report := timeReport{first: first, end: end}
+	byDay := make(map[string]map[string]time.Duration)
+	byIssue := make(map[string]time.Duration)
+	for _, entry := range entries {
+		if entry.StartTime.Before(first) || !entry.StartTime.Before(end) {
+			continue
+		}
+		spent := entry.spent()
+		day := entry.StartTime.In(first.Location()).Format("2006-01-02")
+		if byDay[day] == nil {
+			byDay[day] = make(map[string]time.Duration)
+		}
+		byDay[day][entry.JiraID] += spent
+		byIssue[entry.JiraID] += spent
+		report.total += spent
+		if entry.SyncStatus == SyncPending || entry.SyncStatus == SyncFailed {
+			report.unsynced++
+		}
+	}
+
+	var dayKeys []string
+	for day := range byDay {
+		dayKeys = append(dayKeys, day)
+	}
+	sort.Strings(dayKeys)
+	for _, day := range dayKeys {
+		date, _ := time.ParseInLocation("2006-01-02", day, first.Location())
+		lines := reportLines(byDay[day])
+		var total time.Duration
+		for _, line := range lines {
+			total += line.total
+		}
+		report.days = append(report.days, reportDay{date: date, total: total, issues: lines})
+	}
+	report.issues = reportLines(byIssue)
+	return report
+}
+
+// reportLines sorts per-issue totals, largest first.
+func reportLines(totals map[string]time.Duration) []reportLine {
+	var lines []reportLine
+	for issue, total := range totals {
+		lines = append(lines, reportLine{issue, total})
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].total != lines[j].total {
+			return lines[i].total > lines[j].total
+		}
+		return lines[i].issue < lines[j].issue
+	})
+	return lines
+}
+
+func printReport(w io.Writer, report timeReport) {
+	fmt.Fprintf(w, "Time logged %s to %s:\n", report.first.Format("2006-01-02"), report.end.AddDate(0, 0, -1).Format("2006-01-02"))
+	fmt.Fprintln(w, strings.Repeat("=", 60))
+	if len(report.days) == 0 {
+		fmt.Fprintln(w, "No time entries in this period.")
+		return
+	}
+	for _, day := range report.days {
+		fmt.Fprintf(w, "%s  %s\n", day.date.Format("Mon 2006-01-02"), formatDurationForJira(day.total))
+		for _, line := range day.issues {
+			fmt.Fprintf(w, "   %-12s %s\n", line.issue, formatDurationForJira(line.total))
+		}
+	}
+	fmt.Fprintln(w, strings.Repeat("-", 60))
+	fmt.Fprintln(w, "By issue:")
+	for _, line := range report.issues {
+		fmt.Fprintf(w, "   %-12s %s\n", line.issue, formatDurationForJira(line.total))
+	}
+	fmt.Fprintf(w, "Total: %s\n", formatDurationForJira(report.total))
+	if report.unsynced > 0 {
+		fmt.Fprintf(w, "%d entries haven't reached Jira yet, see \"jira-time outbox\".\n", report.unsynced)
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"jiraTimeWidget/fakejira"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseCommandArgs(t *testing.T) {
+	flags := newCommandFlags("log", "<KEY> <duration> [-m comment]")
+	comment := flags.String("m", "", "")
+	positional, err := parseCommandArgs(flags, []string{"PROJ-1", "1h", "-m", "Pairing", "30m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"PROJ-1", "1h", "30m"}; !reflect.DeepEqual(positional, want) || *comment != "Pairing" {
+		t.Errorf("Got %v and comment %q", positional, *comment)
+	}
+}
+
+func TestParseAtTime(t *testing.T) {
+	now := time.Date(2024, 3, 4, 17, 45, 30, 0, time.UTC)
+	for in, want := range map[string]time.Time{
+		"09:30":                     time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC),
+		"2024-03-01 14:00":          time.Date(2024, 3, 1, 14, 0, 0, 0, time.UTC),
+		"2024-03-01T14:00:00+01:00": time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC),
+	} {
+		got, err := parseAtTime(in, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseAtTime(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+	if _, err := parseAtTime("half nine", now); err == nil {
+		t.Error("Expected an error for an unreadable time")
+	}
+}
+
+func TestFindTransition(t *testing.T) {
+	transitions := []Transition{
+		{ID: "21", Name: "Start work", To: StatusInfo{Name: "In Progress"}},
+		{ID: "31", Name: "Done", To: StatusInfo{Name: "Closed"}},
+	}
+	for target, want := range map[string]string{"in progress": "21", "start WORK": "21", "31": "31", "done": "31"} {
+		if got, ok := findTransition(transitions, target); !ok || got.ID != want {
+			t.Errorf("findTransition(%q) = %+v, %v, want %s", target, got, ok, want)
+		}
+	}
+	if _, ok := findTransition(transitions, "Review"); ok {
+		t.Error("Expected no transition to Review")
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time { return monday.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour) }
+	entries := []TimeLogEntry{
+		{JiraID: "PROJ-1", StartTime: at(0, 9), EndTime: at(0, 11), Duration: "2h", SyncStatus: SyncSynced},
+		{JiraID: "OPS-7", StartTime: at(0, 13), EndTime: at(0, 13), Duration: "1h 30m", SyncStatus: SyncFailed},
+		{JiraID: "PROJ-1", StartTime: at(1, 9), EndTime: at(1, 9).Add(45 * time.Minute), Duration: "1d"},
+		{JiraID: "PROJ-1", StartTime: at(7, 9), EndTime: at(7, 10), Duration: "1h"},
+	}
+
+	report := buildReport(entries, monday, monday.AddDate(0, 0, 7))
+	if report.total != 4*time.Hour+15*time.Minute || report.unsynced != 1 || len(report.days) != 2 {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+	if day := report.days[0]; day.total != 3*time.Hour+30*time.Minute || day.issues[0].issue != "PROJ-1" || day.issues[1].issue != "OPS-7" {
+		t.Errorf("Unexpected first day %+v", day)
+	}
+	// Durations the log can't read fall back to the timed interval
+	if want := []reportLine{{"PROJ-1", 2*time.Hour + 45*time.Minute}, {"OPS-7", 90 * time.Minute}}; !reflect.DeepEqual(report.issues, want) {
+		t.Errorf("By issue = %+v, want %+v", report.issues, want)
+	}
+}
+
+func TestCommands_FakeJira(t *testing.T) {
+	fake := startFakeSite(t)
+	fake.AddIssue("PROJ-1", "Fix the widget")
+	fake.AddIssue("PROJ-2", "Write the docs")
+	// Commands load .jirarc as the CLI does, which prompts for passphrases
+	originalGetPassphrase := getPassphrase
+	t.Cleanup(func() { getPassphrase = originalGetPassphrase })
+
+	if code := runCommand([]string{"start", "PROJ-1", "-at", time.Now().Add(-time.Hour).Format(time.RFC3339), "-m", "Pairing"}); code != 0 {
+		t.Fatalf("start exited with %d", code)
+	}
+	if code := runCommand([]string{"start", "PROJ-1"}); code != 1 {
+		t.Errorf("Expected start to refuse a second timer on PROJ-1, got %d", code)
+	}
+	if code := runCommand([]string{"start", "PROJ-404"}); code != 1 {
+		t.Errorf("Expected start to refuse an unknown issue, got %d", code)
+	}
+	if code := runCommand([]string{"status"}); code != 0 {
+		t.Errorf("status exited with %d", code)
+	}
+
+	// Switching logs the first timer
+	if code := runCommand([]string{"start", "-switch", "PROJ-2"}); code != 0 {
+		t.Fatalf("start -switch exited with %d", code)
+	}
+	worklogs := fake.Worklogs("PROJ-1")
+	if len(worklogs) != 1 || worklogs[0].TimeSpentSeconds != 3600 || worklogs[0].Comment.Text() != "Pairing" {
+		t.Errorf("Unexpected worklogs on PROJ-1 %+v", worklogs)
+	}
+	if code := runCommand([]string{"resume"}); code != 1 {
+		t.Errorf("Expected resume to fail while running, got %d", code)
+	}
+	if code := runCommand([]string{"pause"}); code != 0 {
+		t.Errorf("pause exited with %d", code)
+	}
+	if code := runCommand([]string{"pause"}); code != 1 {
+		t.Errorf("Expected a second pause to fail, got %d", code)
+	}
+	if code := runCommand([]string{"resume"}); code != 0 {
+		t.Errorf("resume exited with %d", code)
+	}
+	if running, _ := mustOpenTimerStore().Timer("PROJ-2"); running == nil || len(running.Pauses) != 1 || running.Paused() {
+		t.Errorf("Expected one finished pause on the shared timer, got %+v", running)
+	}
+
+	// Timers on several issues run side by side and are named to stop them
+	if code := runCommand([]string{"start", "PROJ-1", "-pause-others"}); code != 0 {
+		t.Fatalf("start -pause-others exited with %d", code)
+	}
+	if running, _ := mustOpenTimerStore().Timer("PROJ-2"); running == nil || !running.Paused() {
+		t.Errorf("Expected PROJ-2 to be paused when PROJ-1 started, got %+v", running)
+	}
+	if code := runCommand([]string{"pause"}); code != 1 {
+		t.Errorf("Expected pause without an issue to fail with two timers, got %d", code)
+	}
+	if code := runCommand([]string{"stop", "proj-1", "-discard"}); code != 0 {
+		t.Errorf("stop PROJ-1 -discard exited with %d", code)
+	}
+	if code := runCommand([]string{"status", "-auto-pause", "maybe"}); code != 2 {
+		t.Errorf("Expected an invalid -auto-pause to fail with 2, got %d", code)
+	}
+	if code := runCommand([]string{"stop", "-discard"}); code != 0 {
+		t.Errorf("stop -discard exited with %d", code)
+	}
+	if code := runCommand([]string{"stop"}); code != 1 {
+		t.Errorf("Expected stop without a timer to fail, got %d", code)
+	}
+
+	// The duration is sent as Jira writes it, whatever the spelling
+	if code := runCommand([]string{"log", "PROJ-2", "1h", "30m15s", "-m", "Docs"}); code != 0 {
+		t.Errorf("log exited with %d", code)
+	}
+	if worklogs := fake.Worklogs("PROJ-2"); len(worklogs) != 1 || worklogs[0].TimeSpentSeconds != 5400 {
+		t.Errorf("Unexpected worklogs on PROJ-2 %+v", worklogs)
+	}
+
+	if code := runCommand([]string{"transition", "PROJ-2", "in", "progress"}); code != 0 {
+		t.Errorf("transition exited with %d", code)
+	}
+	if code := runCommand([]string{"transition", "PROJ-2", "Review"}); code != 1 {
+		t.Errorf("Expected a transition to an unknown status to fail, got %d", code)
+	}
+	if issue, _ := fake.Issue("PROJ-2"); issue.Fields.Status.Name != "In Progress" {
+		t.Errorf("Expected PROJ-2 to be In Progress, got %s", issue.Fields.Status.Name)
+	}
+
+	if code := runCommand([]string{"comment", "PROJ-2", "Ready", "for", "review"}); code != 0 {
+		t.Errorf("comment exited with %d", code)
+	}
+	if comments := fake.Comments("PROJ-2"); len(comments) != 1 || comments[0].Body.Text() != "Ready for review" {
+		t.Errorf("Unexpected comments %+v", comments)
+	}
+
+	// A paused timer logs its active time and records its segments
+	start := time.Now().Add(-3 * time.Hour)
+	if code := runCommand([]string{"start", "PROJ-1", "-at", start.Format(time.RFC3339)}); code != 0 {
+		t.Fatalf("start exited with %d", code)
+	}
+	mustOpenTimerStore().Update("PROJ-1", func(timer *ActiveTimer) {
+		timer.Pauses = []TimerPause{{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)}}
+	})
+	if code := runCommand([]string{"stop", "-at", start.Add(3 * time.Hour).Format(time.RFC3339)}); code != 0 {
+		t.Fatalf("stop exited with %d", code)
+	}
+	if worklogs := fake.Worklogs("PROJ-1"); len(worklogs) != 2 || worklogs[1].TimeSpentSeconds != 7200 {
+		t.Errorf("Expected 2h logged for the paused timer, got %+v", worklogs)
+	}
+
+	entries, err := mustOpenTimeLogStore().Entries()
+	if err != nil || len(entries) != 3 || entries[0].SyncStatus != SyncSynced || entries[1].JiraID != "PROJ-2" || entries[1].Duration != "1h 30m" || len(entries[2].Segments) != 2 {
+		t.Errorf("Unexpected local log %+v, %v", entries, err)
+	}
+	if code := runCommand([]string{"bogus"}); code != 2 {
+		t.Errorf("Expected an unknown command to fail with 2, got %d", code)
+	}
+}
+
+func TestStartSwitch_LogsEachTimerUnderItsProfile(t *testing.T) {
+	withSiteGlobals(t)
+	work, client := fakejira.New(), fakejira.New()
+	var sites []string
+	for _, fake := range []*fakejira.Server{work, client} {
+		fake.APIToken = "fake-token"
+		site := httptest.NewServer(fake)
+		t.Cleanup(site.Close)
+		sites = append(sites, site.URL)
+	}
+	work.AddIssue("PROJ-1", "Fix the widget")
+	client.AddIssue("CLI-1", "Consulting")
+	writeJiraConfig(t, fmt.Sprintf(`{"defaultProfile": "work", "profiles": {
+		"work": {"site": %q, "email": "me@example.com", "jira": "fake-token"},
+		"client": {"site": %q, "email": "me@example.com", "jira": "fake-token"}}}`, sites[0], sites[1]))
+	originalGetPassphrase := getPassphrase
+	t.Cleanup(func() { getPassphrase = originalGetPassphrase })
+
+	selectedProfileName = "work"
+	if code := runCommand([]string{"start", "PROJ-1", "-at", time.Now().Add(-time.Hour).Format(time.RFC3339)}); code != 0 {
+		t.Fatalf("start exited with %d", code)
+	}
+	selectedProfileName = "client"
+	if code := runCommand([]string{"start", "-switch", "CLI-1"}); code != 0 {
+		t.Fatalf("start -switch exited with %d", code)
+	}
+	if worklogs := work.Worklogs("PROJ-1"); len(worklogs) != 1 || worklogs[0].TimeSpentSeconds != 3600 {
+		t.Errorf("Expected the work timer logged to the work site, got %+v", worklogs)
+	}
+	if running, _ := mustOpenTimerStore().Timer(""); running == nil || running.Issue != "CLI-1" || running.Profile != "client" {
+		t.Errorf("Expected only the client timer to run, got %+v", running)
+	}
+}
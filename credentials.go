@@ -0,0 +1,330 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// CredentialStore keeps Jira tokens out of .jirarc. Profiles name their
+// token with "tokenRef" and it is looked up here when the config loads.
+type CredentialStore interface {
+	// Get returns the secret stored under name, or errCredentialNotFound.
+	Get(name string) (string, error)
+	// Set stores secret under name, replacing any earlier one.
+	Set(name, secret string) error
+	// Delete removes the secret stored under name.
+	Delete(name string) error
+	// Backend describes where secrets are kept, for messages.
+	Backend() string
+	Close() error
+}
+
+const (
+	// Values for "credentialStore" in .jirarc. Without one, the Secret
+	// Service is used when available and the encrypted file otherwise.
+	credentialStoreAuto          = ""
+	credentialStoreSecretService = "secret-service"
+	credentialStoreFile          = "file"
+
+	credentialFileName      = ".jira_time_credentials"
+	credentialPassphraseEnv = "JIRA_TIME_PASSPHRASE"
+
+	credentialFileVersion = 1
+	pbkdf2Iterations      = 210000
+)
+
+var (
+	errCredentialNotFound = errors.New("credential not found")
+	errPassphraseRequired = errors.New("a passphrase is needed to unlock the credential file")
+	errWrongPassphrase    = errors.New("wrong passphrase for the credential file")
+)
+
+// credentialPassphrase unlocks the encrypted credential file once it has
+// been entered.
+var credentialPassphrase string
+
+// getPassphrase supplies the passphrase for the encrypted credential file;
+// creating is true when the file is about to be written for the first time.
+// CLI commands replace it with a terminal prompt.
+var getPassphrase = func(creating bool) (string, error) {
+	if credentialPassphrase != "" {
+		return credentialPassphrase, nil
+	}
+	if passphrase := os.Getenv(credentialPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	return "", errPassphraseRequired
+}
+
+// openCredentialStore opens the backend named by "credentialStore".
+func openCredentialStore(kind string) (CredentialStore, error) {
+	switch kind {
+	case credentialStoreSecretService:
+		store, err := openSecretService()
+		if err != nil {
+			return nil, fmt.Errorf("connecting to the Secret Service: %w", err)
+		}
+		return store, nil
+	case credentialStoreFile:
+		return openEncryptedFileStore()
+	case credentialStoreAuto:
+		store, err := openSecretService()
+		if err == nil {
+			return store, nil
+		}
+		log.Printf("Secret Service unavailable, using the encrypted credential file: %v", err)
+		return openEncryptedFileStore()
+	default:
+		return nil, fmt.Errorf("unknown credentialStore %q in .jirarc (use %q or %q)", kind, credentialStoreSecretService, credentialStoreFile)
+	}
+}
+
+// resolveProfileTokens fills in the API key of every profile that keeps
+// its token in a credential store, and loads the OAuth token of OAuth
+// profiles. A token that was never stored is left empty so validation can
+// point at `auth login`.
+func resolveProfileTokens(config *jiraConfig) error {
+	var store CredentialStore
+	for _, name := range config.ProfileNames() {
+		profile := config.Profiles[name]
+		if profile.TokenRef == "" || (profile.APIKey != "" && !profile.usesOAuth()) {
+			continue
+		}
+		if store == nil {
+			var err error
+			if store, err = openCredentialStore(config.CredentialStore); err != nil {
+				return err
+			}
+			defer store.Close()
+		}
+
+		if profile.usesOAuth() {
+			err := loadOAuthTokens(store, config.CredentialStore, profile)
+			if errors.Is(err, errCredentialNotFound) {
+				log.Printf("No OAuth login stored as %q for profile %s", profile.TokenRef, name)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("reading OAuth token %q for profile %s: %w", profile.TokenRef, name, err)
+			}
+			continue
+		}
+
+		token, err := store.Get(profile.TokenRef)
+		if errors.Is(err, errCredentialNotFound) {
+			log.Printf("No token stored as %q for profile %s", profile.TokenRef, name)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading token %q for profile %s: %w", profile.TokenRef, name, err)
+		}
+		profile.APIKey = token
+	}
+	return nil
+}
+
+// encryptedFileStore keeps secrets in a single file encrypted with AES-GCM
+// under a key derived from a passphrase. It is the fallback for systems
+// without a Secret Service.
+type encryptedFileStore struct {
+	path string
+}
+
+// credentialFile is the on-disk format of the encrypted store.
+type credentialFile struct {
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func openEncryptedFileStore() (CredentialStore, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedFileStore{path: filepath.Join(homeDir, credentialFileName)}, nil
+}
+
+func (s *encryptedFileStore) Get(name string) (string, error) {
+	secrets, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := secrets[name]
+	if !ok {
+		return "", errCredentialNotFound
+	}
+	return secret, nil
+}
+
+func (s *encryptedFileStore) Set(name, secret string) error {
+	// Another process may be saving the file between our load and save
+	unlock, err := acquireFileLock(s.path+".lock", true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	secrets, err := s.load()
+	if err != nil {
+		return err
+	}
+	secrets[name] = secret
+	return s.save(secrets)
+}
+
+func (s *encryptedFileStore) Delete(name string) error {
+	unlock, err := acquireFileLock(s.path+".lock", true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	secrets, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[name]; !ok {
+		return errCredentialNotFound
+	}
+	delete(secrets, name)
+	return s.save(secrets)
+}
+
+func (s *encryptedFileStore) Backend() string {
+	return "encrypted file " + s.path
+}
+
+func (s *encryptedFileStore) Close() error {
+	return nil
+}
+
+// load decrypts the store. A missing file is an empty store and needs no
+// passphrase.
+func (s *encryptedFileStore) load() (map[string]string, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file credentialFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", s.path, err)
+	}
+	if file.Version != credentialFileVersion {
+		return nil, fmt.Errorf("%s has unsupported version %d", s.path, file.Version)
+	}
+
+	passphrase, err := getPassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newCredentialCipher(passphrase, file.Salt, file.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, errWrongPassphrase
+	}
+
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", s.path, err)
+	}
+	return secrets, nil
+}
+
+// save encrypts secrets with a fresh salt and nonce and replaces the file.
+func (s *encryptedFileStore) save(secrets map[string]string) error {
+	_, statErr := os.Stat(s.path)
+	passphrase, err := getPassphrase(errors.Is(statErr, os.ErrNotExist))
+	if err != nil {
+		return err
+	}
+
+	file := credentialFile{
+		Version:    credentialFileVersion,
+		Iterations: pbkdf2Iterations,
+		Salt:       make([]byte, 16),
+	}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return err
+	}
+	gcm, err := newCredentialCipher(passphrase, file.Salt, file.Iterations)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	file.Ciphertext = gcm.Seal(nil, file.Nonce, plaintext, nil)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, data, 0600)
+}
+
+func newCredentialCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	if iterations <= 0 {
+		return nil, fmt.Errorf("invalid key derivation iteration count %d", iterations)
+	}
+	block, err := aes.NewCipher(pbkdf2SHA256([]byte(passphrase), salt, iterations, 32))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2SHA256 derives a key from password as described in RFC 8018.
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+
+	key := make([]byte, 0, blocks*hashLen)
+	var counter [4]byte
+	u := make([]byte, hashLen)
+	for block := 1; block <= blocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+		prf.Write(counter[:])
+		key = prf.Sum(key)
+
+		t := key[len(key)-hashLen:]
+		copy(u, t)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range u {
+				t[j] ^= u[j]
+			}
+		}
+	}
+	return key[:keyLen]
+}
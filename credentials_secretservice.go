@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/godbus/dbus/v5"
+	"time"
+)
+
+// The freedesktop Secret Service API, as provided by GNOME Keyring and
+// KWallet: https://specifications.freedesktop.org/secret-service/
+const (
+	secretServiceName      = "org.freedesktop.secrets"
+	secretServicePath      = dbus.ObjectPath("/org/freedesktop/secrets")
+	secretServiceInterface = "org.freedesktop.Secret.Service"
+	secretCollectionIface  = "org.freedesktop.Secret.Collection"
+	secretItemInterface    = "org.freedesktop.Secret.Item"
+	secretPromptInterface  = "org.freedesktop.Secret.Prompt"
+	secretSessionInterface = "org.freedesktop.Secret.Session"
+
+	// secretApplication tags our items so they can be found again.
+	secretApplication = "jira-time"
+
+	// secretPromptTimeout bounds how long we wait for the user to answer
+	// an unlock prompt from the keyring.
+	secretPromptTimeout = 2 * time.Minute
+)
+
+// noPrompt is the path the Secret Service returns when no prompt is needed.
+const noPrompt = dbus.ObjectPath("/")
+
+// secretServiceStore keeps secrets in the user's login keyring over D-Bus.
+type secretServiceStore struct {
+	conn    *dbus.Conn
+	session dbus.ObjectPath
+}
+
+// dbusSecret is the Secret Service's (oayays) secret struct.
+type dbusSecret struct {
+	Session     dbus.ObjectPath
+	Parameters  []byte
+	Value       []byte
+	ContentType string
+}
+
+func openSecretService() (*secretServiceStore, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, err
+	}
+
+	// A "plain" session sends secrets unencrypted, which is fine on the
+	// local session bus.
+	var output dbus.Variant
+	var session dbus.ObjectPath
+	err = conn.Object(secretServiceName, secretServicePath).
+		Call(secretServiceInterface+".OpenSession", 0, "plain", dbus.MakeVariant("")).
+		Store(&output, &session)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &secretServiceStore{conn: conn, session: session}, nil
+}
+
+func (s *secretServiceStore) Get(name string) (string, error) {
+	item, err := s.find(name)
+	if err != nil {
+		return "", err
+	}
+	var secret dbusSecret
+	err = s.conn.Object(secretServiceName, item).
+		Call(secretItemInterface+".GetSecret", 0, s.session).
+		Store(&secret)
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Value), nil
+}
+
+func (s *secretServiceStore) Set(name, secret string) error {
+	var collection dbus.ObjectPath
+	err := s.service().Call(secretServiceInterface+".ReadAlias", 0, "default").Store(&collection)
+	if err != nil {
+		return err
+	}
+	if collection == noPrompt {
+		return errors.New("the keyring has no default collection")
+	}
+	if err := s.unlock(collection); err != nil {
+		return err
+	}
+
+	properties := map[string]dbus.Variant{
+		"org.freedesktop.Secret.Item.Label":      dbus.MakeVariant(fmt.Sprintf("Jira API token (%s)", name)),
+		"org.freedesktop.Secret.Item.Attributes": dbus.MakeVariant(secretAttributes(name)),
+	}
+	value := dbusSecret{
+		Session:     s.session,
+		Value:       []byte(secret),
+		ContentType: "text/plain",
+	}
+
+	var item, prompt dbus.ObjectPath
+	err = s.conn.Object(secretServiceName, collection).
+		Call(secretCollectionIface+".CreateItem", 0, properties, value, true).
+		Store(&item, &prompt)
+	if err != nil {
+		return err
+	}
+	return s.prompt(prompt)
+}
+
+func (s *secretServiceStore) Delete(name string) error {
+	item, err := s.find(name)
+	if err != nil {
+		return err
+	}
+	var prompt dbus.ObjectPath
+	if err := s.conn.Object(secretServiceName, item).Call(secretItemInterface+".Delete", 0).Store(&prompt); err != nil {
+		return err
+	}
+	return s.prompt(prompt)
+}
+
+func (s *secretServiceStore) Backend() string {
+	return "the Secret Service keyring"
+}
+
+func (s *secretServiceStore) Close() error {
+	s.conn.Object(secretServiceName, s.session).Call(secretSessionInterface+".Close", 0)
+	return s.conn.Close()
+}
+
+func (s *secretServiceStore) service() dbus.BusObject {
+	return s.conn.Object(secretServiceName, secretServicePath)
+}
+
+func secretAttributes(name string) map[string]string {
+	return map[string]string{"application": secretApplication, "name": name}
+}
+
+// find returns the item stored under name, unlocking it if necessary.
+func (s *secretServiceStore) find(name string) (dbus.ObjectPath, error) {
+	var unlocked, locked []dbus.ObjectPath
+	err := s.service().Call(secretServiceInterface+".SearchItems", 0, secretAttributes(name)).Store(&unlocked, &locked)
+	if err != nil {
+		return "", err
+	}
+	if len(unlocked) > 0 {
+		return unlocked[0], nil
+	}
+	if len(locked) > 0 {
+		if err := s.unlock(locked[0]); err != nil {
+			return "", err
+		}
+		return locked[0], nil
+	}
+	return "", errCredentialNotFound
+}
+
+// unlock asks the keyring to unlock object, which may prompt the user.
+func (s *secretServiceStore) unlock(object dbus.ObjectPath) error {
+	var unlocked []dbus.ObjectPath
+	var prompt dbus.ObjectPath
+	err := s.service().Call(secretServiceInterface+".Unlock", 0, []dbus.ObjectPath{object}).Store(&unlocked, &prompt)
+	if err != nil {
+		return err
+	}
+	return s.prompt(prompt)
+}
+
+// prompt shows a keyring prompt and waits for the user to answer it.
+func (s *secretServiceStore) prompt(prompt dbus.ObjectPath) error {
+	if prompt == noPrompt || prompt == "" {
+		return nil
+	}
+
+	match := []dbus.MatchOption{
+		dbus.WithMatchObjectPath(prompt),
+		dbus.WithMatchInterface(secretPromptInterface),
+		dbus.WithMatchMember("Completed"),
+	}
+	if err := s.conn.AddMatchSignal(match...); err != nil {
+		return err
+	}
+	defer s.conn.RemoveMatchSignal(match...)
+
+	signals := make(chan *dbus.Signal, 1)
+	s.conn.Signal(signals)
+	defer s.conn.RemoveSignal(signals)
+
+	if err := s.conn.Object(secretServiceName, prompt).Call(secretPromptInterface+".Prompt", 0, "").Err; err != nil {
+		return err
+	}
+
+	timeout := time.NewTimer(secretPromptTimeout)
+	defer timeout.Stop()
+	for {
+		select {
+		case signal := <-signals:
+			if signal.Path != prompt || len(signal.Body) == 0 {
+				continue
+			}
+			if dismissed, _ := signal.Body[0].(bool); dismissed {
+				return errors.New("the keyring prompt was dismissed")
+			}
+			return nil
+		case <-timeout.C:
+			return errors.New("timed out waiting for the keyring prompt")
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withPassphrase sets the credential file passphrase for a test.
+func withPassphrase(t *testing.T, passphrase string) {
+	original, originalGet := credentialPassphrase, getPassphrase
+	credentialPassphrase = passphrase
+	t.Cleanup(func() { credentialPassphrase, getPassphrase = original, originalGet })
+	t.Setenv(credentialPassphraseEnv, "")
+}
+
+func TestPBKDF2SHA256(t *testing.T) {
+	// Test vectors from RFC 7914, section 11, and the PBKDF2-HMAC-SHA256
+	// set commonly used alongside RFC 6070.
+	for _, tc := range []struct {
+		password, salt string
+		iterations     int
+		keyLen         int
+		want           string
+	}{
+		{"password", "salt", 1, 32, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{"password", "salt", 2, 32, "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"},
+		{"password", "salt", 4096, 32, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+		{"passwd", "salt", 1, 64, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+	} {
+		got := hex.EncodeToString(pbkdf2SHA256([]byte(tc.password), []byte(tc.salt), tc.iterations, tc.keyLen))
+		if got != tc.want {
+			t.Errorf("pbkdf2(%q, %q, %d) = %s, expected %s", tc.password, tc.salt, tc.iterations, got, tc.want)
+		}
+	}
+}
+
+func TestEncryptedFileStore(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHomeDir := os.Getenv("HOME")
+	os.Setenv("HOME", tempDir)
+	defer os.Setenv("HOME", originalHomeDir)
+
+	withPassphrase(t, "")
+	store, _ := openEncryptedFileStore()
+
+	// A missing file needs no passphrase to report that nothing is stored
+	if _, err := store.Get("work"); !errors.Is(err, errCredentialNotFound) {
+		t.Fatalf("Expected errCredentialNotFound, got %v", err)
+	}
+	if err := store.Set("work", "secret-token"); !errors.Is(err, errPassphraseRequired) {
+		t.Fatalf("Expected errPassphraseRequired, got %v", err)
+	}
+
+	credentialPassphrase = "correct horse"
+	if err := store.Set("work", "secret-token"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := store.Set("client", "other-token"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(tempDir, credentialFileName))
+	if strings.Contains(string(data), "secret-token") {
+		t.Error("Expected the token to be encrypted on disk")
+	}
+	if info, err := os.Stat(filepath.Join(tempDir, credentialFileName)); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected a 0600 credential file, got %v (%v)", info.Mode().Perm(), err)
+	}
+
+	if got, err := store.Get("work"); err != nil || got != "secret-token" {
+		t.Errorf("Expected secret-token, got %q (%v)", got, err)
+	}
+
+	credentialPassphrase = "wrong"
+	if _, err := store.Get("work"); !errors.Is(err, errWrongPassphrase) {
+		t.Errorf("Expected errWrongPassphrase, got %v", err)
+	}
+
+	credentialPassphrase = "correct horse"
+	if err := store.Delete("work"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Get("work"); !errors.Is(err, errCredentialNotFound) {
+		t.Errorf("Expected the deleted token to be gone, got %v", err)
+	}
+	if got, _ := store.Get("client"); got != "other-token" {
+		t.Errorf("Expected the other token to survive, got %q", got)
+	}
+}
+
+// Tokens saved at the same time from separate processes, such as two auth
+// logins, are all kept.
+func TestEncryptedFileStore_ConcurrentSets(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	withPassphrase(t, "correct horse")
+
+	names := []string{"work", "client", "home"}
+	errs := make(chan error, len(names))
+	for _, name := range names {
+		go func(name string) {
+			store, _ := openEncryptedFileStore()
+			errs <- store.Set(name, name+"-token")
+		}(name)
+	}
+	for range names {
+		if err := <-errs; err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+
+	store, _ := openEncryptedFileStore()
+	for _, name := range names {
+		if got, err := store.Get(name); err != nil || got != name+"-token" {
+			t.Errorf("Expected %s-token, got %q (%v)", name, got, err)
+		}
+	}
+}
+
+func TestLoadJiraConfig_TokenRef(t *testing.T) {
+	withSiteGlobals(t)
+	writeJiraConfig(t, `{"credentialStore":"file","defaultProfile":"work","profiles":{
+		"work":{"site":"https://work.atlassian.net","tokenRef":"work-token"},
+		"client":{"site":"https://client.atlassian.net","tokenRef":"missing"}}}`)
+	withPassphrase(t, "pass")
+
+	store, _ := openEncryptedFileStore()
+	if err := store.Set("work-token", "from-the-store"); err != nil {
+		t.Fatal(err)
+	}
+
+	selectedProfileName = ""
+	if err := loadJiraConfig(); err != nil {
+		t.Fatalf("loadJiraConfig failed: %v", err)
+	}
+	if jiraApiKey != "from-the-store" {
+		t.Errorf("Expected the stored token, got %q", jiraApiKey)
+	}
+
+	// A token that was never saved is reported by validation
+	selectedProfileName = "client"
+	loadJiraConfig()
+	if err := validateJiraConfig(context.Background()); err == nil || !strings.Contains(err.Error(), "auth login") {
+		t.Errorf("Expected a hint to run auth login, got %v", err)
+	}
+
+	credentialPassphrase = ""
+	if err := loadJiraConfig(); !errors.Is(err, errPassphraseRequired) {
+		t.Errorf("Expected errPassphraseRequired, got %v", err)
+	}
+}
+
+func TestAuthLogin(t *testing.T) {
+	withSiteGlobals(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer typed-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"displayName":"Test User","emailAddress":"test@example.com"}`))
+	}))
+	defer server.Close()
+
+	writeJiraConfig(t, `{"credentialStore":"file","profiles":{
+		"work":{"site":"`+server.URL+`","jira":"plain-text-token","jql":"project = W"}}}`)
+	withPassphrase(t, "")
+	originalReader := stdinReader
+	defer func() { stdinReader = originalReader }()
+
+	// A rejected token is not saved
+	stdinReader = bufio.NewReader(strings.NewReader("bad-token\n"))
+	if code := runAuthCommand([]string{"login"}); code != 1 {
+		t.Errorf("Expected a rejected token to fail, got exit code %d", code)
+	}
+
+	stdinReader = bufio.NewReader(strings.NewReader("typed-token\npass\npass\n"))
+	if code := runAuthCommand([]string{"login"}); code != 0 {
+		t.Fatalf("Expected login to succeed, got exit code %d", code)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(os.Getenv("HOME"), ".jirarc"))
+	var config struct {
+		Profiles map[string]map[string]string `json:"profiles"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatalf("Rewritten .jirarc is invalid: %v", err)
+	}
+	work := config.Profiles["work"]
+	if work["tokenRef"] != "work" || work["jira"] != "" || work["jql"] != "project = W" {
+		t.Errorf("Unexpected profile after login: %v", work)
+	}
+
+	credentialPassphrase = "pass"
+	store, _ := openEncryptedFileStore()
+	if got, err := store.Get("work"); err != nil || got != "typed-token" {
+		t.Errorf("Expected the typed token in the store, got %q (%v)", got, err)
+	}
+}
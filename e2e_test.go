@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"jiraTimeWidget/fakejira"
+	"jiraTimeWidget/jiraApiFunctions"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// startFakeSite serves a fake Jira and points a .jirarc at it.
+func startFakeSite(t *testing.T) *fakejira.Server {
+	t.Helper()
+	withSiteGlobals(t)
+	fake := fakejira.New()
+	fake.APIToken = "fake-token"
+	site := httptest.NewServer(fake)
+	t.Cleanup(site.Close)
+	writeJiraConfig(t, fmt.Sprintf(`{"site": %q, "email": "me@example.com", "jira": "fake-token"}`, site.URL))
+	return fake
+}
+
+func TestEndToEnd_FakeJira(t *testing.T) {
+	fake := startFakeSite(t)
+	fake.AddIssue("PROJ-1", "Fix the widget")
+	fake.AddIssue("PROJ-2", "Write the docs")
+	fake.SetStatus("PROJ-2", "Done")
+	fake.SetEstimate("PROJ-1", 4*time.Hour)
+	ctx := context.Background()
+
+	if err := setupJiraConfig(ctx); err != nil {
+		t.Fatalf("setupJiraConfig failed against the fake site: %v", err)
+	}
+	if jiraCloudId != fake.CloudID {
+		t.Errorf("Expected the cloud ID to be discovered as %q, got %q", fake.CloudID, jiraCloudId)
+	}
+
+	issues := getRecentIssues(ctx, 20)
+	if len(issues) != 2 || issues[0].Key != "PROJ-2" || issues[1].Status != "To Do" {
+		t.Errorf("Unexpected recent issues %+v", issues)
+	}
+
+	details, err := loadIssueDetails(ctx, "PROJ-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.Status.Name != "To Do" || len(details.Transitions) != 2 {
+		t.Errorf("Unexpected details %+v", details)
+	}
+
+	started := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	id, err := logWorkToJira(ctx, jiraApiFunctions.DefaultClient, "PROJ-1", "1h 30m", "Pairing", started)
+	if err != nil || id == "" {
+		t.Fatalf("logWorkToJira = %q, %v", id, err)
+	}
+	worklogs := fake.Worklogs("PROJ-1")
+	if len(worklogs) != 1 || worklogs[0].ID != id || worklogs[0].TimeSpentSeconds != 5400 || !worklogs[0].Started.Equal(started) {
+		t.Errorf("Unexpected worklogs on the server %+v", worklogs)
+	}
+
+	if err := ExecuteStatusTransition(ctx, "PROJ-1", "21"); err != nil {
+		t.Fatal(err)
+	}
+	if issue, _ := fake.Issue("PROJ-1"); issue.Fields.Status.Name != "In Progress" {
+		t.Errorf("Expected PROJ-1 to be In Progress, got %s", issue.Fields.Status.Name)
+	}
+	details, err = loadIssueDetails(ctx, "PROJ-1")
+	if err != nil || details.Status.Name != "In Progress" || details.describeTimeTracking() != "1h 30m logged of 4h" {
+		t.Errorf("Expected the new status and logged time, got %+v, %v", details, err)
+	}
+}
@@ -0,0 +1,30 @@
+// Command fakejira serves an in-memory Jira site with a few issues, so the
+// widget can be run locally without a real Jira. Point a profile at it:
+//
+//	"fake": {"site": "http://localhost:8089", "jira": "fake-token"}
+package main
+
+import (
+	"flag"
+	"jiraTimeWidget/fakejira"
+	"log"
+	"net/http"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8089", "address to listen on")
+	token := flag.String("token", "", "only accept this API token (default: any)")
+	flag.Parse()
+
+	fake := fakejira.New()
+	fake.APIToken = *token
+	fake.AddIssue("DEMO-1", "Release 2.4 to production")
+	fake.AddIssue("DEMO-2", "Pair on the reporting export")
+	fake.AddIssue("DEMO-3", "Write the upgrade notes")
+	fake.SetEstimate("DEMO-1", 8*time.Hour)
+	fake.SetStatus("DEMO-1", "In Progress")
+
+	log.Printf("Fake Jira listening on http://%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, fake))
+}
@@ -0,0 +1,61 @@
+package fakejira
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Jira's default time tracking units: a day is 8 hours and a week 5 days.
+var durationUnits = []struct {
+	suffix  string
+	seconds int
+}{
+	{"w", 5 * 8 * 3600},
+	{"d", 8 * 3600},
+	{"h", 3600},
+	{"m", 60},
+}
+
+// parseDuration reads a duration as Jira writes it, e.g. "1d 2h 30m".
+func parseDuration(s string) (int, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("empty duration")
+	}
+	total := 0
+	for _, field := range fields {
+		matched := false
+		for _, unit := range durationUnits {
+			if !strings.HasSuffix(field, unit.suffix) {
+				continue
+			}
+			n, err := strconv.ParseFloat(strings.TrimSuffix(field, unit.suffix), 64)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			total += int(n * float64(unit.seconds))
+			matched = true
+			break
+		}
+		if !matched {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+	}
+	return total, nil
+}
+
+// formatDuration writes seconds the way Jira does, e.g. "1d 2h 30m".
+func formatDuration(seconds int) string {
+	var parts []string
+	for _, unit := range durationUnits {
+		if n := seconds / unit.seconds; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, unit.suffix))
+			seconds -= n * unit.seconds
+		}
+	}
+	if len(parts) == 0 {
+		return "0m"
+	}
+	return strings.Join(parts, " ")
+}
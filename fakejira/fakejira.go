@@ -0,0 +1,240 @@
+// Package fakejira is an in-memory Jira Cloud site for tests and local
+// development. It serves the part of REST API v3 and of the GraphQL gateway
+// the widget uses: JQL search, issues, transitions through a configurable
+// workflow, worklogs, comments and the current user.
+//
+// Tests create a Server, seed it with AddIssue, point a client at it with
+// httptest.NewServer and then check what was logged or transitioned:
+//
+//	fake := fakejira.New()
+//	fake.AddIssue("PROJ-1", "Fix the widget")
+//	site := httptest.NewServer(fake)
+//	defer site.Close()
+//	client := jiraApiFunctions.NewClient(site.URL, "", "token")
+package fakejira
+
+import (
+	"fmt"
+	"jiraTimeWidget/jiraModels"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is a fake Jira site. It implements http.Handler.
+type Server struct {
+	// CloudID is reported by tenant info and expected by GraphQL queries.
+	CloudID string
+	// Me is the user the credentials belong to. Issues are assigned to
+	// them and worklogs and comments are written as them.
+	Me jiraModels.User
+	// APIToken, when set, is the only token accepted, as a Bearer token or
+	// the password of Basic auth. Otherwise any credentials are.
+	APIToken string
+	// Now is the clock used for timestamps; it defaults to time.Now.
+	Now func() time.Time
+
+	mu       sync.Mutex
+	workflow Workflow
+	issues   map[string]*issueState
+	nextID   int
+	requests []string
+}
+
+// issueState is an issue with its worklogs and comments.
+type issueState struct {
+	issue            jiraModels.Issue
+	originalEstimate int
+	worklogs         []jiraModels.Worklog
+	comments         []jiraModels.Comment
+}
+
+// New returns an empty site using DefaultWorkflow.
+func New() *Server {
+	return &Server{
+		CloudID: "fake-cloud-id",
+		Me: jiraModels.User{
+			AccountID:    "fake-account-id",
+			DisplayName:  "Fake User",
+			EmailAddress: "fake.user@example.com",
+			Active:       true,
+			TimeZone:     "UTC",
+		},
+		workflow: DefaultWorkflow(),
+		issues:   map[string]*issueState{},
+		nextID:   10000,
+	}
+}
+
+func (s *Server) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// newID returns the next issue, worklog or comment ID. The caller holds
+// s.mu.
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprint(s.nextID)
+}
+
+// SetWorkflow replaces the workflow. Issues keep their status, so set it
+// before adding issues.
+func (s *Server) SetWorkflow(workflow Workflow) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workflow = workflow
+}
+
+// AddIssue creates an issue assigned to Me, in the workflow's initial
+// status and the project its key names.
+func (s *Server) AddIssue(key, summary string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project := key
+	if i := strings.LastIndex(key, "-"); i > 0 {
+		project = key[:i]
+	}
+	me := s.Me
+	now := jiraModels.Time{Time: s.now()}
+	status := s.workflow.Statuses[0]
+	s.issues[key] = &issueState{issue: jiraModels.Issue{
+		ID:  s.newID(),
+		Key: key,
+		Fields: jiraModels.IssueFields{
+			Summary:   summary,
+			Status:    &status,
+			IssueType: &jiraModels.IssueType{ID: "10001", Name: "Task"},
+			Project:   &jiraModels.Project{ID: "10000", Key: project, Name: project},
+			Assignee:  &me,
+			Reporter:  &me,
+			Created:   now,
+			Updated:   now,
+		},
+	}}
+}
+
+// SetStatus moves an issue straight to the named status, without a
+// transition.
+func (s *Server) SetStatus(key, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.issues[key]
+	if !ok {
+		return fmt.Errorf("no issue %s", key)
+	}
+	to, ok := s.workflow.status(status)
+	if !ok {
+		return fmt.Errorf("no status %q in the workflow", status)
+	}
+	state.issue.Fields.Status = &to
+	state.touch(s.now())
+	return nil
+}
+
+// SetEstimate sets an issue's original estimate.
+func (s *Server) SetEstimate(key string, estimate time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.issues[key]
+	if !ok {
+		return fmt.Errorf("no issue %s", key)
+	}
+	state.originalEstimate = int(estimate / time.Second)
+	return nil
+}
+
+// Issue returns a copy of an issue, with its time tracking filled in.
+func (s *Server) Issue(key string) (jiraModels.Issue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.issues[key]
+	if !ok {
+		return jiraModels.Issue{}, false
+	}
+	return state.snapshot(), true
+}
+
+// Worklogs returns the worklogs of an issue, oldest first.
+func (s *Server) Worklogs(key string) []jiraModels.Worklog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if state, ok := s.issues[key]; ok {
+		return append([]jiraModels.Worklog(nil), state.worklogs...)
+	}
+	return nil
+}
+
+// Comments returns the comments on an issue, oldest first.
+func (s *Server) Comments(key string) []jiraModels.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if state, ok := s.issues[key]; ok {
+		return append([]jiraModels.Comment(nil), state.comments...)
+	}
+	return nil
+}
+
+// Requests returns the requests served so far, as "METHOD /path".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// sortedIssues returns the issues most recently updated first. The caller
+// holds s.mu.
+func (s *Server) sortedIssues() []*issueState {
+	issues := make([]*issueState, 0, len(s.issues))
+	for _, state := range s.issues {
+		issues = append(issues, state)
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		a, b := issues[i].issue.Fields.Updated.Time, issues[j].issue.Fields.Updated.Time
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return issues[i].issue.Key < issues[j].issue.Key
+	})
+	return issues
+}
+
+func (st *issueState) touch(now time.Time) {
+	st.issue.Fields.Updated = jiraModels.Time{Time: now}
+}
+
+// timeSpent totals the issue's worklogs in seconds.
+func (st *issueState) timeSpent() int {
+	total := 0
+	for _, worklog := range st.worklogs {
+		total += worklog.TimeSpentSeconds
+	}
+	return total
+}
+
+// snapshot returns a copy of the issue with its time tracking computed
+// from the estimate and worklogs.
+func (st *issueState) snapshot() jiraModels.Issue {
+	issue := st.issue
+	spent := st.timeSpent()
+	remaining := st.originalEstimate - spent
+	if remaining < 0 {
+		remaining = 0
+	}
+	issue.Fields.TimeSpent = spent
+	issue.Fields.TimeTracking = &jiraModels.TimeTracking{
+		TimeSpentSeconds:         spent,
+		TimeSpent:                formatDuration(spent),
+		OriginalEstimateSeconds:  st.originalEstimate,
+		RemainingEstimateSeconds: remaining,
+	}
+	if st.originalEstimate > 0 {
+		issue.Fields.TimeTracking.OriginalEstimate = formatDuration(st.originalEstimate)
+		issue.Fields.TimeTracking.RemainingEstimate = formatDuration(remaining)
+	}
+	return issue
+}
@@ -0,0 +1,226 @@
+package fakejira
+
+import (
+	"context"
+	"jiraTimeWidget/jiraApiFunctions"
+	"jiraTimeWidget/jiraModels"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// startFake serves a fake with two issues and returns a client for it.
+func startFake(t *testing.T) (*Server, *jiraApiFunctions.Client) {
+	t.Helper()
+	fake := New()
+	now := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	fake.Now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+	fake.AddIssue("PROJ-1", "Fix the widget")
+	fake.AddIssue("OPS-7", "Babysit the deploy")
+	site := httptest.NewServer(fake)
+	t.Cleanup(site.Close)
+	client := jiraApiFunctions.NewClient(site.URL, "me@example.com", "token")
+	client.Retry = jiraApiFunctions.NoRetries
+	return fake, client
+}
+
+func transition(id string) map[string]interface{} {
+	return map[string]interface{}{"transition": map[string]string{"id": id}}
+}
+
+func TestSearch(t *testing.T) {
+	fake, client := startFake(t)
+	ctx := context.Background()
+	fake.SetStatus("OPS-7", "Done")
+
+	keys := func(jql string) []string {
+		issues, err := client.Search(ctx, jql, []string{"summary", "status"}, jiraApiFunctions.PageOptions{PageSize: 1}).All()
+		if err != nil {
+			t.Fatalf("Search(%q) failed: %v", jql, err)
+		}
+		var keys []string
+		for _, issue := range issues {
+			keys = append(keys, issue.Key)
+		}
+		return keys
+	}
+
+	for jql, want := range map[string][]string{
+		"assignee = currentUser() ORDER BY updated DESC": {"OPS-7", "PROJ-1"},
+		"project = PROJ":                           {"PROJ-1"},
+		`status NOT IN (Done, "On Hold")`:          {"PROJ-1"},
+		"key in (PROJ-1, OPS-7) AND status = Done": {"OPS-7"},
+		"assignee = someone-else":                  nil,
+		"(status != Closed OR updated >= -7d)":     {"OPS-7", "PROJ-1"},
+	} {
+		if got := keys(jql); len(got) != len(want) || (len(want) > 0 && got[0] != want[0]) {
+			t.Errorf("Search(%q) = %v, want %v", jql, got, want)
+		}
+	}
+}
+
+func TestIssueAndTransitions(t *testing.T) {
+	fake, client := startFake(t)
+	ctx := context.Background()
+	fake.SetEstimate("PROJ-1", 8*time.Hour)
+
+	issue, err := client.Issue(ctx, "PROJ-1", []string{"summary", "status", "timetracking"}, "transitions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.Fields.Summary != "Fix the widget" || issue.Fields.Status.Name != "To Do" || issue.Fields.Assignee != nil {
+		t.Errorf("Unexpected issue %+v", issue.Fields)
+	}
+	if issue.Fields.TimeTracking.OriginalEstimate != "1d" || len(issue.Transitions) != 2 {
+		t.Errorf("Unexpected time tracking %+v or transitions %+v", issue.Fields.TimeTracking, issue.Transitions)
+	}
+
+	if _, err := client.TransitionIssueContext(ctx, "PROJ-1", transition("31")); err != nil {
+		t.Fatal(err)
+	}
+	if issue, _ := fake.Issue("PROJ-1"); issue.Fields.Status.Name != "Done" {
+		t.Errorf("Expected PROJ-1 to be Done, got %s", issue.Fields.Status.Name)
+	}
+	if _, err := client.TransitionIssueContext(ctx, "PROJ-1", transition("31")); jiraApiFunctions.StatusCode(err) != http.StatusBadRequest {
+		t.Errorf("Expected a transition to the current status to be rejected, got %v", err)
+	}
+	if _, err := client.Issue(ctx, "PROJ-404", nil, ""); !jiraApiFunctions.IsNotFound(err) {
+		t.Errorf("Expected 404 for an unknown issue, got %v", err)
+	}
+}
+
+func TestCustomWorkflow(t *testing.T) {
+	fake := New()
+	review := DefaultWorkflow()
+	review.Statuses = append(review.Statuses, newStatus("10003", "In Review", jiraModels.StatusCategoryInProgress))
+	review.Transitions = []WorkflowTransition{
+		{ID: "21", Name: "Start", From: []string{"To Do"}, To: "In Progress"},
+		{ID: "41", Name: "Review", From: []string{"In Progress"}, To: "In Review"},
+	}
+	fake.SetWorkflow(review)
+	fake.AddIssue("PROJ-1", "Fix the widget")
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if got := fake.workflow.available("To Do"); len(got) != 1 || got[0].To.Name != "In Progress" {
+		t.Errorf("Unexpected transitions out of To Do: %+v", got)
+	}
+	if got := fake.workflow.available("In Review"); len(got) != 0 {
+		t.Errorf("Expected no way out of In Review, got %+v", got)
+	}
+}
+
+func TestWorklogs(t *testing.T) {
+	fake, client := startFake(t)
+	ctx := context.Background()
+	started := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	worklog, err := client.CreateWorklog(ctx, "PROJ-1", map[string]interface{}{
+		"timeSpent": "1h 30m",
+		"started":   started.Format(jiraModels.TimeFormat),
+		"comment":   client.TextBody("Pairing"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if worklog.TimeSpentSeconds != 5400 || !worklog.Started.Equal(started) || worklog.Comment.Text() != "Pairing" {
+		t.Errorf("Unexpected worklog %+v", worklog)
+	}
+	if _, err := client.UpdateWorklogContext(ctx, "PROJ-1", worklog.ID, map[string]interface{}{"timeSpent": "2h"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateWorklog(ctx, "PROJ-1", map[string]interface{}{"timeSpent": "soon"}); jiraApiFunctions.StatusCode(err) != http.StatusBadRequest {
+		t.Errorf("Expected an invalid duration to be rejected, got %v", err)
+	}
+
+	logs, err := client.Worklogs(ctx, "PROJ-1", jiraApiFunctions.PageOptions{}).All()
+	if err != nil || len(logs) != 1 || logs[0].TimeSpent != "2h" {
+		t.Errorf("Unexpected worklogs %+v, %v", logs, err)
+	}
+	if issue, _ := fake.Issue("PROJ-1"); issue.Fields.TimeTracking.TimeSpentSeconds != 7200 {
+		t.Errorf("Expected 2h spent, got %+v", issue.Fields.TimeTracking)
+	}
+
+	if _, err := client.DeleteWorklogContext(ctx, "PROJ-1", worklog.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := fake.Worklogs("PROJ-1"); len(got) != 0 {
+		t.Errorf("Expected the worklog to be deleted, got %+v", got)
+	}
+}
+
+func TestCommentsAndMyself(t *testing.T) {
+	fake, client := startFake(t)
+	ctx := context.Background()
+
+	if _, err := client.AddCommentContext(ctx, "PROJ-1", map[string]interface{}{"body": client.TextBody("Deployed")}); err != nil {
+		t.Fatal(err)
+	}
+	comments, err := client.Comments(ctx, "PROJ-1", jiraApiFunctions.PageOptions{}).All()
+	if err != nil || len(comments) != 1 || comments[0].Body.Text() != "Deployed" || comments[0].Author.AccountID != fake.Me.AccountID {
+		t.Errorf("Unexpected comments %+v, %v", comments, err)
+	}
+
+	me, err := client.Myself(ctx)
+	if err != nil || me.AccountID != fake.Me.AccountID {
+		t.Errorf("Myself = %+v, %v", me, err)
+	}
+}
+
+func TestGraphQLIssueDetails(t *testing.T) {
+	fake, client := startFake(t)
+	fake.SetEstimate("PROJ-1", 2*time.Hour)
+
+	var data struct {
+		Jira struct {
+			IssueByKey struct {
+				Key    string
+				Fields struct {
+					Edges []struct {
+						Node map[string]interface{}
+					}
+				}
+			}
+		}
+	}
+	variables := map[string]string{"cloudId": fake.CloudID, "key": "PROJ-1"}
+	if _, err := client.GraphQLQuery(context.Background(), "issueDetails", variables, &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Jira.IssueByKey.Key != "PROJ-1" || len(data.Jira.IssueByKey.Fields.Edges) != 4 {
+		t.Errorf("Unexpected issue %+v", data.Jira.IssueByKey)
+	}
+
+	variables["cloudId"] = "other"
+	if _, err := client.GraphQLQuery(context.Background(), "issueDetails", variables, &data); !jiraApiFunctions.IsGraphQLError(err) {
+		t.Errorf("Expected a GraphQL error for another cloud ID, got %v", err)
+	}
+}
+
+func TestAPIToken(t *testing.T) {
+	fake, client := startFake(t)
+	fake.APIToken = "secret"
+	if _, err := client.Myself(context.Background()); !jiraApiFunctions.IsUnauthorized(err) {
+		t.Errorf("Expected the wrong token to be rejected, got %v", err)
+	}
+	client.APIKey = "secret"
+	if _, err := client.Myself(context.Background()); err != nil {
+		t.Errorf("Expected the right token to be accepted, got %v", err)
+	}
+}
+
+func TestDurations(t *testing.T) {
+	for text, seconds := range map[string]int{"1h": 3600, "1d 2h 30m": 37800, "1w": 144000, "0.5h": 1800} {
+		got, err := parseDuration(text)
+		if err != nil || got != seconds {
+			t.Errorf("parseDuration(%q) = %d, %v", text, got, err)
+		}
+	}
+	if got := formatDuration(37800); got != "1d 2h 30m" {
+		t.Errorf("formatDuration = %q", got)
+	}
+}
@@ -0,0 +1,126 @@
+package fakejira
+
+import (
+	"encoding/json"
+	"fmt"
+	"jiraTimeWidget/jiraModels"
+	"net/http"
+	"strconv"
+)
+
+// serveGraphQL answers the widget's named GraphQL operations. The query
+// text isn't parsed: the operation name decides the shape of the answer.
+func (s *Server) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		OperationName string `json:"operationName"`
+		Variables     struct {
+			CloudID string `json:"cloudId"`
+			Key     string `json:"key"`
+		} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeGraphQLError(w, nil, "Invalid request: "+err.Error())
+		return
+	}
+
+	switch request.OperationName {
+	case "currentUser":
+		writeGraphQL(w, map[string]interface{}{"me": map[string]interface{}{"user": s.accountUser()}})
+	case "issueDetails":
+		if request.Variables.CloudID != s.CloudID {
+			writeGraphQLError(w, []interface{}{"jira", "issueByKey"}, fmt.Sprintf("Cloud ID %q does not match this site", request.Variables.CloudID))
+			return
+		}
+		state, ok := s.issues[request.Variables.Key]
+		if !ok {
+			writeGraphQL(w, map[string]interface{}{"jira": map[string]interface{}{"issueByKey": nil}})
+			return
+		}
+		writeGraphQL(w, map[string]interface{}{"jira": map[string]interface{}{"issueByKey": s.issueNode(siteURL(r), state)}})
+	default:
+		writeGraphQLError(w, nil, fmt.Sprintf("The fake site doesn't know the operation %q", request.OperationName))
+	}
+}
+
+func (s *Server) accountUser() map[string]interface{} {
+	return map[string]interface{}{
+		"accountId":     s.Me.AccountID,
+		"accountStatus": "active",
+		"name":          s.Me.DisplayName,
+		"picture":       "",
+	}
+}
+
+// issueNode renders an issue as issueDetails selects it.
+func (s *Server) issueNode(site string, st *issueState) map[string]interface{} {
+	issue := st.snapshot()
+	fields := issue.Fields
+
+	var transitions []interface{}
+	for _, t := range s.workflow.available(fields.Status.Name) {
+		id, _ := strconv.Atoi(t.ID)
+		transitions = append(transitions, map[string]interface{}{"node": map[string]interface{}{
+			"transitionId": id,
+			"name":         t.Name,
+			"to":           statusNode(t.To),
+		}})
+	}
+	var assignee interface{}
+	if fields.Assignee != nil {
+		assignee = s.accountUser()
+	}
+	seconds := func(n int) interface{} {
+		if n == 0 {
+			return nil
+		}
+		return map[string]int{"timeInSeconds": n}
+	}
+
+	edges := []interface{}{
+		map[string]interface{}{"node": map[string]interface{}{
+			"__typename": "JiraSingleLineTextField", "fieldId": "summary", "name": "Summary", "text": fields.Summary,
+		}},
+		map[string]interface{}{"node": map[string]interface{}{
+			"__typename": "JiraStatusField", "fieldId": "status", "name": "Status",
+			"status": statusNode(*fields.Status), "transitions": map[string]interface{}{"edges": transitions},
+		}},
+		map[string]interface{}{"node": map[string]interface{}{
+			"__typename": "JiraSingleSelectUserPickerField", "fieldId": "assignee", "name": "Assignee", "user": assignee,
+		}},
+		map[string]interface{}{"node": map[string]interface{}{
+			"__typename": "JiraTimeTrackingField", "fieldId": "timetracking", "name": "Time tracking",
+			"originalEstimate":  seconds(fields.TimeTracking.OriginalEstimateSeconds),
+			"remainingEstimate": seconds(fields.TimeTracking.RemainingEstimateSeconds),
+			"timeSpent":         seconds(fields.TimeTracking.TimeSpentSeconds),
+		}},
+	}
+	return map[string]interface{}{
+		"issueId": issue.ID,
+		"key":     issue.Key,
+		"webUrl":  site + "/browse/" + issue.Key,
+		"fields":  map[string]interface{}{"edges": edges},
+	}
+}
+
+func statusNode(status jiraModels.Status) map[string]interface{} {
+	return map[string]interface{}{
+		"statusId":       status.ID,
+		"name":           status.Name,
+		"description":    status.Description,
+		"statusCategory": map[string]string{"key": status.StatusCategory.Key},
+	}
+}
+
+func writeGraphQL(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":       data,
+		"extensions": map[string]interface{}{"gateway": map[string]string{"request_id": "fakejira"}},
+	})
+}
+
+func writeGraphQLError(w http.ResponseWriter, path []interface{}, message string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":   nil,
+		"errors": []interface{}{map[string]interface{}{"message": message, "path": path}},
+	})
+}
@@ -0,0 +1,101 @@
+package fakejira
+
+import (
+	"regexp"
+	"strings"
+)
+
+// jqlClause is one condition of a query.
+type jqlClause struct {
+	field  string
+	negate bool
+	values []string
+}
+
+var (
+	jqlOrderBy   = regexp.MustCompile(`(?i)\s+ORDER\s+BY\s+.*$`)
+	jqlAnd       = regexp.MustCompile(`(?i)\s+AND\s+`)
+	jqlCondition = regexp.MustCompile(`(?i)^(project|key|issuekey|status|assignee)\s*(=|!=|\bIN\b|\bNOT\s+IN\b)\s*(.+)$`)
+)
+
+// parseJQL understands the conditions tests filter issues by: project,
+// key, status and assignee compared with =, !=, IN or NOT IN, joined by
+// AND. Anything else, such as date conditions or OR groups, is ignored,
+// so a query matches at least the issues it would in Jira. Results are
+// always ordered by last update.
+func parseJQL(jql string) []jqlClause {
+	jql = jqlOrderBy.ReplaceAllString(strings.TrimSpace(jql), "")
+	var clauses []jqlClause
+	for _, part := range jqlAnd.Split(jql, -1) {
+		m := jqlCondition.FindStringSubmatch(strings.TrimSpace(part))
+		if m == nil {
+			continue
+		}
+		op := strings.ToUpper(strings.Join(strings.Fields(m[2]), " "))
+		field := strings.ToLower(m[1])
+		if field == "issuekey" {
+			field = "key"
+		}
+		clauses = append(clauses, jqlClause{
+			field:  field,
+			negate: op == "!=" || op == "NOT IN",
+			values: jqlValues(m[3]),
+		})
+	}
+	return clauses
+}
+
+// jqlValues reads a single value or a parenthesised list of them.
+func jqlValues(s string) []string {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		var values []string
+		for _, v := range strings.Split(s[1:len(s)-1], ",") {
+			values = append(values, unquote(v))
+		}
+		return values
+	}
+	return []string{unquote(s)}
+}
+
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// matches reports whether the issue satisfies every clause.
+func (st *issueState) matches(clauses []jqlClause, me string) bool {
+	fields := st.issue.Fields
+	for _, clause := range clauses {
+		var actual string
+		switch clause.field {
+		case "project":
+			actual = fields.Project.Key
+		case "key":
+			actual = st.issue.Key
+		case "status":
+			actual = fields.Status.Name
+		case "assignee":
+			if fields.Assignee != nil {
+				actual = fields.Assignee.AccountID
+			}
+		}
+		found := false
+		for _, value := range clause.values {
+			if clause.field == "assignee" && strings.EqualFold(value, "currentUser()") {
+				value = me
+			}
+			if strings.EqualFold(value, actual) {
+				found = true
+				break
+			}
+		}
+		if found == clause.negate {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,435 @@
+package fakejira
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"jiraTimeWidget/jiraModels"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const restPrefix = "/rest/api/3/"
+
+// ServeHTTP answers a request as Jira Cloud would.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	if r.URL.Path == "/_edge/tenant_info" {
+		writeJSON(w, http.StatusOK, map[string]string{"cloudId": s.CloudID})
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Client must be authenticated to access this resource.")
+		return
+	}
+	if r.URL.Path == "/gateway/api/graphql" && r.Method == http.MethodPost {
+		s.serveGraphQL(w, r)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, restPrefix) {
+		writeError(w, http.StatusNotFound, "No such endpoint in the fake site: "+r.URL.Path)
+		return
+	}
+
+	// Issue and worklog IDs in the path become {} in the route
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, restPrefix), "/"), "/")
+	pattern := append([]string(nil), parts...)
+	if parts[0] == "issue" {
+		for _, i := range []int{1, 3} {
+			if i < len(pattern) {
+				pattern[i] = "{}"
+			}
+		}
+	}
+	route := r.Method + " " + strings.Join(pattern, "/")
+
+	switch route {
+	case "GET myself":
+		writeJSON(w, http.StatusOK, s.Me)
+	case "GET search/jql":
+		s.search(w, r)
+	case "GET issue/{}":
+		s.withIssue(w, parts[1], func(st *issueState) { s.getIssue(w, r, st) })
+	case "PUT issue/{}":
+		s.withIssue(w, parts[1], func(st *issueState) { s.editIssue(w, r, st) })
+	case "GET issue/{}/transitions":
+		s.withIssue(w, parts[1], func(st *issueState) {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"transitions": s.workflow.available(st.issue.Fields.Status.Name)})
+		})
+	case "POST issue/{}/transitions":
+		s.withIssue(w, parts[1], func(st *issueState) { s.transition(w, r, st) })
+	case "GET issue/{}/worklog":
+		s.withIssue(w, parts[1], func(st *issueState) { writePage(w, r, "worklogs", st.worklogs) })
+	case "POST issue/{}/worklog":
+		s.withIssue(w, parts[1], func(st *issueState) { s.addWorklog(w, r, st) })
+	case "PUT issue/{}/worklog/{}":
+		s.withWorklog(w, parts[1], parts[3], func(st *issueState, i int) { s.updateWorklog(w, r, st, i) })
+	case "DELETE issue/{}/worklog/{}":
+		s.withWorklog(w, parts[1], parts[3], func(st *issueState, i int) {
+			st.worklogs = append(st.worklogs[:i], st.worklogs[i+1:]...)
+			st.touch(s.now())
+			w.WriteHeader(http.StatusNoContent)
+		})
+	case "GET issue/{}/comment":
+		s.withIssue(w, parts[1], func(st *issueState) { writePage(w, r, "comments", st.comments) })
+	case "POST issue/{}/comment":
+		s.withIssue(w, parts[1], func(st *issueState) { s.addComment(w, r, st) })
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("No such endpoint in the fake site: %s %s", r.Method, r.URL.Path))
+	}
+}
+
+// authorized checks the request's credentials against APIToken.
+func (s *Server) authorized(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	if s.APIToken == "" {
+		return auth != ""
+	}
+	if strings.TrimPrefix(auth, "Bearer ") == s.APIToken {
+		return true
+	}
+	if encoded := strings.TrimPrefix(auth, "Basic "); encoded != auth {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return false
+		}
+		_, password, _ := strings.Cut(string(decoded), ":")
+		return password == s.APIToken
+	}
+	return false
+}
+
+func (s *Server) withIssue(w http.ResponseWriter, idOrKey string, handle func(*issueState)) {
+	for key, state := range s.issues {
+		if key == idOrKey || state.issue.ID == idOrKey {
+			handle(state)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+}
+
+func (s *Server) withWorklog(w http.ResponseWriter, idOrKey, worklogID string, handle func(*issueState, int)) {
+	s.withIssue(w, idOrKey, func(st *issueState) {
+		for i, worklog := range st.worklogs {
+			if worklog.ID == worklogID {
+				handle(st, i)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "Cannot find worklog with id: "+worklogID)
+	})
+}
+
+// search serves the enhanced JQL search, paged by nextPageToken.
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	clauses := parseJQL(query.Get("jql"))
+	var matching []*issueState
+	for _, state := range s.sortedIssues() {
+		if state.matches(clauses, s.Me.AccountID) {
+			matching = append(matching, state)
+		}
+	}
+
+	start, _ := strconv.Atoi(query.Get("nextPageToken"))
+	size := pageSize(query.Get("maxResults"))
+	end := start + size
+	if end > len(matching) {
+		end = len(matching)
+	}
+	if start > end {
+		start = end
+	}
+
+	fields := requestedFields(query)
+	issues := make([]map[string]interface{}, 0, end-start)
+	for _, state := range matching[start:end] {
+		issues = append(issues, issueJSON(siteURL(r), state.snapshot(), fields, nil))
+	}
+	response := map[string]interface{}{"issues": issues, "isLast": end == len(matching)}
+	if end < len(matching) {
+		response["nextPageToken"] = strconv.Itoa(end)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) getIssue(w http.ResponseWriter, r *http.Request, st *issueState) {
+	var transitions []jiraModels.Transition
+	for _, expand := range strings.Split(r.URL.Query().Get("expand"), ",") {
+		if strings.TrimSpace(expand) == "transitions" {
+			transitions = s.workflow.available(st.issue.Fields.Status.Name)
+			if transitions == nil {
+				transitions = []jiraModels.Transition{}
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, issueJSON(siteURL(r), st.snapshot(), requestedFields(r.URL.Query()), transitions))
+}
+
+// editIssue sets the summary or assignee, the fields the fake knows.
+func (s *Server) editIssue(w http.ResponseWriter, r *http.Request, st *issueState) {
+	var body struct {
+		Fields map[string]json.RawMessage `json:"fields"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		return
+	}
+	problems := map[string]string{}
+	for field, value := range body.Fields {
+		switch field {
+		case "summary":
+			if json.Unmarshal(value, &st.issue.Fields.Summary) != nil {
+				problems[field] = "Summary must be a string."
+			}
+		case "assignee":
+			var assignee *jiraModels.User
+			if json.Unmarshal(value, &assignee) != nil {
+				problems[field] = "Specify a valid value for assignee."
+			} else if assignee != nil && assignee.AccountID == s.Me.AccountID {
+				me := s.Me
+				st.issue.Fields.Assignee = &me
+			} else if assignee == nil {
+				st.issue.Fields.Assignee = nil
+			} else {
+				problems[field] = "User '" + assignee.AccountID + "' cannot be assigned issues."
+			}
+		default:
+			problems[field] = "Field '" + field + "' cannot be set. It is not on the appropriate screen, or unknown."
+		}
+	}
+	if len(problems) > 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"errorMessages": []string{}, "errors": problems})
+		return
+	}
+	st.touch(s.now())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) transition(w http.ResponseWriter, r *http.Request, st *issueState) {
+	var body struct {
+		Transition struct {
+			ID string `json:"id"`
+		} `json:"transition"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		return
+	}
+	for _, t := range s.workflow.available(st.issue.Fields.Status.Name) {
+		if t.ID == body.Transition.ID {
+			to := t.To
+			st.issue.Fields.Status = &to
+			st.touch(s.now())
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeError(w, http.StatusBadRequest, fmt.Sprintf("Transition id '%s' is not valid for this issue.", body.Transition.ID))
+}
+
+// worklogRequest is the body of a worklog create or update.
+type worklogRequest struct {
+	TimeSpent        string              `json:"timeSpent"`
+	TimeSpentSeconds int                 `json:"timeSpentSeconds"`
+	Started          string              `json:"started"`
+	Comment          jiraModels.RichText `json:"comment"`
+}
+
+func (s *Server) addWorklog(w http.ResponseWriter, r *http.Request, st *issueState) {
+	now := jiraModels.Time{Time: s.now()}
+	me := s.Me
+	worklog := jiraModels.Worklog{
+		ID:           s.newID(),
+		IssueID:      st.issue.ID,
+		Author:       &me,
+		UpdateAuthor: &me,
+		Created:      now,
+		Updated:      now,
+		Started:      now,
+	}
+	if !s.applyWorklog(w, r, &worklog, true) {
+		return
+	}
+	st.worklogs = append(st.worklogs, worklog)
+	st.touch(now.Time)
+	writeJSON(w, http.StatusCreated, worklog)
+}
+
+func (s *Server) updateWorklog(w http.ResponseWriter, r *http.Request, st *issueState, i int) {
+	worklog := st.worklogs[i]
+	if !s.applyWorklog(w, r, &worklog, false) {
+		return
+	}
+	me := s.Me
+	worklog.UpdateAuthor = &me
+	worklog.Updated = jiraModels.Time{Time: s.now()}
+	st.worklogs[i] = worklog
+	st.touch(worklog.Updated.Time)
+	writeJSON(w, http.StatusOK, worklog)
+}
+
+// applyWorklog copies a request's fields onto worklog, answering with an
+// error and returning false if they are invalid.
+func (s *Server) applyWorklog(w http.ResponseWriter, r *http.Request, worklog *jiraModels.Worklog, create bool) bool {
+	var body worklogRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		return false
+	}
+
+	seconds := body.TimeSpentSeconds
+	if body.TimeSpent != "" {
+		var err error
+		if seconds, err = parseDuration(body.TimeSpent); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"errorMessages": []string{}, "errors": map[string]string{"timeLogged": "Invalid time duration entered."}})
+			return false
+		}
+	}
+	if seconds > 0 {
+		worklog.TimeSpentSeconds = seconds
+		worklog.TimeSpent = formatDuration(seconds)
+	} else if create {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"errorMessages": []string{}, "errors": map[string]string{"timeLogged": "You must indicate the time spent working."}})
+		return false
+	}
+
+	if body.Started != "" {
+		started, err := time.Parse(jiraModels.TimeFormat, body.Started)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"errorMessages": []string{}, "errors": map[string]string{"started": "Invalid start date."}})
+			return false
+		}
+		worklog.Started = jiraModels.Time{Time: started}
+	}
+	if len(body.Comment.Raw()) > 0 {
+		worklog.Comment = body.Comment
+	}
+	return true
+}
+
+func (s *Server) addComment(w http.ResponseWriter, r *http.Request, st *issueState) {
+	var body struct {
+		Body jiraModels.RichText `json:"body"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		return
+	}
+	if body.Body.Text() == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"errorMessages": []string{}, "errors": map[string]string{"comment": "Comment body can not be empty!"}})
+		return
+	}
+	now := jiraModels.Time{Time: s.now()}
+	me := s.Me
+	comment := jiraModels.Comment{ID: s.newID(), Author: &me, Body: body.Body, Created: now, Updated: now}
+	st.comments = append(st.comments, comment)
+	st.touch(now.Time)
+	writeJSON(w, http.StatusCreated, comment)
+}
+
+// requestedFields returns the fields parameter, or nil for all fields.
+func requestedFields(query map[string][]string) map[string]bool {
+	var fields map[string]bool
+	for _, value := range query["fields"] {
+		for _, field := range strings.Split(value, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" || field == "*all" || field == "*navigable" {
+				return nil
+			}
+			if fields == nil {
+				fields = map[string]bool{}
+			}
+			fields[field] = true
+		}
+	}
+	return fields
+}
+
+// siteURL returns the root of the site r was sent to.
+func siteURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// issueJSON renders an issue with only the requested fields.
+func issueJSON(site string, issue jiraModels.Issue, fields map[string]bool, transitions []jiraModels.Transition) map[string]interface{} {
+	all := map[string]interface{}{
+		"summary":      issue.Fields.Summary,
+		"status":       issue.Fields.Status,
+		"issuetype":    issue.Fields.IssueType,
+		"project":      issue.Fields.Project,
+		"assignee":     issue.Fields.Assignee,
+		"reporter":     issue.Fields.Reporter,
+		"created":      issue.Fields.Created,
+		"updated":      issue.Fields.Updated,
+		"timespent":    issue.Fields.TimeSpent,
+		"timetracking": issue.Fields.TimeTracking,
+	}
+	selected := map[string]interface{}{}
+	for name, value := range all {
+		if fields == nil || fields[name] {
+			selected[name] = value
+		}
+	}
+	result := map[string]interface{}{
+		"id":     issue.ID,
+		"key":    issue.Key,
+		"self":   site + restPrefix + "issue/" + issue.ID,
+		"fields": selected,
+	}
+	if transitions != nil {
+		result["transitions"] = transitions
+	}
+	return result
+}
+
+// writePage writes the startAt/maxResults page of items under key.
+func writePage[T any](w http.ResponseWriter, r *http.Request, key string, items []T) {
+	start, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+	size := pageSize(r.URL.Query().Get("maxResults"))
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"startAt":    start,
+		"maxResults": size,
+		"total":      len(items),
+		key:          append([]T{}, items[start:end]...),
+	})
+}
+
+// pageSize reads maxResults, which Jira caps at 100 for these endpoints.
+func pageSize(maxResults string) int {
+	size, err := strconv.Atoi(maxResults)
+	if err != nil || size <= 0 {
+		return 50
+	}
+	if size > 100 {
+		return 100
+	}
+	return size
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"errorMessages": []string{message}, "errors": map[string]string{}})
+}
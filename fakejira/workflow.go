@@ -0,0 +1,94 @@
+package fakejira
+
+import "jiraTimeWidget/jiraModels"
+
+// Workflow is the set of statuses issues move through and the transitions
+// between them.
+type Workflow struct {
+	// Statuses are the statuses issues can have; new issues get the first.
+	Statuses []jiraModels.Status
+	// Transitions are offered in order wherever they apply.
+	Transitions []WorkflowTransition
+}
+
+// WorkflowTransition moves an issue into the status named To.
+type WorkflowTransition struct {
+	ID   string
+	Name string
+	// From names the statuses the transition is available in; empty means
+	// all of them except To.
+	From []string
+	To   string
+}
+
+// DefaultWorkflow is Jira's simplified workflow: To Do, In Progress and
+// Done, with every status reachable from the others.
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		Statuses: []jiraModels.Status{
+			newStatus("10000", "To Do", jiraModels.StatusCategoryToDo),
+			newStatus("10001", "In Progress", jiraModels.StatusCategoryInProgress),
+			newStatus("10002", "Done", jiraModels.StatusCategoryDone),
+		},
+		Transitions: []WorkflowTransition{
+			{ID: "11", Name: "To Do", To: "To Do"},
+			{ID: "21", Name: "In Progress", To: "In Progress"},
+			{ID: "31", Name: "Done", To: "Done"},
+		},
+	}
+}
+
+func newStatus(id, name, category string) jiraModels.Status {
+	categoryIDs := map[string]int{
+		jiraModels.StatusCategoryToDo:       2,
+		jiraModels.StatusCategoryInProgress: 4,
+		jiraModels.StatusCategoryDone:       3,
+	}
+	return jiraModels.Status{
+		ID:             id,
+		Name:           name,
+		StatusCategory: jiraModels.StatusCategory{ID: categoryIDs[category], Key: category},
+	}
+}
+
+func (w Workflow) status(name string) (jiraModels.Status, bool) {
+	for _, status := range w.Statuses {
+		if status.Name == name {
+			return status, true
+		}
+	}
+	return jiraModels.Status{}, false
+}
+
+// available returns the transitions out of the status named from.
+func (w Workflow) available(from string) []jiraModels.Transition {
+	var transitions []jiraModels.Transition
+	for _, t := range w.Transitions {
+		if !t.appliesTo(from) {
+			continue
+		}
+		to, ok := w.status(t.To)
+		if !ok {
+			continue
+		}
+		transitions = append(transitions, jiraModels.Transition{
+			ID:       t.ID,
+			Name:     t.Name,
+			To:       to,
+			IsGlobal: len(t.From) == 0,
+		})
+	}
+	return transitions
+}
+
+func (t WorkflowTransition) appliesTo(from string) bool {
+	if len(t.From) == 0 {
+		return t.To != from
+	}
+	for _, name := range t.From {
+		if name == from {
+			return true
+		}
+	}
+	return false
+}
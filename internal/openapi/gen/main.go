@@ -0,0 +1,44 @@
+// Command gen generates typed Jira REST functions from the bundled OpenAPI
+// description for the operations named in an allow-list. It is run by
+// go generate in jiraRestApi.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"jiraTimeWidget/internal/openapi"
+	"os"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path to the OpenAPI JSON document")
+	allowList := flag.String("operations", "", "file listing the operationIds to generate, one per line")
+	pkg := flag.String("package", "", "package name of the generated file")
+	out := flag.String("out", "", "file to write")
+	flag.Parse()
+
+	if *specPath == "" || *allowList == "" || *pkg == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*specPath, *allowList, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, allowList, pkg, out string) error {
+	spec, err := openapi.Load(specPath)
+	if err != nil {
+		return err
+	}
+	ids, err := openapi.ReadAllowList(allowList)
+	if err != nil {
+		return err
+	}
+	code, err := openapi.Generate(spec, pkg, ids)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, code, 0644)
+}
@@ -0,0 +1,452 @@
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ReadAllowList reads the operationIds to generate, one per line. Blank
+// lines and lines starting with # are ignored.
+func ReadAllowList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ids = append(ids, line)
+	}
+	return ids, scanner.Err()
+}
+
+// Generate writes a gofmt'd Go file for package pkg holding, for each
+// allowed operation, a parameter struct and a function that calls it
+// through a *jiraApiFunctions.Client and decodes the response, plus the
+// component schemas those operations use.
+func Generate(spec *Spec, pkg string, operationIDs []string) ([]byte, error) {
+	g := &generator{spec: spec, types: map[string]string{}}
+
+	var funcs bytes.Buffer
+	seen := map[string]bool{}
+	for _, id := range operationIDs {
+		if seen[id] {
+			return nil, fmt.Errorf("operation %s is listed twice", id)
+		}
+		seen[id] = true
+		op := spec.Operation(id)
+		if op == nil {
+			return nil, fmt.Errorf("operation %s is not in the spec", id)
+		}
+		if err := g.operation(&funcs, op); err != nil {
+			return nil, fmt.Errorf("operation %s: %w", id, err)
+		}
+	}
+	for len(g.queue) > 0 {
+		name := g.queue[0]
+		g.queue = g.queue[1:]
+		if err := g.schema(name); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+
+	var types bytes.Buffer
+	names := make([]string, 0, len(g.types))
+	for name := range g.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		types.WriteString(g.types[name])
+	}
+
+	body := funcs.String() + types.String()
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by jiraTimeWidget/internal/openapi/gen from jiraRestApiDoc/OpenApi.json. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\nimport (\n", pkg)
+	for _, imp := range []struct{ path, use string }{
+		{"context", "context."},
+		{"encoding/json", "json."},
+		{"fmt", "fmt."},
+		{"jiraTimeWidget/jiraApiFunctions", "jiraApiFunctions."},
+		{"net/url", "url."},
+		{"strconv", "strconv."},
+	} {
+		if strings.Contains(body, imp.use) {
+			fmt.Fprintf(&out, "\t%q\n", imp.path)
+		}
+	}
+	out.WriteString(")\n\n")
+	out.WriteString(body)
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+type generator struct {
+	spec *Spec
+	// types holds the declaration of each component schema in use.
+	types map[string]string
+	queue []string
+}
+
+// use records that the component schema name is needed and returns its Go
+// type name.
+func (g *generator) use(name string) string {
+	if _, ok := g.types[name]; !ok {
+		g.types[name] = ""
+		g.queue = append(g.queue, name)
+	}
+	return exportName(name)
+}
+
+func (g *generator) operation(w *bytes.Buffer, op *Operation) error {
+	name := exportName(op.OperationID)
+	paramsType := name + "Params"
+
+	// The parameter struct.
+	fmt.Fprintf(w, "// %s holds the parameters of %s.\n", paramsType, name)
+	fmt.Fprintf(w, "type %s struct {\n", paramsType)
+	fields := map[string]string{}
+	for _, p := range op.Parameters {
+		if p.In != "path" && p.In != "query" {
+			return fmt.Errorf("%s parameter %s is not supported", p.In, p.Name)
+		}
+		field := uniqueField(fields, exportName(p.Name), p.Name)
+		writeComment(w, "\t", p.Description)
+		fmt.Fprintf(w, "\t%s %s\n", field, g.parameterType(p))
+	}
+	bodyType := ""
+	if op.RequestBody != nil {
+		media := op.RequestBody.Content["application/json"]
+		if media == nil || media.Schema == nil {
+			return fmt.Errorf("request bodies other than application/json are not supported")
+		}
+		bodyType = g.goType(media.Schema, true)
+		uniqueField(fields, "Body", "")
+		writeComment(w, "\t", op.RequestBody.Description)
+		fmt.Fprintf(w, "\tBody %s\n", bodyType)
+	}
+	w.WriteString("}\n\n")
+
+	// The function.
+	resultType := g.resultType(op)
+	fmt.Fprintf(w, "// %s calls %s %s.\n", name, op.Method, op.Path)
+	if sentence := firstSentence(op.Description); sentence != "" {
+		fmt.Fprintf(w, "//\n// %s\n", sentence)
+	}
+	if op.Deprecated {
+		w.WriteString("//\n// Deprecated: Jira has deprecated this operation.\n")
+	}
+	if resultType == "" {
+		fmt.Fprintf(w, "func %s(ctx context.Context, c *jiraApiFunctions.Client, params %s) error {\n", name, paramsType)
+	} else {
+		fmt.Fprintf(w, "func %s(ctx context.Context, c *jiraApiFunctions.Client, params %s) (%s, error) {\n", name, paramsType, resultType)
+	}
+
+	endpoint := `"` + op.Path + `"`
+	for _, p := range op.Parameters {
+		if p.In != "path" {
+			continue
+		}
+		value := "url.PathEscape(" + formatValue(p.Schema, "params."+fields[p.Name]) + ")"
+		endpoint = strings.Replace(endpoint, "{"+p.Name+"}", `" + `+value+` + "`, 1)
+	}
+	endpoint = strings.ReplaceAll(endpoint, ` + ""`, "")
+	fmt.Fprintf(w, "\tendpoint := %s\n", endpoint)
+
+	w.WriteString("\tquery := url.Values{}\n")
+	for _, p := range op.Parameters {
+		if p.In == "query" {
+			writeQueryParameter(w, p, "params."+fields[p.Name])
+		}
+	}
+
+	bodyArg := "nil"
+	if bodyType != "" {
+		bodyArg = "body"
+		w.WriteString("\tvar body interface{}\n")
+		if nillable(bodyType) {
+			w.WriteString("\tif params.Body != nil {\n\t\tbody = params.Body\n\t}\n")
+		} else {
+			w.WriteString("\tbody = params.Body\n")
+		}
+	}
+
+	call := fmt.Sprintf("c.CallValuesContext(ctx, %q, endpoint, %s, query)", op.Method, bodyArg)
+	if resultType == "" {
+		fmt.Fprintf(w, "\t_, err := %s\n\treturn err\n}\n\n", call)
+		return nil
+	}
+	zero := zeroValue(resultType)
+	fmt.Fprintf(w, "\tresponse, err := %s\n", call)
+	fmt.Fprintf(w, "\tif err != nil {\n\t\treturn %s, err\n\t}\n", zero)
+	decodeType := strings.TrimPrefix(resultType, "*")
+	fmt.Fprintf(w, "\tvar result %s\n", decodeType)
+	w.WriteString("\tif len(response) > 0 {\n")
+	fmt.Fprintf(w, "\t\tif err := json.Unmarshal(response, &result); err != nil {\n")
+	fmt.Fprintf(w, "\t\t\treturn %s, fmt.Errorf(\"decoding %s response: %%w\", err)\n\t\t}\n\t}\n", zero, op.OperationID)
+	if strings.HasPrefix(resultType, "*") {
+		w.WriteString("\treturn &result, nil\n}\n\n")
+	} else {
+		w.WriteString("\treturn result, nil\n}\n\n")
+	}
+	return nil
+}
+
+// resultType is the Go type of the operation's JSON success response, or
+// "" if it has none.
+func (g *generator) resultType(op *Operation) string {
+	for _, code := range []string{"200", "201", "202"} {
+		response := op.Responses[code]
+		if response == nil {
+			continue
+		}
+		media := response.Content["application/json"]
+		if media == nil || media.Schema == nil {
+			return ""
+		}
+		return g.goType(media.Schema, true)
+	}
+	return ""
+}
+
+// parameterType picks the Go type of a path or query parameter. Booleans
+// are pointers because Jira defaults some of them to true.
+func (g *generator) parameterType(p *Parameter) string {
+	t := g.goType(p.Schema, false)
+	if t == "bool" && p.In == "query" {
+		return "*bool"
+	}
+	return t
+}
+
+// goType maps a schema to a Go type. References become the named type,
+// as a pointer when pointerRefs is set.
+func (g *generator) goType(s *Schema, pointerRefs bool) string {
+	if s == nil {
+		return "json.RawMessage"
+	}
+	if ref := s.RefName(); ref != "" {
+		name := g.use(ref)
+		if pointerRefs {
+			return "*" + name
+		}
+		return name
+	}
+	switch s.Type {
+	case "string":
+		// Dates stay strings: Jira's timestamps aren't RFC 3339.
+		return "string"
+	case "integer":
+		switch s.Format {
+		case "int32":
+			return "int32"
+		case "int64":
+			return "int64"
+		}
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if s.Items == nil {
+			return "[]json.RawMessage"
+		}
+		return "[]" + g.goType(s.Items, false)
+	}
+	if len(s.Properties) > 0 || len(s.AllOf) > 0 || len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		// Anonymous structures and unions are left for the caller to decode.
+		return "json.RawMessage"
+	}
+	if additional := s.AdditionalSchema(); additional != nil && (additional.Type != "" || additional.RefName() != "") {
+		return "map[string]" + g.goType(additional, false)
+	}
+	if s.Type == "object" {
+		return "map[string]interface{}"
+	}
+	return "json.RawMessage"
+}
+
+// schema renders the declaration of a component schema.
+func (g *generator) schema(name string) error {
+	s := g.spec.Schema(name)
+	if s == nil {
+		return fmt.Errorf("not in the spec")
+	}
+	typeName := exportName(name)
+
+	var w bytes.Buffer
+	fmt.Fprintf(&w, "// %s is the %s schema.\n", typeName, name)
+	if sentence := firstSentence(s.Description); sentence != "" {
+		fmt.Fprintf(&w, "//\n// %s\n", sentence)
+	}
+	if len(s.Properties) == 0 {
+		underlying := g.goType(s, false)
+		if underlying == typeName {
+			underlying = "json.RawMessage"
+		}
+		fmt.Fprintf(&w, "type %s %s\n\n", typeName, underlying)
+		g.types[name] = w.String()
+		return nil
+	}
+
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+	props := make([]string, 0, len(s.Properties))
+	for prop := range s.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	fmt.Fprintf(&w, "type %s struct {\n", typeName)
+	fields := map[string]string{}
+	for _, prop := range props {
+		ps := s.Properties[prop]
+		field := uniqueField(fields, exportName(prop), prop)
+		t := g.goType(ps, true)
+		tag := prop + ",omitempty"
+		if required[prop] {
+			tag = prop
+		} else if t == "bool" {
+			// A false that is set must still be sent.
+			t = "*bool"
+		}
+		writeComment(&w, "\t", ps.Description)
+		fmt.Fprintf(&w, "\t%s %s `json:%q`\n", field, t, tag)
+	}
+	w.WriteString("}\n\n")
+	g.types[name] = w.String()
+	return nil
+}
+
+// writeQueryParameter adds a query parameter to query when it is set.
+// Arrays repeat the parameter, which is how the spec's form style
+// serialises them.
+func writeQueryParameter(w *bytes.Buffer, p *Parameter, value string) {
+	s := p.Schema
+	switch {
+	case s != nil && s.Type == "array":
+		fmt.Fprintf(w, "\tfor _, v := range %s {\n", value)
+		fmt.Fprintf(w, "\t\tquery.Add(%q, %s)\n\t}\n", p.Name, formatValue(s.Items, "v"))
+	case s != nil && s.Type == "boolean":
+		fmt.Fprintf(w, "\tif %s != nil {\n\t\tquery.Set(%q, strconv.FormatBool(*%s))\n\t}\n", value, p.Name, value)
+	case s != nil && (s.Type == "integer" || s.Type == "number"):
+		fmt.Fprintf(w, "\tif %s != 0 {\n\t\tquery.Set(%q, %s)\n\t}\n", value, p.Name, formatValue(s, value))
+	default:
+		fmt.Fprintf(w, "\tif %s != \"\" {\n\t\tquery.Set(%q, %s)\n\t}\n", value, p.Name, formatValue(s, value))
+	}
+}
+
+// formatValue returns an expression turning value into a string.
+func formatValue(s *Schema, value string) string {
+	if s == nil {
+		return value
+	}
+	switch s.Type {
+	case "integer":
+		return "strconv.FormatInt(int64(" + value + "), 10)"
+	case "number":
+		return "strconv.FormatFloat(" + value + ", 'f', -1, 64)"
+	case "boolean":
+		return "strconv.FormatBool(" + value + ")"
+	}
+	return value
+}
+
+func nillable(goType string) bool {
+	return strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") ||
+		strings.HasPrefix(goType, "map[") || goType == "json.RawMessage"
+}
+
+func zeroValue(goType string) string {
+	switch {
+	case nillable(goType):
+		return "nil"
+	case goType == "string":
+		return `""`
+	case goType == "bool":
+		return "false"
+	case strings.HasPrefix(goType, "int") || goType == "float64":
+		return "0"
+	}
+	return goType + "{}"
+}
+
+// uniqueField claims a struct field name for the wire name key, adding a
+// number when two names map to the same identifier.
+func uniqueField(fields map[string]string, name, key string) string {
+	taken := map[string]bool{}
+	for _, f := range fields {
+		taken[f] = true
+	}
+	field := name
+	for i := 2; taken[field]; i++ {
+		field = fmt.Sprintf("%s%d", name, i)
+	}
+	fields[key] = field
+	return field
+}
+
+// exportName turns an OpenAPI name such as getIssue or fields.key into an
+// exported Go identifier.
+func exportName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	ident := b.String()
+	if ident == "" || unicode.IsDigit(rune(ident[0])) {
+		ident = "X" + ident
+	}
+	return ident
+}
+
+var markdownLink = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+
+// firstSentence shortens a Markdown description to its first sentence for
+// use in a doc comment.
+func firstSentence(description string) string {
+	text := markdownLink.ReplaceAllString(description, "$1")
+	if i := strings.Index(text, "\n"); i >= 0 {
+		text = text[:i]
+	}
+	if i := strings.Index(text, ". "); i >= 0 {
+		text = text[:i+1]
+	}
+	return strings.TrimSpace(text)
+}
+
+func writeComment(w *bytes.Buffer, indent, description string) {
+	if sentence := firstSentence(description); sentence != "" {
+		fmt.Fprintf(w, "%s// %s\n", indent, sentence)
+	}
+}
@@ -0,0 +1,216 @@
+// Package openapi reads the Jira REST API description bundled in
+// jiraRestApiDoc/OpenApi.json. It understands only the parts of OpenAPI 3
+// that document uses, which is enough to generate typed request code and
+// to check hand-written endpoint wrappers against the spec.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Methods lists the HTTP methods an OpenAPI path item may describe, in the
+// order operations are reported.
+var Methods = []string{"get", "put", "post", "delete", "patch", "head", "options"}
+
+// Spec is a parsed OpenAPI document.
+type Spec struct {
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Operation is a single method on a path.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Deprecated  bool                 `json:"deprecated"`
+	Parameters  []*Parameter         `json:"parameters"`
+	RequestBody *RequestBody         `json:"requestBody"`
+	Responses   map[string]*Response `json:"responses"`
+
+	// Method and Path are filled in by Load.
+	Method string `json:"-"`
+	Path   string `json:"-"`
+}
+
+// Parameter is a path, query or header parameter of an operation.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Explode     *bool   `json:"explode"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes what an operation accepts, keyed by media type.
+type RequestBody struct {
+	Description string                `json:"description"`
+	Required    bool                  `json:"required"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// Response describes one status code of an operation.
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// MediaType holds the schema of one content type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a JSON schema as OpenAPI 3.0 writes it.
+type Schema struct {
+	Ref         string             `json:"$ref"`
+	Type        string             `json:"type"`
+	Format      string             `json:"format"`
+	Description string             `json:"description"`
+	Items       *Schema            `json:"items"`
+	Properties  map[string]*Schema `json:"properties"`
+	Required    []string           `json:"required"`
+	AllOf       []*Schema          `json:"allOf"`
+	OneOf       []*Schema          `json:"oneOf"`
+	AnyOf       []*Schema          `json:"anyOf"`
+	// AdditionalProperties is either a bool or a schema.
+	AdditionalProperties json.RawMessage `json:"additionalProperties"`
+}
+
+// RefName returns the component a $ref points at, or "" if the schema is
+// not a reference. A lone allOf reference, which the spec uses to attach a
+// description to a referenced type, counts as a reference too.
+func (s *Schema) RefName() string {
+	if s == nil {
+		return ""
+	}
+	if s.Ref != "" {
+		return strings.TrimPrefix(s.Ref, "#/components/schemas/")
+	}
+	if len(s.AllOf) == 1 && s.Type == "" && len(s.Properties) == 0 {
+		return s.AllOf[0].RefName()
+	}
+	return ""
+}
+
+// AdditionalSchema returns the schema of additionalProperties when it is
+// given as a schema rather than a bool.
+func (s *Schema) AdditionalSchema() *Schema {
+	if len(s.AdditionalProperties) == 0 || s.AdditionalProperties[0] != '{' {
+		return nil
+	}
+	var additional Schema
+	if err := json.Unmarshal(s.AdditionalProperties, &additional); err != nil {
+		return nil
+	}
+	return &additional
+}
+
+// Load reads and parses the OpenAPI document at path.
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses an OpenAPI document.
+func Parse(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parsing OpenAPI document: %w", err)
+	}
+	for path, item := range spec.Paths {
+		for method, op := range item {
+			if op == nil {
+				delete(item, method)
+				continue
+			}
+			op.Method, op.Path = strings.ToUpper(method), path
+		}
+	}
+	return &spec, nil
+}
+
+// Operations returns every operation, sorted by path and then method.
+func (s *Spec) Operations() []*Operation {
+	paths := make([]string, 0, len(s.Paths))
+	for path := range s.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var ops []*Operation
+	for _, path := range paths {
+		for _, method := range Methods {
+			if op := s.Paths[path][method]; op != nil {
+				ops = append(ops, op)
+			}
+		}
+	}
+	return ops
+}
+
+// Operation looks an operation up by its operationId.
+func (s *Spec) Operation(id string) *Operation {
+	for _, op := range s.Operations() {
+		if op.OperationID == id {
+			return op
+		}
+	}
+	return nil
+}
+
+// Schema returns the component schema called name.
+func (s *Spec) Schema(name string) *Schema {
+	return s.Components.Schemas[name]
+}
+
+var pathParameter = regexp.MustCompile(`\{[^}/]+\}`)
+
+// Match finds the operation serving method on a concrete request path such
+// as /rest/api/3/issue/PROJ-1/worklog. When several templates match, the
+// one with the fewest parameters wins, so /issue/createmeta is preferred
+// over /issue/{issueIdOrKey}.
+func (s *Spec) Match(method, path string) *Operation {
+	method = strings.ToLower(method)
+	var best *Operation
+	bestParams := -1
+	for template, item := range s.Paths {
+		op := item[method]
+		if op == nil {
+			continue
+		}
+		// QuoteMeta escapes the braces, so undo that before substituting.
+		quoted := strings.NewReplacer(`\{`, "{", `\}`, "}").Replace(regexp.QuoteMeta(template))
+		pattern := "^" + pathParameter.ReplaceAllString(quoted, `[^/]+`) + "$"
+		if !regexp.MustCompile(pattern).MatchString(path) {
+			continue
+		}
+		params := len(pathParameter.FindAllString(template, -1))
+		if best == nil || params < bestParams || (params == bestParams && template < best.Path) {
+			best, bestParams = op, params
+		}
+	}
+	return best
+}
+
+// ParametersIn returns the names of the operation's parameters of one kind:
+// "path", "query" or "header".
+func (op *Operation) ParametersIn(in string) []string {
+	var names []string
+	for _, p := range op.Parameters {
+		if p.In == in {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
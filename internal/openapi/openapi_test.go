@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSpec = `{
+	"paths": {
+		"/rest/api/3/issue/{issueIdOrKey}": {
+			"get": {"operationId": "getIssue", "parameters": [
+				{"name": "issueIdOrKey", "in": "path", "required": true, "schema": {"type": "string"}},
+				{"name": "fields", "in": "query", "schema": {"type": "array", "items": {"type": "string"}}}
+			]}
+		},
+		"/rest/api/3/issue/createmeta": {
+			"get": {"operationId": "getCreateIssueMeta"}
+		}
+	},
+	"components": {"schemas": {}}
+}`
+
+func TestMatchPrefersLiteralPaths(t *testing.T) {
+	spec, err := Parse([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op := spec.Match("GET", "/rest/api/3/issue/createmeta"); op == nil || op.OperationID != "getCreateIssueMeta" {
+		t.Errorf("Expected getCreateIssueMeta, got %+v", op)
+	}
+	op := spec.Match("GET", "/rest/api/3/issue/PROJ-1")
+	if op == nil || op.OperationID != "getIssue" || op.Method != "GET" {
+		t.Fatalf("Expected getIssue, got %+v", op)
+	}
+	if got := op.ParametersIn("query"); len(got) != 1 || got[0] != "fields" {
+		t.Errorf("Unexpected query parameters %v", got)
+	}
+	if op := spec.Match("DELETE", "/rest/api/3/issue/PROJ-1"); op != nil {
+		t.Errorf("Expected no DELETE operation, got %s", op.OperationID)
+	}
+}
+
+func TestGenerate_UnknownOperation(t *testing.T) {
+	spec, err := Parse([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Generate(spec, "p", []string{"getIssue", "noSuchOperation"}); err == nil || !strings.Contains(err.Error(), "noSuchOperation") {
+		t.Errorf("Expected an error naming the missing operation, got %v", err)
+	}
+	code, err := Generate(spec, "p", []string{"getIssue"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(code), `query.Add("fields", v)`) {
+		t.Errorf("Expected fields to be sent as repeated parameters:\n%s", code)
+	}
+}
+
+func TestExportName(t *testing.T) {
+	for in, want := range map[string]string{
+		"getIssue":      "GetIssue",
+		"issueIdOrKey":  "IssueIdOrKey",
+		"fields.key":    "FieldsKey",
+		"x-request-id":  "XRequestId",
+		"3rdPartyThing": "X3rdPartyThing",
+	} {
+		if got := exportName(in); got != want {
+			t.Errorf("exportName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"jiraTimeWidget/jiraApiFunctions"
+	"jiraTimeWidget/jiraModels"
+	"log"
+	"time"
+)
+
+// issueDetailFields are the REST fields loadIssueDetails asks for.
+var issueDetailFields = []string{"summary", "status", "assignee", "timetracking"}
+
+// IssueDetails is what the widget shows about the selected issue.
+type IssueDetails struct {
+	Key      string
+	Summary  string
+	Status   StatusInfo
+	Assignee string
+
+	// Time tracking in seconds, zero when not set
+	OriginalEstimate  int
+	RemainingEstimate int
+	TimeSpent         int
+
+	// Transitions available from Status
+	Transitions []Transition
+}
+
+// loadIssueDetails fetches an issue together with its available
+// transitions in one request. It asks the GraphQL gateway on Cloud sites
+// and falls back to REST when GraphQL can't be used or fails.
+func loadIssueDetails(ctx context.Context, issueKey string) (*IssueDetails, error) {
+	site := currentSite()
+	if site.canUseGraphQL() {
+		details, err := loadIssueDetailsGraphQL(ctx, site, issueKey)
+		if err == nil || isCancelled(err) {
+			return details, err
+		}
+		log.Printf("⚠️ GraphQL issue query failed for %s, using REST: %v", issueKey, err)
+	}
+	return loadIssueDetailsREST(ctx, site.client, issueKey)
+}
+
+// canUseGraphQL reports whether the site has a GraphQL gateway we can
+// query; it needs the cloud ID, which Data Center doesn't have.
+func (s jiraSite) canUseGraphQL() bool {
+	return s.cloudID != "" && !s.profile.isDataCenter()
+}
+
+func loadIssueDetailsGraphQL(ctx context.Context, site jiraSite, issueKey string) (*IssueDetails, error) {
+	variables := struct {
+		CloudID string `json:"cloudId"`
+		Key     string `json:"key"`
+	}{site.cloudID, issueKey}
+
+	var data Data
+	if _, err := site.graphQLClient().GraphQLQuery(ctx, "issueDetails", variables, &data); err != nil {
+		return nil, err
+	}
+	if data.Jira.IssueByKey == nil {
+		return nil, fmt.Errorf("issue %s not found", issueKey)
+	}
+	return issueDetailsFromNode(data.Jira.IssueByKey)
+}
+
+// issueDetailsFromNode reads the fields of an issueDetails query result.
+func issueDetailsFromNode(node *IssueNode) (*IssueDetails, error) {
+	details := &IssueDetails{Key: node.Key}
+	var transitions []TransitionNode
+	haveStatus := false
+	for _, edge := range node.Fields.Edges {
+		field := edge.Node
+		switch field.FieldId {
+		case "summary":
+			if field.Text != nil {
+				details.Summary = *field.Text
+			}
+		case "status":
+			if field.Status != nil {
+				details.Status = statusInfoFromNode(*field.Status)
+				haveStatus = true
+			}
+			if field.Transitions != nil {
+				for _, t := range field.Transitions.Edges {
+					transitions = append(transitions, t.Node)
+				}
+			}
+		case "assignee":
+			if field.User != nil {
+				details.Assignee = field.User.Name
+			}
+		case "timetracking":
+			details.OriginalEstimate = field.OriginalEstimate.seconds()
+			details.RemainingEstimate = field.RemainingEstimate.seconds()
+			details.TimeSpent = field.TimeSpent.seconds()
+		}
+	}
+	if !haveStatus {
+		return nil, fmt.Errorf("no status in GraphQL response for %s", node.Key)
+	}
+
+	details.Transitions = make([]Transition, 0, len(transitions))
+	for _, t := range transitions {
+		to := statusInfoFromNode(t.To)
+		details.Transitions = append(details.Transitions, Transition{
+			ID:        t.TransitionId.String(),
+			Name:      t.Name,
+			To:        to,
+			IsForward: determineTransitionDirection(details.Status.Category, to.Category, to.Name),
+		})
+	}
+	return details, nil
+}
+
+func statusInfoFromNode(status IssueStatusNode) StatusInfo {
+	return StatusInfo{
+		ID:          status.StatusId,
+		Name:        status.Name,
+		Description: status.Description,
+		Category:    status.StatusCategory.Key,
+	}
+}
+
+func (e *TimeEstimate) seconds() int {
+	if e == nil || e.TimeInSeconds == nil {
+		return 0
+	}
+	return *e.TimeInSeconds
+}
+
+// loadIssueDetailsREST gets the same details from one REST call, with the
+// transitions expanded into the issue.
+func loadIssueDetailsREST(ctx context.Context, client *jiraApiFunctions.Client, issueKey string) (*IssueDetails, error) {
+	issue, err := client.Issue(ctx, issueKey, issueDetailFields, "transitions")
+	if err != nil {
+		return nil, err
+	}
+	return issueDetailsFromIssue(issue)
+}
+
+func issueDetailsFromIssue(issue *jiraModels.Issue) (*IssueDetails, error) {
+	if issue.Fields.Status == nil || issue.Fields.Status.ID == "" {
+		return nil, fmt.Errorf("no status found for issue %s", issue.Key)
+	}
+	details := &IssueDetails{
+		Key:     issue.Key,
+		Summary: issue.Fields.Summary,
+		Status:  newStatusInfo(*issue.Fields.Status),
+	}
+	if issue.Fields.Assignee != nil {
+		details.Assignee = issue.Fields.Assignee.DisplayName
+	}
+	if tt := issue.Fields.TimeTracking; tt != nil {
+		details.OriginalEstimate = tt.OriginalEstimateSeconds
+		details.RemainingEstimate = tt.RemainingEstimateSeconds
+		details.TimeSpent = tt.TimeSpentSeconds
+	}
+
+	details.Transitions = make([]Transition, 0, len(issue.Transitions))
+	for _, t := range issue.Transitions {
+		details.Transitions = append(details.Transitions, Transition{
+			ID:        t.ID,
+			Name:      t.Name,
+			To:        newStatusInfo(t.To),
+			IsForward: determineTransitionDirection(details.Status.Category, t.To.StatusCategory.Key, t.To.Name),
+		})
+	}
+	return details, nil
+}
+
+// describeTimeTracking summarises logged time against the estimate, e.g.
+// "2h 30m logged of 8h". It returns "" when nothing is tracked.
+func (d *IssueDetails) describeTimeTracking() string {
+	switch {
+	case d.TimeSpent == 0 && d.OriginalEstimate == 0:
+		return ""
+	case d.OriginalEstimate == 0:
+		return formatTrackedSeconds(d.TimeSpent) + " logged"
+	default:
+		return formatTrackedSeconds(d.TimeSpent) + " logged of " + formatTrackedSeconds(d.OriginalEstimate)
+	}
+}
+
+func formatTrackedSeconds(seconds int) string {
+	if seconds <= 0 {
+		return "0m"
+	}
+	return formatDurationForJira(time.Duration(seconds) * time.Second)
+}
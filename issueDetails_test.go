@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"jiraTimeWidget/jiraApiFunctions"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const issueDetailsGraphQLResponse = `{"data":{"jira":{"issueByKey":{"key":"PROJ-1","fields":{"edges":[
+	{"node":{"__typename":"JiraSingleLineTextField","fieldId":"summary","text":"Fix the widget"}},
+	{"node":{"__typename":"JiraStatusField","fieldId":"status",
+		"status":{"statusId":"3","name":"In Progress","statusCategory":{"key":"indeterminate"}},
+		"transitions":{"edges":[
+			{"node":{"transitionId":31,"name":"Done","to":{"statusId":"5","name":"Done","statusCategory":{"key":"done"}}}},
+			{"node":{"transitionId":11,"name":"Back","to":{"statusId":"1","name":"To Do","statusCategory":{"key":"new"}}}}]}}},
+	{"node":{"__typename":"JiraSingleSelectUserPickerField","fieldId":"assignee","user":{"accountId":"abc","name":"Ada"}}},
+	{"node":{"__typename":"JiraTimeTrackingField","fieldId":"timetracking",
+		"originalEstimate":{"timeInSeconds":28800},"timeSpent":{"timeInSeconds":9000}}}
+]}}}}}`
+
+const issueDetailsRESTResponse = `{"key":"PROJ-1","fields":{"summary":"Fix the widget",
+	"status":{"id":"3","name":"In Progress","statusCategory":{"key":"indeterminate"}},
+	"assignee":{"displayName":"Ada"},
+	"timetracking":{"originalEstimateSeconds":28800,"timeSpentSeconds":9000}},
+	"transitions":[{"id":"31","name":"Done","to":{"id":"5","name":"Done","statusCategory":{"key":"done"}}}]}`
+
+// issueDetailsSite serves the GraphQL gateway and the REST issue endpoint,
+// recording which were used.
+func issueDetailsSite(t *testing.T, graphQLBody string, requests *[]string) {
+	t.Helper()
+	withSiteGlobals(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.Path)
+		switch {
+		case r.URL.Path == "/gateway/api/graphql":
+			var request jiraApiFunctions.GraphQLRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			if request.OperationName != "issueDetails" {
+				t.Errorf("Unexpected operation %q", request.OperationName)
+			}
+			w.Write([]byte(graphQLBody))
+		case strings.HasSuffix(r.URL.Path, "/issue/PROJ-1"):
+			if r.URL.Query().Get("expand") != "transitions" {
+				t.Errorf("Expected transitions to be expanded, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(issueDetailsRESTResponse))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	jiraApiFunctions.DefaultClient = jiraApiFunctions.NewClient(server.URL, "", "key")
+	jiraGraphQlBaseUri = jiraApiFunctions.DefaultClient.GraphQLURL()
+	jiraCloudId = "cloud-1"
+	activeProfile = &JiraProfile{Name: legacyProfileName}
+}
+
+func TestLoadIssueDetails_GraphQL(t *testing.T) {
+	var requests []string
+	issueDetailsSite(t, issueDetailsGraphQLResponse, &requests)
+
+	details, err := loadIssueDetails(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 || requests[0] != "/gateway/api/graphql" {
+		t.Errorf("Expected a single GraphQL request, got %v", requests)
+	}
+	if details.Summary != "Fix the widget" || details.Status.Name != "In Progress" || details.Assignee != "Ada" {
+		t.Errorf("Unexpected details %+v", details)
+	}
+	if details.describeTimeTracking() != "2h 30m logged of 8h" {
+		t.Errorf("Unexpected time tracking %q", details.describeTimeTracking())
+	}
+	if len(details.Transitions) != 2 || details.Transitions[0].ID != "31" || !details.Transitions[0].IsForward || details.Transitions[1].IsForward {
+		t.Errorf("Unexpected transitions %+v", details.Transitions)
+	}
+}
+
+func TestLoadIssueDetails_FallsBackToREST(t *testing.T) {
+	var requests []string
+	issueDetailsSite(t, `{"data":null,"errors":[{"message":"Cannot query field \"issueByKey\""}]}`, &requests)
+
+	details, err := loadIssueDetails(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 || !strings.HasSuffix(requests[1], "/issue/PROJ-1") {
+		t.Errorf("Expected GraphQL then REST, got %v", requests)
+	}
+	if details.Summary != "Fix the widget" || details.Assignee != "Ada" || details.TimeSpent != 9000 {
+		t.Errorf("Unexpected details %+v", details)
+	}
+	if len(details.Transitions) != 1 || details.Transitions[0].To.Name != "Done" || !details.Transitions[0].IsForward {
+		t.Errorf("Unexpected transitions %+v", details.Transitions)
+	}
+}
+
+func TestLoadIssueDetails_DataCenterUsesREST(t *testing.T) {
+	var requests []string
+	issueDetailsSite(t, issueDetailsGraphQLResponse, &requests)
+	activeProfile = &JiraProfile{Name: "onprem", Deployment: "datacenter"}
+	jiraApiFunctions.DefaultClient.Deployment = jiraApiFunctions.DeploymentDataCenter
+
+	if _, err := loadIssueDetails(context.Background(), "PROJ-1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 || requests[0] != "/rest/api/2/issue/PROJ-1" {
+		t.Errorf("Expected only the REST v2 request, got %v", requests)
+	}
+}
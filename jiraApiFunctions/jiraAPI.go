@@ -2,70 +2,294 @@ package jiraApiFunctions
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
-var (
-	JiraGraphQlBaseUri string
-	JiraApiKey         string
-	JiraEmail          string
+const (
+	graphQlGatewayPath = "/gateway/api/graphql"
+	defaultUserAgent   = "JiraTimeWidget"
 )
 
+// Client talks to a single Jira site. Each endpoint function in this package
+// is available as a method, so several sites can be used side by side.
+type Client struct {
+	// BaseURL is the site root, e.g. https://example.atlassian.net.
+	BaseURL string
+	// Email selects Basic auth with Email:APIKey; without it APIKey is sent
+	// as a Bearer token.
+	Email  string
+	APIKey string
+	// Tokens, when set, supplies Bearer tokens instead of APIKey, e.g. an
+	// *OAuthTokenSource.
+	Tokens TokenSource
+	// GraphQLEndpoint overrides the site's GraphQL gateway, for clients
+	// that reach Jira through api.atlassian.com.
+	GraphQLEndpoint string
+	// HTTPClient defaults to http.DefaultClient when nil.
+	HTTPClient *http.Client
+	UserAgent  string
+	// Logger, when set, receives one line per request.
+	Logger *log.Logger
+	// Retry overrides DefaultRetryPolicy; use NoRetries to disable retries.
+	Retry *RetryPolicy
+	// Timeout bounds each HTTP attempt. Zero means DefaultTimeout and a
+	// negative value disables the limit.
+	Timeout time.Duration
+	// Deployment selects Cloud (the default) or Data Center behaviour.
+	Deployment Deployment
+	// Cache, when set, keeps read responses on disk; see ResponseCache.
+	Cache *ResponseCache
+}
+
+// DefaultTimeout limits how long a single request may take when the client
+// doesn't set its own Timeout.
+var DefaultTimeout = 30 * time.Second
+
+// DefaultClient is the client used by the package-level functions.
+var DefaultClient = &Client{}
+
+// NewClient returns a client for the site at baseURL. A GraphQL gateway URL
+// is accepted too and trimmed back to the site root.
+func NewClient(baseURL, email, apiKey string) *Client {
+	baseURL = strings.TrimSuffix(strings.TrimSuffix(baseURL, "/"), graphQlGatewayPath)
+	return &Client{
+		BaseURL:   baseURL,
+		Email:     email,
+		APIKey:    apiKey,
+		UserAgent: defaultUserAgent,
+	}
+}
+
+// GraphQLURL returns the site's GraphQL gateway endpoint.
+func (c *Client) GraphQLURL() string {
+	if c.GraphQLEndpoint != "" {
+		return c.GraphQLEndpoint
+	}
+	return c.BaseURL + graphQlGatewayPath
+}
+
+// GraphQLContext posts query to the client's GraphQL endpoint and returns
+// the raw response body, GraphQL errors included. Prefer GraphQLQuery,
+// which decodes the response and reports those errors.
+func (c *Client) GraphQLContext(ctx context.Context, query string, variables map[string]interface{}) ([]byte, error) {
+	var vars interface{}
+	if len(variables) > 0 {
+		vars = variables
+	}
+	return c.postGraphQL(ctx, GraphQLRequest{Query: query, Variables: vars})
+}
+
 // Generic API call function
 func MakeJiraAPICall(method, endpoint string, body interface{}, queryParams map[string]string) ([]byte, error) {
-	baseURL := strings.Replace(JiraGraphQlBaseUri, "/gateway/api/graphql", "", 1)
-	fullURL := baseURL + endpoint
-	
-	// Add query parameters
-	if len(queryParams) > 0 {
-		params := url.Values{}
-		for k, v := range queryParams {
-			if v != "" {
-				params.Add(k, v)
-			}
-		}
-		if len(params) > 0 {
-			fullURL += "?" + params.Encode()
+	return DefaultClient.Call(method, endpoint, body, queryParams)
+}
+
+// MakeJiraAPICallContext is MakeJiraAPICall with cancellation via ctx.
+func MakeJiraAPICallContext(ctx context.Context, method, endpoint string, body interface{}, queryParams map[string]string) ([]byte, error) {
+	return DefaultClient.CallContext(ctx, method, endpoint, body, queryParams)
+}
+
+// Call sends a request to endpoint, a path relative to the site root, and
+// returns the response body. Responses outside the 2xx range are returned
+// as an *APIError. Requests with idempotent methods are retried according
+// to the client's RetryPolicy.
+func (c *Client) Call(method, endpoint string, body interface{}, queryParams map[string]string) ([]byte, error) {
+	return c.CallContext(context.Background(), method, endpoint, body, queryParams)
+}
+
+// CallContext is Call bound to ctx: cancelling ctx aborts the request and
+// any pending retries.
+func (c *Client) CallContext(ctx context.Context, method, endpoint string, body interface{}, queryParams map[string]string) ([]byte, error) {
+	return c.call(ctx, method, endpoint, body, queryValues(queryParams), isIdempotent(method))
+}
+
+// CallValuesContext is CallContext for endpoints whose query parameters
+// repeat, such as ?fields=summary&fields=status.
+func (c *Client) CallValuesContext(ctx context.Context, method, endpoint string, body interface{}, query url.Values) ([]byte, error) {
+	return c.call(ctx, method, endpoint, body, query, isIdempotent(method))
+}
+
+// CallRetryable is like Call but marks the request as safe to retry even
+// though its method is not idempotent. Use it only for read-only POSTs such
+// as JQL searches, never for ones that create something like a worklog.
+func (c *Client) CallRetryable(method, endpoint string, body interface{}, queryParams map[string]string) ([]byte, error) {
+	return c.CallRetryableContext(context.Background(), method, endpoint, body, queryParams)
+}
+
+// CallRetryableContext is CallRetryable bound to ctx.
+func (c *Client) CallRetryableContext(ctx context.Context, method, endpoint string, body interface{}, queryParams map[string]string) ([]byte, error) {
+	return c.call(ctx, method, endpoint, body, queryValues(queryParams), true)
+}
+
+// queryValues drops the empty parameters the endpoint functions leave in
+// their maps.
+func queryValues(queryParams map[string]string) url.Values {
+	params := url.Values{}
+	for k, v := range queryParams {
+		if v != "" {
+			params.Add(k, v)
 		}
 	}
-	
-	var reqBody io.Reader
+	return params
+}
+
+func (c *Client) call(ctx context.Context, method, endpoint string, body interface{}, query url.Values, retrySafe bool) ([]byte, error) {
+	endpoint = c.apiPath(endpoint)
+	fullURL := c.BaseURL + endpoint
+	if len(query) > 0 {
+		fullURL += "?" + query.Encode()
+	}
+
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, err
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
 	}
-	
-	req, err := http.NewRequest(method, fullURL, reqBody)
+	return c.do(ctx, method, endpoint, fullURL, jsonBody, retrySafe)
+}
+
+// do sends a request through the client's cache, if it has one.
+func (c *Client) do(ctx context.Context, method, endpoint, fullURL string, jsonBody []byte, retrySafe bool) ([]byte, error) {
+	if c.Cache != nil && cacheable(method, endpoint, retrySafe) {
+		return c.cachedRoundTrip(ctx, method, endpoint, fullURL, jsonBody, retrySafe)
+	}
+	respBody, _, err := c.roundTrip(ctx, method, endpoint, fullURL, jsonBody, nil, retrySafe)
+	if err == nil && c.Cache != nil && isWrite(method, retrySafe) {
+		c.Cache.invalidate(endpoint)
+	}
+	return respBody, err
+}
+
+// roundTrip sends a request with the extra header, retrying it under the
+// client's policy when retrySafe is set.
+func (c *Client) roundTrip(ctx context.Context, method, endpoint, fullURL string, jsonBody []byte, header http.Header, retrySafe bool) ([]byte, http.Header, error) {
+	policy := c.retryPolicy()
+	for attempt := 1; ; attempt++ {
+		respBody, respHeader, err := c.send(ctx, method, endpoint, fullURL, jsonBody, header)
+		if err == nil {
+			return respBody, respHeader, nil
+		}
+		if !retrySafe || ctx.Err() != nil {
+			return nil, nil, err
+		}
+		wait, retry := policy.delay(attempt, err)
+		if !retry {
+			return nil, nil, err
+		}
+		c.logf("%s %s: retrying in %s after attempt %d failed: %v", method, endpoint, wait.Round(time.Millisecond), attempt, err)
+		if err := sleep(ctx, wait); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// send performs a single HTTP round trip, bounded by the client's timeout.
+// A 304 answer to a conditional request is returned as errNotModified.
+func (c *Client) send(ctx context.Context, method, endpoint, fullURL string, jsonBody []byte, header http.Header) ([]byte, http.Header, error) {
+	if timeout := c.timeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var reqBody io.Reader
+	if jsonBody != nil {
+		reqBody = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	
-	// For Jira Cloud, use Basic Auth with email:token if email is provided
-	if JiraEmail != "" {
-		auth := base64.StdEncoding.EncodeToString([]byte(JiraEmail + ":" + JiraApiKey))
-		req.Header.Set("Authorization", "Basic "+auth)
-	} else {
-		req.Header.Set("Authorization", "Bearer "+JiraApiKey)
+
+	if err := c.authorize(ctx, req); err != nil {
+		return nil, nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
 	}
 	req.Header.Set("Accept", "application/json")
-	if body != nil {
+	if jsonBody != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient().Do(req)
 	if err != nil {
-		return nil, err
+		c.logf("%s %s failed: %v", method, endpoint, err)
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
-	
-	return io.ReadAll(resp.Body)
-}
\ No newline at end of file
+	c.logf("%s %s -> %d (%s)", method, endpoint, resp.StatusCode, time.Since(start).Round(time.Millisecond))
+	if resp.Header.Get("X-RateLimit-NearLimit") == "true" {
+		c.logf("Jira reports this client is close to its rate limit")
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && header != nil {
+		return nil, resp.Header, errNotModified
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, nil, newAPIError(resp, method, endpoint, respBody)
+	}
+	return respBody, resp.Header, nil
+}
+
+// authorize sets the Authorization header for the client's credentials.
+func (c *Client) authorize(ctx context.Context, req *http.Request) error {
+	if c.Tokens != nil {
+		token, err := c.Tokens.Token(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+
+	// For Jira Cloud, use Basic Auth with email:token if email is provided.
+	// Data Center takes its personal access tokens as Bearer tokens only.
+	if c.Email != "" && !c.IsDataCenter() {
+		auth := base64.StdEncoding.EncodeToString([]byte(c.Email + ":" + c.APIKey))
+		req.Header.Set("Authorization", "Basic "+auth)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+	return nil
+}
+
+func (c *Client) timeout() time.Duration {
+	if c.Timeout == 0 {
+		return DefaultTimeout
+	}
+	return c.Timeout
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (c *Client) logf(format string, args ...interface{}) {
+	if c.Logger != nil {
+		c.Logger.Printf(format, args...)
+	}
+}
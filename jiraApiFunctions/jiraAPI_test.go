@@ -0,0 +1,144 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"syscall"
+	"testing"
+)
+
+func TestClient_SeparateSites(t *testing.T) {
+	newSite := func(name, wantAuth string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("Authorization"); got != wantAuth {
+				t.Errorf("%s: expected Authorization %q, got %q", name, wantAuth, got)
+			}
+			if got := r.Header.Get("User-Agent"); got != defaultUserAgent {
+				t.Errorf("%s: expected User-Agent %q, got %q", name, defaultUserAgent, got)
+			}
+			w.Write([]byte(name))
+		}))
+	}
+
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte("me@example.com:key-a"))
+	siteA := newSite("site-a", basic)
+	defer siteA.Close()
+	siteB := newSite("site-b", "Bearer key-b")
+	defer siteB.Close()
+
+	clientA := NewClient(siteA.URL, "me@example.com", "key-a")
+	clientB := NewClient(siteB.URL+"/gateway/api/graphql", "", "key-b")
+
+	for _, tc := range []struct {
+		client *Client
+		want   string
+	}{{clientA, "site-a"}, {clientB, "site-b"}} {
+		body, err := tc.client.GetIssue("TEST-1", "", "")
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if string(body) != tc.want {
+			t.Errorf("Expected response from %s, got %s", tc.want, body)
+		}
+	}
+
+	if clientB.GraphQLURL() != siteB.URL+"/gateway/api/graphql" {
+		t.Errorf("Unexpected GraphQL URL %s", clientB.GraphQLURL())
+	}
+}
+
+func TestPackageFunctionsUseDefaultClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/myself" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"accountId":"abc"}`))
+	}))
+	defer server.Close()
+
+	originalClient := DefaultClient
+	DefaultClient = NewClient(server.URL, "", "token")
+	defer func() { DefaultClient = originalClient }()
+
+	body, err := GetCurrentUser("")
+	if err != nil {
+		t.Fatalf("GetCurrentUser failed: %v", err)
+	}
+	if string(body) != `{"accountId":"abc"}` {
+		t.Errorf("Unexpected body %s", body)
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Arequestid", "req-42")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errorMessages":["Transition is not valid"],"errors":{"resolution":"Resolution is required"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", "token")
+	body, err := client.TransitionIssue("TEST-1", map[string]string{})
+	if body != nil {
+		t.Errorf("Expected no body on error, got %s", body)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Method != "POST" || apiErr.Endpoint != "/rest/api/3/issue/TEST-1/transitions" {
+		t.Errorf("Unexpected error fields: %+v", apiErr)
+	}
+	if apiErr.RequestID != "req-42" {
+		t.Errorf("Expected request ID req-42, got %q", apiErr.RequestID)
+	}
+	if msg := apiErr.Message(); msg != "Transition is not valid; resolution: Resolution is required" {
+		t.Errorf("Unexpected message %q", msg)
+	}
+	if IsRetryable(err) {
+		t.Error("Expected 400 not to be retryable")
+	}
+}
+
+func TestErrorPredicates(t *testing.T) {
+	for _, tc := range []struct {
+		status int
+		check  func(error) bool
+	}{
+		{http.StatusNotFound, IsNotFound},
+		{http.StatusUnauthorized, IsUnauthorized},
+		{http.StatusForbidden, IsForbidden},
+		{http.StatusTooManyRequests, IsRateLimited},
+		{http.StatusBadGateway, IsServerError},
+	} {
+		err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: tc.status})
+		if !tc.check(err) {
+			t.Errorf("Expected predicate to match status %d", tc.status)
+		}
+		if tc.check(&APIError{StatusCode: http.StatusTeapot}) {
+			t.Errorf("Expected predicate for %d not to match 418", tc.status)
+		}
+	}
+
+	refused := &url.Error{Op: "Post", URL: "https://example.test", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}
+	if !IsRetryable(refused) || !IsRetryable(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)) {
+		t.Error("Expected network errors to be retryable")
+	}
+	// Errors without a status may come after Jira answered, so aren't retried
+	if IsRetryable(errors.New("decoding worklog: unexpected end of JSON input")) {
+		t.Error("Expected an error raised after a response not to be retryable")
+	}
+	if !IsRetryable(&APIError{StatusCode: http.StatusTooManyRequests}) {
+		t.Error("Expected 429 to be retryable")
+	}
+	if IsRetryable(&APIError{StatusCode: http.StatusNotFound}) {
+		t.Error("Expected 404 not to be retryable")
+	}
+}
@@ -1,10 +1,20 @@
 package jiraApiFunctions
 
+import "context"
+
 // Announcement Banner APIs
-func GetAnnouncementBanner() ([]byte, error) {
-	return MakeJiraAPICall("GET", "/rest/api/3/announcementBanner", nil, nil)
+func (c *Client) GetAnnouncementBanner() ([]byte, error) {
+	return c.GetAnnouncementBannerContext(context.Background())
+}
+
+func (c *Client) GetAnnouncementBannerContext(ctx context.Context) ([]byte, error) {
+	return c.CallContext(ctx, "GET", "/rest/api/3/announcementBanner", nil, nil)
+}
+
+func (c *Client) SetAnnouncementBanner(config interface{}) ([]byte, error) {
+	return c.SetAnnouncementBannerContext(context.Background(), config)
 }
 
-func SetAnnouncementBanner(config interface{}) ([]byte, error) {
-	return MakeJiraAPICall("PUT", "/rest/api/3/announcementBanner", config, nil)
+func (c *Client) SetAnnouncementBannerContext(ctx context.Context, config interface{}) ([]byte, error) {
+	return c.CallContext(ctx, "PUT", "/rest/api/3/announcementBanner", config, nil)
 }
\ No newline at end of file
@@ -1,22 +1,37 @@
 package jiraApiFunctions
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // Application Properties APIs
-func GetApplicationProperties(key, keyFilter, permissionLevel string) ([]byte, error) {
+func (c *Client) GetApplicationProperties(key, keyFilter, permissionLevel string) ([]byte, error) {
+	return c.GetApplicationPropertiesContext(context.Background(), key, keyFilter, permissionLevel)
+}
+
+func (c *Client) GetApplicationPropertiesContext(ctx context.Context, key, keyFilter, permissionLevel string) ([]byte, error) {
 	params := map[string]string{
 		"key":             key,
 		"keyFilter":       keyFilter,
 		"permissionLevel": permissionLevel,
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/application-properties", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/application-properties", nil, params)
+}
+
+func (c *Client) GetAdvancedSettings() ([]byte, error) {
+	return c.GetAdvancedSettingsContext(context.Background())
+}
+
+func (c *Client) GetAdvancedSettingsContext(ctx context.Context) ([]byte, error) {
+	return c.CallContext(ctx, "GET", "/rest/api/3/application-properties/advanced-settings", nil, nil)
 }
 
-func GetAdvancedSettings() ([]byte, error) {
-	return MakeJiraAPICall("GET", "/rest/api/3/application-properties/advanced-settings", nil, nil)
+func (c *Client) SetApplicationProperty(id string, property interface{}) ([]byte, error) {
+	return c.SetApplicationPropertyContext(context.Background(), id, property)
 }
 
-func SetApplicationProperty(id string, property interface{}) ([]byte, error) {
+func (c *Client) SetApplicationPropertyContext(ctx context.Context, id string, property interface{}) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/application-properties/%s", id)
-	return MakeJiraAPICall("PUT", endpoint, property, nil)
+	return c.CallContext(ctx, "PUT", endpoint, property, nil)
 }
\ No newline at end of file
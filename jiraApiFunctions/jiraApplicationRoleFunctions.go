@@ -1,13 +1,24 @@
 package jiraApiFunctions
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // Application Role APIs
-func GetApplicationRoles() ([]byte, error) {
-	return MakeJiraAPICall("GET", "/rest/api/3/applicationrole", nil, nil)
+func (c *Client) GetApplicationRoles() ([]byte, error) {
+	return c.GetApplicationRolesContext(context.Background())
 }
 
-func GetApplicationRole(key string) ([]byte, error) {
+func (c *Client) GetApplicationRolesContext(ctx context.Context) ([]byte, error) {
+	return c.CallContext(ctx, "GET", "/rest/api/3/applicationrole", nil, nil)
+}
+
+func (c *Client) GetApplicationRole(key string) ([]byte, error) {
+	return c.GetApplicationRoleContext(context.Background(), key)
+}
+
+func (c *Client) GetApplicationRoleContext(ctx context.Context, key string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/applicationrole/%s", key)
-	return MakeJiraAPICall("GET", endpoint, nil, nil)
+	return c.CallContext(ctx, "GET", endpoint, nil, nil)
 }
\ No newline at end of file
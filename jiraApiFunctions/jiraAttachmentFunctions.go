@@ -1,22 +1,37 @@
 package jiraApiFunctions
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // Attachment APIs
-func GetAttachmentContent(id string, redirect bool) ([]byte, error) {
+func (c *Client) GetAttachmentContent(id string, redirect bool) ([]byte, error) {
+	return c.GetAttachmentContentContext(context.Background(), id, redirect)
+}
+
+func (c *Client) GetAttachmentContentContext(ctx context.Context, id string, redirect bool) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/attachment/content/%s", id)
 	params := map[string]string{}
 	if redirect {
 		params["redirect"] = "true"
 	}
-	return MakeJiraAPICall("GET", endpoint, nil, params)
+	return c.CallContext(ctx, "GET", endpoint, nil, params)
+}
+
+func (c *Client) GetAttachmentMeta() ([]byte, error) {
+	return c.GetAttachmentMetaContext(context.Background())
+}
+
+func (c *Client) GetAttachmentMetaContext(ctx context.Context) ([]byte, error) {
+	return c.CallContext(ctx, "GET", "/rest/api/3/attachment/meta", nil, nil)
 }
 
-func GetAttachmentMeta() ([]byte, error) {
-	return MakeJiraAPICall("GET", "/rest/api/3/attachment/meta", nil, nil)
+func (c *Client) GetAttachmentThumbnail(id string, redirect, fallbackToDefault bool, width, height int) ([]byte, error) {
+	return c.GetAttachmentThumbnailContext(context.Background(), id, redirect, fallbackToDefault, width, height)
 }
 
-func GetAttachmentThumbnail(id string, redirect, fallbackToDefault bool, width, height int) ([]byte, error) {
+func (c *Client) GetAttachmentThumbnailContext(ctx context.Context, id string, redirect, fallbackToDefault bool, width, height int) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/attachment/thumbnail/%s", id)
 	params := map[string]string{}
 	if redirect {
@@ -31,20 +46,32 @@ func GetAttachmentThumbnail(id string, redirect, fallbackToDefault bool, width,
 	if height > 0 {
 		params["height"] = fmt.Sprintf("%d", height)
 	}
-	return MakeJiraAPICall("GET", endpoint, nil, params)
+	return c.CallContext(ctx, "GET", endpoint, nil, params)
 }
 
-func DeleteAttachment(id string) ([]byte, error) {
+func (c *Client) DeleteAttachment(id string) ([]byte, error) {
+	return c.DeleteAttachmentContext(context.Background(), id)
+}
+
+func (c *Client) DeleteAttachmentContext(ctx context.Context, id string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/attachment/%s", id)
-	return MakeJiraAPICall("DELETE", endpoint, nil, nil)
+	return c.CallContext(ctx, "DELETE", endpoint, nil, nil)
+}
+
+func (c *Client) GetAttachmentExpandHuman(id string) ([]byte, error) {
+	return c.GetAttachmentExpandHumanContext(context.Background(), id)
 }
 
-func GetAttachmentExpandHuman(id string) ([]byte, error) {
+func (c *Client) GetAttachmentExpandHumanContext(ctx context.Context, id string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/attachment/%s/expand/human", id)
-	return MakeJiraAPICall("GET", endpoint, nil, nil)
+	return c.CallContext(ctx, "GET", endpoint, nil, nil)
+}
+
+func (c *Client) GetAttachmentExpandRaw(id string) ([]byte, error) {
+	return c.GetAttachmentExpandRawContext(context.Background(), id)
 }
 
-func GetAttachmentExpandRaw(id string) ([]byte, error) {
+func (c *Client) GetAttachmentExpandRawContext(ctx context.Context, id string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/attachment/%s/expand/raw", id)
-	return MakeJiraAPICall("GET", endpoint, nil, nil)
+	return c.CallContext(ctx, "GET", endpoint, nil, nil)
 }
\ No newline at end of file
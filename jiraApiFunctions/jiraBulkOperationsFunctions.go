@@ -1,44 +1,72 @@
 package jiraApiFunctions
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
 // Bulk Operations APIs
-func BulkDeleteIssues(issuesUpdate interface{}) ([]byte, error) {
-	return MakeJiraAPICall("POST", "/rest/api/3/bulk/issues/delete", issuesUpdate, nil)
+func (c *Client) BulkDeleteIssues(issuesUpdate interface{}) ([]byte, error) {
+	return c.BulkDeleteIssuesContext(context.Background(), issuesUpdate)
 }
 
-func GetBulkIssueFields(issueIds []string, expand string) ([]byte, error) {
+func (c *Client) BulkDeleteIssuesContext(ctx context.Context, issuesUpdate interface{}) ([]byte, error) {
+	return c.CallContext(ctx, "POST", "/rest/api/3/bulk/issues/delete", issuesUpdate, nil)
+}
+
+func (c *Client) GetBulkIssueFields(issueIdsOrKeys []string, searchText string) ([]byte, error) {
+	return c.GetBulkIssueFieldsContext(context.Background(), issueIdsOrKeys, searchText)
+}
+
+func (c *Client) GetBulkIssueFieldsContext(ctx context.Context, issueIdsOrKeys []string, searchText string) ([]byte, error) {
 	params := map[string]string{
-		"issueIds": strings.Join(issueIds, ","),
-		"expand":   expand,
+		"issueIdsOrKeys": strings.Join(issueIdsOrKeys, ","),
+		"searchText":     searchText,
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/bulk/issues/fields", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/bulk/issues/fields", nil, params)
+}
+
+func (c *Client) BulkMoveIssues(moveRequest interface{}) ([]byte, error) {
+	return c.BulkMoveIssuesContext(context.Background(), moveRequest)
+}
+
+func (c *Client) BulkMoveIssuesContext(ctx context.Context, moveRequest interface{}) ([]byte, error) {
+	return c.CallContext(ctx, "POST", "/rest/api/3/bulk/issues/move", moveRequest, nil)
 }
 
-func BulkMoveIssues(moveRequest interface{}) ([]byte, error) {
-	return MakeJiraAPICall("POST", "/rest/api/3/bulk/issues/move", moveRequest, nil)
+func (c *Client) GetBulkIssueTransitions(issueIdsOrKeys []string) ([]byte, error) {
+	return c.GetBulkIssueTransitionsContext(context.Background(), issueIdsOrKeys)
 }
 
-func GetBulkIssueTransitions(issueIds []string, expand string) ([]byte, error) {
+func (c *Client) GetBulkIssueTransitionsContext(ctx context.Context, issueIdsOrKeys []string) ([]byte, error) {
 	params := map[string]string{
-		"issueIds": strings.Join(issueIds, ","),
-		"expand":   expand,
+		"issueIdsOrKeys": strings.Join(issueIdsOrKeys, ","),
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/bulk/issues/transition", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/bulk/issues/transition", nil, params)
+}
+
+func (c *Client) BulkUnwatchIssues(unwatchRequest interface{}) ([]byte, error) {
+	return c.BulkUnwatchIssuesContext(context.Background(), unwatchRequest)
+}
+
+func (c *Client) BulkUnwatchIssuesContext(ctx context.Context, unwatchRequest interface{}) ([]byte, error) {
+	return c.CallContext(ctx, "POST", "/rest/api/3/bulk/issues/unwatch", unwatchRequest, nil)
+}
+
+func (c *Client) BulkWatchIssues(watchRequest interface{}) ([]byte, error) {
+	return c.BulkWatchIssuesContext(context.Background(), watchRequest)
 }
 
-func BulkUnwatchIssues(unwatchRequest interface{}) ([]byte, error) {
-	return MakeJiraAPICall("POST", "/rest/api/3/bulk/issues/unwatch", unwatchRequest, nil)
+func (c *Client) BulkWatchIssuesContext(ctx context.Context, watchRequest interface{}) ([]byte, error) {
+	return c.CallContext(ctx, "POST", "/rest/api/3/bulk/issues/watch", watchRequest, nil)
 }
 
-func BulkWatchIssues(watchRequest interface{}) ([]byte, error) {
-	return MakeJiraAPICall("POST", "/rest/api/3/bulk/issues/watch", watchRequest, nil)
+func (c *Client) GetBulkOperationStatus(taskId string) ([]byte, error) {
+	return c.GetBulkOperationStatusContext(context.Background(), taskId)
 }
 
-func GetBulkOperationStatus(taskId string) ([]byte, error) {
+func (c *Client) GetBulkOperationStatusContext(ctx context.Context, taskId string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/bulk/queue/%s", taskId)
-	return MakeJiraAPICall("GET", endpoint, nil, nil)
+	return c.CallContext(ctx, "GET", endpoint, nil, nil)
 }
\ No newline at end of file
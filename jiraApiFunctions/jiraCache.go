@@ -0,0 +1,334 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseCache keeps read responses on disk so a client can answer from
+// them without a round trip while they are fresh, revalidate them with
+// ETag or Last-Modified once they are not, and fall back to them when Jira
+// can't be reached.
+//
+// GET requests and GraphQL queries are cached, keyed by URL, request body
+// and credentials. A successful write drops the cached responses for the
+// resource it touched, along with searches and GraphQL queries whose
+// results may include it. Responses are kept well past their TTL for use
+// offline, then deleted, see cacheEvictAfter.
+type ResponseCache struct {
+	// Dir holds one file per cached response, in a directory per
+	// endpoint path, e.g. rest/api/3/issue/PROJ-1 under Dir.
+	Dir string
+	// TTLs gives the freshness of responses by endpoint; the first entry
+	// whose prefix matches wins. v2 paths are matched as their v3
+	// equivalents.
+	TTLs []CacheTTL
+	// DefaultTTL applies to endpoints without a matching entry.
+	DefaultTTL time.Duration
+
+	now func() time.Time
+
+	mu          sync.Mutex
+	lastEvicted time.Time
+}
+
+// CacheTTL is how long responses under an endpoint prefix stay fresh.
+type CacheTTL struct {
+	Prefix string
+	TTL    time.Duration
+}
+
+// DefaultCacheTTLs keeps issues and searches briefly and site metadata,
+// which rarely changes, for a day.
+var DefaultCacheTTLs = []CacheTTL{
+	{"/rest/api/3/issue/", 2 * time.Minute},
+	{"/rest/api/3/search", time.Minute},
+	{graphQlGatewayPath, 2 * time.Minute},
+	{"/rest/api/3/myself", time.Hour},
+	{"/rest/api/3/field", 24 * time.Hour},
+	{"/rest/api/3/project", 24 * time.Hour},
+	{"/rest/api/3/issuetype", 24 * time.Hour},
+	{"/rest/api/3/priority", 24 * time.Hour},
+	{"/rest/api/3/status", 24 * time.Hour},
+	{"/rest/api/3/serverInfo", 24 * time.Hour},
+}
+
+// volatilePaths are dropped from the cache by every write.
+var volatilePaths = []string{"/rest/api/3/search", graphQlGatewayPath}
+
+// cacheEvictAfter is how long a stored response is kept, well past the
+// longest TTL, so it can still stand in while Jira is unreachable.
+// Responses are deleted once they are older, at most every
+// cacheEvictInterval.
+const (
+	cacheEvictAfter    = 7 * 24 * time.Hour
+	cacheEvictInterval = time.Hour
+)
+
+// NewResponseCache returns a cache in dir using DefaultCacheTTLs. The
+// directory is created when the first response is stored.
+func NewResponseCache(dir string) *ResponseCache {
+	return &ResponseCache{
+		Dir:        dir,
+		TTLs:       DefaultCacheTTLs,
+		DefaultTTL: 5 * time.Minute,
+	}
+}
+
+// cacheEntry is the stored form of a response.
+type cacheEntry struct {
+	Method       string    `json:"method"`
+	URL          string    `json:"url"`
+	Path         string    `json:"path"`
+	StoredAt     time.Time `json:"storedAt"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Body         []byte    `json:"body"`
+}
+
+type cacheRefreshKey struct{}
+
+// WithCacheRefresh returns a context whose requests skip fresh cached
+// responses and ask Jira again. Jira may still answer 304 Not Modified for
+// an unchanged response, and offline the cached copy is still used.
+func WithCacheRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheRefreshKey{}, true)
+}
+
+func isCacheRefresh(ctx context.Context) bool {
+	refresh, _ := ctx.Value(cacheRefreshKey{}).(bool)
+	return refresh
+}
+
+// errNotModified is returned by send for a 304 answer to a conditional
+// request.
+var errNotModified = errors.New("not modified")
+
+// TTL returns how long responses from endpoint stay fresh.
+func (rc *ResponseCache) TTL(endpoint string) time.Duration {
+	path := cachePath(endpoint)
+	for _, rule := range rc.TTLs {
+		if strings.HasPrefix(path, rule.Prefix) {
+			return rule.TTL
+		}
+	}
+	return rc.DefaultTTL
+}
+
+// cachePath normalises endpoint for TTL and invalidation matching.
+func cachePath(endpoint string) string {
+	if i := strings.IndexByte(endpoint, '?'); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+	return strings.Replace(endpoint, "/rest/api/2/", "/rest/api/3/", 1)
+}
+
+func (rc *ResponseCache) clock() time.Time {
+	if rc.now != nil {
+		return rc.now()
+	}
+	return time.Now()
+}
+
+func (rc *ResponseCache) fresh(entry *cacheEntry) bool {
+	return rc.clock().Sub(entry.StoredAt) < rc.TTL(entry.Path)
+}
+
+// dir returns the directory responses from endpoint are kept in, with a
+// level per path segment, so a resource can be dropped together with
+// everything under it.
+func (rc *ResponseCache) dir(endpoint string) string {
+	dir := []string{rc.Dir}
+	for _, segment := range strings.Split(cachePath(endpoint), "/") {
+		segment = url.QueryEscape(segment)
+		if strings.Trim(segment, ".") == "" {
+			// Never "." or ".."
+			segment = strings.ReplaceAll(segment, ".", "%2E")
+		}
+		dir = append(dir, segment)
+	}
+	return filepath.Join(dir...)
+}
+
+func (rc *ResponseCache) file(endpoint, key string) string {
+	return filepath.Join(rc.dir(endpoint), key+".json")
+}
+
+func (rc *ResponseCache) load(endpoint, key string) *cacheEntry {
+	data, err := os.ReadFile(rc.file(endpoint, key))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if json.Unmarshal(data, &entry) != nil {
+		return nil
+	}
+	return &entry
+}
+
+// store writes entry through a temporary file, so a concurrent load never
+// sees half of it.
+func (rc *ResponseCache) store(key string, entry *cacheEntry) error {
+	rc.evictExpired()
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	dir := rc.dir(entry.Path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), rc.file(entry.Path, key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// invalidate drops the entries under the resource endpoint belongs to,
+// e.g. /rest/api/3/issue/PROJ-1 for a worklog added to PROJ-1, and the
+// volatile ones. Only their directories are touched.
+func (rc *ResponseCache) invalidate(endpoint string) {
+	resource := cachePath(endpoint)
+	if parts := strings.SplitN(resource, "/", 7); len(parts) == 7 {
+		resource = strings.Join(parts[:6], "/")
+	}
+	for _, path := range append([]string{resource}, volatilePaths...) {
+		os.RemoveAll(rc.dir(path))
+	}
+}
+
+// evictExpired deletes the responses stored more than cacheEvictAfter ago,
+// along with temporary files left behind, unless it has done so within
+// cacheEvictInterval.
+func (rc *ResponseCache) evictExpired() {
+	now := rc.clock()
+	rc.mu.Lock()
+	due := now.Sub(rc.lastEvicted) >= cacheEvictInterval
+	if due {
+		rc.lastEvicted = now
+	}
+	rc.mu.Unlock()
+	if !due {
+		return
+	}
+
+	filepath.WalkDir(rc.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && now.Sub(info.ModTime()) > cacheEvictAfter {
+			os.Remove(path)
+		}
+		return nil
+	})
+}
+
+// cacheKey identifies a request together with the credentials it was sent
+// with, so one user's responses are never served to another.
+func (c *Client) cacheKey(method, fullURL string, jsonBody []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + fullURL + "\n"))
+	if c.Tokens != nil {
+		// Access tokens rotate; the gateway URL carries the site instead.
+		h.Write([]byte("oauth\n"))
+	} else {
+		h.Write([]byte(c.Email + "\x00" + c.APIKey + "\n"))
+	}
+	h.Write(jsonBody)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cacheable reports whether a request only reads, and so may be answered
+// from the cache.
+func cacheable(method, endpoint string, retrySafe bool) bool {
+	return method == http.MethodGet || (endpoint == graphQlGatewayPath && method == http.MethodPost && retrySafe)
+}
+
+// isWrite reports whether a request may change what Jira returns for
+// later reads. Retry-safe POSTs are searches and queries.
+func isWrite(method string, retrySafe bool) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	case http.MethodPost:
+		return !retrySafe
+	}
+	return true
+}
+
+// cachedRoundTrip answers a read from the cache when it can, and keeps the
+// cache up to date with what Jira returns otherwise.
+func (c *Client) cachedRoundTrip(ctx context.Context, method, endpoint, fullURL string, jsonBody []byte, retrySafe bool) ([]byte, error) {
+	rc := c.Cache
+	key := c.cacheKey(method, fullURL, jsonBody)
+	entry := rc.load(endpoint, key)
+	if entry != nil && !isCacheRefresh(ctx) && rc.fresh(entry) {
+		c.logf("%s %s -> cached (%s old)", method, endpoint, rc.clock().Sub(entry.StoredAt).Round(time.Second))
+		return entry.Body, nil
+	}
+
+	var header http.Header
+	if entry != nil {
+		header = http.Header{}
+		if entry.ETag != "" {
+			header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	respBody, respHeader, err := c.roundTrip(ctx, method, endpoint, fullURL, jsonBody, header, retrySafe)
+	switch {
+	case err == nil:
+		entry = &cacheEntry{
+			Method:       method,
+			URL:          fullURL,
+			Path:         endpoint,
+			StoredAt:     rc.clock(),
+			ETag:         respHeader.Get("ETag"),
+			LastModified: respHeader.Get("Last-Modified"),
+			Body:         respBody,
+		}
+	case errors.Is(err, errNotModified) && entry != nil:
+		entry.StoredAt = rc.clock()
+	case entry != nil && isUnreachable(ctx, err):
+		c.logf("%s %s: Jira is unreachable, using the response cached at %s: %v", method, endpoint, entry.StoredAt.Format(time.RFC3339), err)
+		return entry.Body, nil
+	default:
+		return nil, err
+	}
+	if err := rc.store(key, entry); err != nil {
+		c.logf("%s %s: caching response failed: %v", method, endpoint, err)
+	}
+	return entry.Body, nil
+}
+
+// isUnreachable reports whether err means Jira could not be asked, as
+// opposed to Jira answering with an error, a login that needs renewing or
+// the caller giving up.
+func isUnreachable(ctx context.Context, err error) bool {
+	return ctx.Err() == nil && IsNetworkError(err)
+}
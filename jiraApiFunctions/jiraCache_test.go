@@ -0,0 +1,274 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// cachedClient returns a client for server with a cache in a temporary
+// directory and a clock the test moves with advance.
+func cachedClient(t *testing.T, server *httptest.Server) (*Client, func(time.Duration)) {
+	t.Helper()
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	client := NewClient(server.URL, "me@example.com", "key")
+	client.Retry = NoRetries
+	client.Cache = NewResponseCache(t.TempDir())
+	client.Cache.now = func() time.Time { return now }
+	return client, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestCache_ServesFreshResponses(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"key":"TEST-1"}`))
+	}))
+	defer server.Close()
+	client, advance := cachedClient(t, server)
+
+	for i := 0; i < 2; i++ {
+		body, err := client.GetIssue("TEST-1", "", "")
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if string(body) != `{"key":"TEST-1"}` {
+			t.Errorf("Unexpected body %s", body)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected the second call to be served from the cache, got %d requests", requests)
+	}
+
+	advance(3 * time.Minute)
+	if _, err := client.GetIssue("TEST-1", "", ""); err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected a stale issue to be fetched again, got %d requests", requests)
+	}
+}
+
+func TestCache_RevalidatesWithETag(t *testing.T) {
+	var requests, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`[{"id":"summary"}]`))
+	}))
+	defer server.Close()
+	client, advance := cachedClient(t, server)
+
+	if _, err := client.GetFields(); err != nil {
+		t.Fatalf("GetFields failed: %v", err)
+	}
+	advance(25 * time.Hour)
+	body, err := client.GetFields()
+	if err != nil {
+		t.Fatalf("GetFields failed: %v", err)
+	}
+	if string(body) != `[{"id":"summary"}]` || notModified != 1 {
+		t.Errorf("Expected the cached body after a 304, got %s (%d not modified)", body, notModified)
+	}
+
+	// The 304 made the entry fresh again.
+	if _, err := client.GetFields(); err != nil {
+		t.Fatalf("GetFields failed: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestCache_RefreshBypassesFreshEntries(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, _ := cachedClient(t, server)
+
+	ctx := context.Background()
+	client.GetIssueContext(ctx, "TEST-1", "", "")
+	client.GetIssueContext(WithCacheRefresh(ctx), "TEST-1", "", "")
+	if requests != 2 {
+		t.Errorf("Expected the refresh to reach Jira, got %d requests", requests)
+	}
+}
+
+func TestCache_ServesStaleResponsesOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"key":"TEST-1"}`))
+	}))
+	client, advance := cachedClient(t, server)
+
+	if _, err := client.GetIssue("TEST-1", "", ""); err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	server.Close()
+	advance(time.Hour)
+
+	body, err := client.GetIssue("TEST-1", "", "")
+	if err != nil {
+		t.Fatalf("Expected the cached issue while offline, got %v", err)
+	}
+	if string(body) != `{"key":"TEST-1"}` {
+		t.Errorf("Unexpected body %s", body)
+	}
+	if _, err := client.GetIssue("TEST-2", "", ""); err == nil {
+		t.Error("Expected an error for an issue that was never cached")
+	}
+}
+
+func TestCache_ErrorsAreNotCachedOrMasked(t *testing.T) {
+	var fail int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&fail) == 1 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, advance := cachedClient(t, server)
+
+	client.GetIssue("TEST-1", "", "")
+	atomic.StoreInt32(&fail, 1)
+	advance(time.Hour)
+	if _, err := client.GetIssue("TEST-1", "", ""); !IsNotFound(err) {
+		t.Errorf("Expected Jira's 404 rather than the cached issue, got %v", err)
+	}
+}
+
+// tokenFunc adapts a function to TokenSource.
+type tokenFunc func(ctx context.Context) (string, error)
+
+func (f tokenFunc) Token(ctx context.Context) (string, error) { return f(ctx) }
+
+func TestCache_ExpiredLoginIsNotMasked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, advance := cachedClient(t, server)
+	var revoked int32
+	client.Tokens = tokenFunc(func(ctx context.Context) (string, error) {
+		if atomic.LoadInt32(&revoked) == 1 {
+			return "", &OAuthError{StatusCode: http.StatusBadRequest, Code: "invalid_grant"}
+		}
+		return "access", nil
+	})
+
+	client.GetIssue("TEST-1", "", "")
+	atomic.StoreInt32(&revoked, 1)
+	advance(time.Hour)
+	if _, err := client.GetIssue("TEST-1", "", ""); !IsInvalidGrant(err) {
+		t.Errorf("Expected the revoked login rather than the cached issue, got %v", err)
+	}
+}
+
+func TestCache_WritesInvalidateTheirIssue(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			atomic.AddInt32(&requests, 1)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, _ := cachedClient(t, server)
+
+	client.GetIssue("TEST-1", "", "")
+	client.GetIssue("TEST-2", "", "")
+	client.SearchJQL("assignee = currentUser()", []string{"summary"}, 10)
+	if _, err := client.TransitionIssue("TEST-1", map[string]interface{}{"transition": map[string]string{"id": "31"}}); err != nil {
+		t.Fatalf("TransitionIssue failed: %v", err)
+	}
+
+	client.GetIssue("TEST-1", "", "")
+	client.GetIssue("TEST-2", "", "")
+	client.SearchJQL("assignee = currentUser()", []string{"summary"}, 10)
+	if requests != 5 {
+		t.Errorf("Expected TEST-1 and the search to be fetched again, got %d GETs", requests)
+	}
+}
+
+func TestCache_KeepsResponsesByPath(t *testing.T) {
+	cache := NewResponseCache(t.TempDir())
+	for endpoint, want := range map[string]string{
+		"/rest/api/2/issue/TEST-1?fields=summary": filepath.Join(cache.Dir, "rest", "api", "3", "issue", "TEST-1"),
+		"/rest/api/3/issue/TEST-1/worklog":        filepath.Join(cache.Dir, "rest", "api", "3", "issue", "TEST-1", "worklog"),
+		"/rest/api/3/issue/../myself":             filepath.Join(cache.Dir, "rest", "api", "3", "issue", "%2E%2E", "myself"),
+		"/rest/api/3/user/a:b c":                  filepath.Join(cache.Dir, "rest", "api", "3", "user", "a%3Ab+c"),
+	} {
+		if got := cache.dir(endpoint); got != want {
+			t.Errorf("dir(%s) = %s, want %s", endpoint, got, want)
+		}
+	}
+}
+
+func TestCache_EvictsOldResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, advance := cachedClient(t, server)
+	client.GetIssue("TEST-1", "", "")
+	old := filepath.Join(client.Cache.dir("/rest/api/3/issue/TEST-1"), "old.json")
+	os.WriteFile(old, []byte(`{}`), 0600)
+	stored := client.Cache.clock().Add(-cacheEvictAfter - time.Hour)
+	os.Chtimes(old, stored, stored)
+
+	// Eviction waits for cacheEvictInterval to pass since the last one
+	client.GetIssue("TEST-2", "", "")
+	if _, err := os.Stat(old); err != nil {
+		t.Fatalf("Expected the old response to be kept until the next eviction, got %v", err)
+	}
+	advance(cacheEvictInterval)
+	client.GetIssue("TEST-2", "", "")
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Errorf("Expected the old response to be evicted, got %v", err)
+	}
+	if entries, _ := os.ReadDir(client.Cache.dir("/rest/api/3/issue/TEST-1")); len(entries) != 1 {
+		t.Errorf("Expected the recent response to be kept, got %v", entries)
+	}
+}
+
+func TestCache_KeysIncludeCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	a := NewClient(server.URL, "a@example.com", "key")
+	b := NewClient(server.URL, "b@example.com", "key")
+	if a.cacheKey("GET", server.URL+"/rest/api/3/myself", nil) == b.cacheKey("GET", server.URL+"/rest/api/3/myself", nil) {
+		t.Error("Expected different users to get different cache keys")
+	}
+}
+
+func TestCache_TTL(t *testing.T) {
+	cache := NewResponseCache(t.TempDir())
+	for endpoint, want := range map[string]time.Duration{
+		"/rest/api/3/issue/TEST-1":   2 * time.Minute,
+		"/rest/api/2/issue/TEST-1":   2 * time.Minute,
+		"/rest/api/3/issuetype":      24 * time.Hour,
+		"/rest/api/3/field":          24 * time.Hour,
+		"/rest/api/3/project/search": 24 * time.Hour,
+		"/rest/api/3/myself":         time.Hour,
+		"/gateway/api/graphql":       2 * time.Minute,
+		"/rest/api/3/dashboard":      5 * time.Minute,
+	} {
+		if got := cache.TTL(endpoint); got != want {
+			t.Errorf("TTL(%s) = %s, want %s", endpoint, got, want)
+		}
+	}
+}
@@ -0,0 +1,397 @@
+package jiraApiFunctions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// CassetteSite stands in for the recorded site in cassettes, so links in
+// recorded bodies don't reveal which Jira they came from.
+const CassetteSite = "https://jira.example.test"
+
+// CassetteCloudID stands in for the recorded site's cloud ID, which the
+// API gateway paths of OAuth profiles and GraphQL queries carry.
+const CassetteCloudID = "00000000-0000-0000-0000-000000000000"
+
+// Cassette is a recording of Jira traffic that a Replayer can play back
+// without a network.
+type Cassette struct {
+	Site         string        `json:"site"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and Jira's response to it.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest identifies a request by method, path, query and body.
+// Headers, and with them the credentials, are never recorded.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	CassetteBody
+}
+
+// RecordedResponse is what Jira answered.
+type RecordedResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	CassetteBody
+}
+
+// CassetteBody holds a body as JSON when it is JSON, so cassettes stay
+// readable and can be edited by hand, and as text otherwise.
+type CassetteBody struct {
+	JSON json.RawMessage `json:"json,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+func newCassetteBody(body []byte) CassetteBody {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return CassetteBody{}
+	}
+	var compact bytes.Buffer
+	if json.Compact(&compact, body) == nil {
+		return CassetteBody{JSON: compact.Bytes()}
+	}
+	return CassetteBody{Text: string(body)}
+}
+
+// Bytes returns the body as it is sent.
+func (b CassetteBody) Bytes() []byte {
+	if len(b.JSON) > 0 {
+		return b.JSON
+	}
+	return []byte(b.Text)
+}
+
+// canonical returns the body with JSON objects' keys sorted, so bodies
+// that only differ in key order match.
+func (b CassetteBody) canonical() string {
+	if len(b.JSON) == 0 {
+		return b.Text
+	}
+	var v interface{}
+	if json.Unmarshal(b.JSON, &v) != nil {
+		return string(b.JSON)
+	}
+	canonical, _ := json.Marshal(v)
+	return string(canonical)
+}
+
+// recordedHeaders are the response headers worth keeping; cookies and
+// tracing headers are left out.
+var recordedHeaders = []string{"Content-Type", "ETag", "Last-Modified", "Retry-After"}
+
+// secretFields are JSON fields holding credentials or personal details,
+// whose values are replaced before a body is recorded. Users also have
+// their avatarUrls emptied and, where they carry one, their name replaced.
+var secretFields = []string{
+	"access_token", "refresh_token", "client_secret", "token", "apiToken", "password",
+	"emailAddress", "displayName", "accountId", "picture", "avatarUrl",
+}
+
+// secretFieldPattern finds secretFields in bodies that aren't valid JSON.
+var secretFieldPattern = regexp.MustCompile(`("(?:` + strings.Join(secretFields, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// gatewayPaths and userParams find the cloud ID and users in the paths,
+// query strings and links of recorded requests and responses. Cloud names
+// users by account ID, Data Center by username or key, and user searches
+// take a query that is often an email address.
+var (
+	gatewayPaths = regexp.MustCompile(`/ex/jira/[^/?&"\s]+`)
+	userParams   = regexp.MustCompile(`((?:^|[?&])(?:accountId|username|key|query)=)[^&#"\s]+`)
+)
+
+// redact removes credentials and personal details from body and replaces
+// the site's address with CassetteSite and its cloud ID with
+// CassetteCloudID.
+func redact(body []byte, site string) []byte {
+	if site != "" {
+		body = bytes.ReplaceAll(body, []byte(site), []byte(CassetteSite))
+	}
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if len(bytes.TrimSpace(body)) > 0 && decoder.Decode(&v) == nil && !decoder.More() {
+		var redacted bytes.Buffer
+		encoder := json.NewEncoder(&redacted)
+		encoder.SetEscapeHTML(false)
+		if encoder.Encode(redactJSON(v)) == nil {
+			body = bytes.TrimSuffix(redacted.Bytes(), []byte("\n"))
+		}
+	} else {
+		body = secretFieldPattern.ReplaceAll(body, []byte(`$1"REDACTED"`))
+	}
+	return []byte(redactURL(string(body)))
+}
+
+// redactJSON redacts a decoded JSON body in place.
+func redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		// A user's name and Data Center key, a username or JIRAUSERnnn,
+		// are personal; a status's name or an issue's key isn't
+		_, hasAccount := v["accountId"]
+		_, hasDisplayName := v["displayName"]
+		user := hasAccount || hasDisplayName
+		for key, value := range v {
+			_, isString := value.(string)
+			switch {
+			case key == "cloudId" && isString:
+				v[key] = CassetteCloudID
+			case key == "avatarUrls":
+				v[key] = map[string]interface{}{}
+			case isString && (isSecretField(key) || user && (key == "name" || key == "key")):
+				v[key] = "REDACTED"
+			default:
+				v[key] = redactJSON(value)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactJSON(v[i])
+		}
+	}
+	return v
+}
+
+func isSecretField(key string) bool {
+	for _, field := range secretFields {
+		if key == field {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL replaces the cloud ID in API gateway paths and users in query
+// strings.
+func redactURL(s string) string {
+	s = gatewayPaths.ReplaceAllString(s, "/ex/jira/"+CassetteCloudID)
+	return userParams.ReplaceAllString(s, "${1}REDACTED")
+}
+
+// LoadCassette reads a cassette written by a Recorder.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+	}
+	if cassette.Site == "" {
+		cassette.Site = CassetteSite
+	}
+	return &cassette, nil
+}
+
+// Save writes the cassette to path.
+func (c *Cassette) Save(path string) error {
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(c); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data.Bytes(), 0600)
+}
+
+// Recorder is an http.RoundTripper that passes requests on to Jira and
+// appends each exchange, redacted, to a cassette file. The file is
+// rewritten after every exchange, so a crash loses nothing.
+type Recorder struct {
+	path string
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder writing to path. next defaults to
+// http.DefaultTransport when nil.
+func NewRecorder(path string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{path: path, next: next, cassette: Cassette{Site: CassetteSite}}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	site := req.URL.Scheme + "://" + req.URL.Host
+	recorded := Interaction{
+		Request: RecordedRequest{
+			Method:       req.Method,
+			Path:         redactURL(req.URL.Path),
+			Query:        redactURL(req.URL.Query().Encode()),
+			CassetteBody: newCassetteBody(redact(reqBody, site)),
+		},
+		Response: RecordedResponse{
+			Status:       resp.StatusCode,
+			CassetteBody: newCassetteBody(redact(respBody, site)),
+		},
+	}
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			if recorded.Response.Headers == nil {
+				recorded.Response.Headers = map[string]string{}
+			}
+			recorded.Response.Headers[name] = value
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, recorded)
+	if err := r.cassette.Save(r.path); err != nil {
+		// The request has reached Jira, so failing it now would have the
+		// caller repeat a request that already took effect
+		log.Printf("Warning: failed to record to %s: %v", r.path, err)
+	}
+	return resp, nil
+}
+
+// Replayer is an http.RoundTripper that answers from a cassette. Requests
+// are matched on method, path, query and body, ignoring the host; a
+// request whose body differs from every recording, such as a worklog with
+// a new start time, gets the response to one with the same method, path
+// and query. Matching recordings are played in order and the last one is
+// repeated. Requests that were never recorded get a 404.
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	played   map[int]bool
+}
+
+// NewReplayer returns a Replayer for cassette.
+func NewReplayer(cassette *Cassette) *Replayer {
+	return &Replayer{cassette: cassette, played: map[int]bool{}}
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	query := req.URL.Query().Encode()
+
+	r.mu.Lock()
+	interaction := r.match(req.Method, req.URL.Path, query, newCassetteBody(body).canonical())
+	r.mu.Unlock()
+
+	if interaction == nil {
+		target := req.URL.Path
+		if query != "" {
+			target += "?" + query
+		}
+		message, _ := json.Marshal(map[string][]string{
+			"errorMessages": {fmt.Sprintf("no recorded response for %s %s", req.Method, target)},
+		})
+		return newReplayResponse(req, http.StatusNotFound, map[string]string{"Content-Type": "application/json"}, message), nil
+	}
+	recorded := interaction.Response
+	return newReplayResponse(req, recorded.Status, recorded.Headers, recorded.Bytes()), nil
+}
+
+// match picks the recording to play for a request. The caller holds r.mu.
+func (r *Replayer) match(method, path, query, body string) *Interaction {
+	var exact, loose []int
+	for i, interaction := range r.cassette.Interactions {
+		recorded := interaction.Request
+		if recorded.Method != method || recorded.Path != path || canonicalQuery(recorded.Query) != query {
+			continue
+		}
+		loose = append(loose, i)
+		if recorded.canonical() == body {
+			exact = append(exact, i)
+		}
+	}
+	candidates := exact
+	if len(candidates) == 0 {
+		candidates = loose
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	for _, i := range candidates {
+		if !r.played[i] {
+			r.played[i] = true
+			return &r.cassette.Interactions[i]
+		}
+	}
+	return &r.cassette.Interactions[candidates[len(candidates)-1]]
+}
+
+// canonicalQuery sorts a recorded query string, which may have been
+// written by hand.
+func canonicalQuery(query string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return query
+	}
+	return values.Encode()
+}
+
+func newReplayResponse(req *http.Request, status int, headers map[string]string, body []byte) *http.Response {
+	header := http.Header{}
+	for name, value := range headers {
+		header.Set(name, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
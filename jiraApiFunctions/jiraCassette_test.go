@@ -0,0 +1,182 @@
+package jiraApiFunctions
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_RedactsSecrets(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=cookie-secret")
+		w.Write([]byte(`{"self":"` + server.URL + `/rest/api/3/myself","emailAddress":"me@example.com","access_token":"token-secret"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	client := NewClient(server.URL, "me@example.com", "api-secret")
+	client.HTTPClient = &http.Client{Transport: NewRecorder(path, nil)}
+	body, err := client.GetCurrentUser("")
+	if err != nil {
+		t.Fatalf("GetCurrentUser failed: %v", err)
+	}
+	if !strings.Contains(string(body), "token-secret") {
+		t.Errorf("Expected the caller to get the real response, got %s", body)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"api-secret", "token-secret", "cookie-secret", "me@example.com", server.URL} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Cassette contains %q:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), CassetteSite+"/rest/api/3/myself") {
+		t.Errorf("Expected links to point at %s:\n%s", CassetteSite, data)
+	}
+}
+
+func TestRecorder_RedactsPersonalDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"self":"https://api.example.test/ex/jira/cloud-secret/rest/api/3/user?accountId=account-secret",` +
+			`"accountId":"account-secret","displayName":"Name Secret","avatarUrls":{"48x48":"https://avatar.example.test/secret.png"},` +
+			`"status":{"name":"In Progress"},"assignee":{"accountId":"account-secret","name":"Name Secret","picture":"https://avatar.example.test/secret.png"},` +
+			`"cloudId":"cloud-secret","timeSpentSeconds":3600}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	client := NewClient(server.URL+"/ex/jira/cloud-secret", "", "key")
+	client.HTTPClient = &http.Client{Transport: NewRecorder(path, nil)}
+	if _, err := client.GetUser("account-secret", "", "", ""); err != nil {
+		t.Fatalf("GetUser failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"cloud-secret", "account-secret", "Name Secret", "avatar.example.test"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Cassette contains %q:\n%s", secret, data)
+		}
+	}
+	for _, kept := range []string{`"name": "In Progress"`, `"timeSpentSeconds": 3600`, "/ex/jira/" + CassetteCloudID + "/rest/api/3/user"} {
+		if !strings.Contains(string(data), kept) {
+			t.Errorf("Expected the cassette to keep %s:\n%s", kept, data)
+		}
+	}
+}
+
+func TestRecorder_RedactsDataCenterUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"self":"https://jira.example.test/rest/api/2/user?username=jsecret","key":"JIRAUSER10100",` +
+			`"name":"jsecret","displayName":"Name Secret","emailAddress":"jane@mail.example.test",` +
+			`"issue":{"key":"PROJ-1"}}]`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	client := NewClient(server.URL, "", "key")
+	client.Deployment = DeploymentDataCenter
+	client.HTTPClient = &http.Client{Transport: NewRecorder(path, nil)}
+	if _, err := client.FindUsers("jane@mail.example.test", 0, 0, ""); err != nil {
+		t.Fatalf("FindUsers failed: %v", err)
+	}
+	if _, err := client.GetUser("", "jsecret", "JIRAUSER10100", ""); err != nil {
+		t.Fatalf("GetUser failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"jsecret", "JIRAUSER10100", "jane", "Name Secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Cassette contains %q:\n%s", secret, data)
+		}
+	}
+	for _, kept := range []string{`"key": "PROJ-1"`, "query=REDACTED", "username=REDACTED", "key=REDACTED"} {
+		if !strings.Contains(string(data), kept) {
+			t.Errorf("Expected the cassette to keep %s:\n%s", kept, data)
+		}
+	}
+}
+
+func TestRecorder_KeepsResponseWhenSaveFails(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"10001"}`))
+	}))
+	defer server.Close()
+
+	// The cassette can't be written, but Jira has already taken the worklog
+	notDir := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(notDir, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(notDir, "cassette.json")
+	client := NewClient(server.URL, "", "key")
+	client.HTTPClient = &http.Client{Transport: NewRecorder(path, nil)}
+	body, err := client.AddWorklog("TEST-1", map[string]string{"timeSpent": "1h"})
+	if err != nil || string(body) != `{"id":"10001"}` || posts != 1 {
+		t.Errorf("Expected the response despite the failed recording, got %s, %v after %d posts", body, err, posts)
+	}
+}
+
+func TestReplayer(t *testing.T) {
+	cassette := &Cassette{Site: CassetteSite, Interactions: []Interaction{
+		{
+			Request:  RecordedRequest{Method: "GET", Path: "/rest/api/3/issue/TEST-1", Query: "fields=summary"},
+			Response: RecordedResponse{Status: 200, CassetteBody: CassetteBody{JSON: []byte(`{"n":1}`)}},
+		},
+		{
+			Request:  RecordedRequest{Method: "GET", Path: "/rest/api/3/issue/TEST-1", Query: "fields=summary"},
+			Response: RecordedResponse{Status: 200, CassetteBody: CassetteBody{JSON: []byte(`{"n":2}`)}},
+		},
+		{
+			Request:  RecordedRequest{Method: "POST", Path: "/rest/api/3/issue/TEST-1/worklog", CassetteBody: CassetteBody{JSON: []byte(`{"timeSpent":"1h"}`)}},
+			Response: RecordedResponse{Status: 201, CassetteBody: CassetteBody{JSON: []byte(`{"id":"1"}`)}},
+		},
+		{
+			Request:  RecordedRequest{Method: "POST", Path: "/rest/api/3/issue/TEST-1/worklog", CassetteBody: CassetteBody{JSON: []byte(`{"timeSpent":"2h"}`)}},
+			Response: RecordedResponse{Status: 201, CassetteBody: CassetteBody{JSON: []byte(`{"id":"2"}`)}},
+		},
+	}}
+	client := NewClient(CassetteSite, "", "key")
+	client.HTTPClient = &http.Client{Transport: NewReplayer(cassette)}
+
+	for _, want := range []string{`{"n":1}`, `{"n":2}`, `{"n":2}`} {
+		body, err := client.GetIssue("TEST-1", "summary", "")
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if string(body) != want {
+			t.Errorf("Expected %s, got %s", want, body)
+		}
+	}
+
+	body, err := client.AddWorklog("TEST-1", map[string]string{"timeSpent": "2h"})
+	if err != nil || string(body) != `{"id":"2"}` {
+		t.Errorf("Expected the recording with the same body, got %s, %v", body, err)
+	}
+	body, err = client.AddWorklog("TEST-1", map[string]string{"timeSpent": "3h"})
+	if err != nil || string(body) != `{"id":"1"}` {
+		t.Errorf("Expected the first unplayed recording for a new body, got %s, %v", body, err)
+	}
+
+	if _, err := client.GetIssue("TEST-2", "", ""); !IsNotFound(err) {
+		t.Errorf("Expected a 404 for a request that wasn't recorded, got %v", err)
+	}
+}
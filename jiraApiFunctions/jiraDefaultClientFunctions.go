@@ -0,0 +1,747 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"jiraTimeWidget/jiraModels"
+)
+
+// Package-level wrappers that call the endpoint methods on DefaultClient.
+
+// Announcement Banner APIs
+func GetAnnouncementBanner() ([]byte, error) {
+	return DefaultClient.GetAnnouncementBanner()
+}
+
+func GetAnnouncementBannerContext(ctx context.Context) ([]byte, error) {
+	return DefaultClient.GetAnnouncementBannerContext(ctx)
+}
+
+func SetAnnouncementBanner(config interface{}) ([]byte, error) {
+	return DefaultClient.SetAnnouncementBanner(config)
+}
+
+func SetAnnouncementBannerContext(ctx context.Context, config interface{}) ([]byte, error) {
+	return DefaultClient.SetAnnouncementBannerContext(ctx, config)
+}
+
+// Application Properties APIs
+func GetApplicationProperties(key, keyFilter, permissionLevel string) ([]byte, error) {
+	return DefaultClient.GetApplicationProperties(key, keyFilter, permissionLevel)
+}
+
+func GetApplicationPropertiesContext(ctx context.Context, key, keyFilter, permissionLevel string) ([]byte, error) {
+	return DefaultClient.GetApplicationPropertiesContext(ctx, key, keyFilter, permissionLevel)
+}
+
+func GetAdvancedSettings() ([]byte, error) {
+	return DefaultClient.GetAdvancedSettings()
+}
+
+func GetAdvancedSettingsContext(ctx context.Context) ([]byte, error) {
+	return DefaultClient.GetAdvancedSettingsContext(ctx)
+}
+
+func SetApplicationProperty(id string, property interface{}) ([]byte, error) {
+	return DefaultClient.SetApplicationProperty(id, property)
+}
+
+func SetApplicationPropertyContext(ctx context.Context, id string, property interface{}) ([]byte, error) {
+	return DefaultClient.SetApplicationPropertyContext(ctx, id, property)
+}
+
+// Application Role APIs
+func GetApplicationRoles() ([]byte, error) {
+	return DefaultClient.GetApplicationRoles()
+}
+
+func GetApplicationRolesContext(ctx context.Context) ([]byte, error) {
+	return DefaultClient.GetApplicationRolesContext(ctx)
+}
+
+func GetApplicationRole(key string) ([]byte, error) {
+	return DefaultClient.GetApplicationRole(key)
+}
+
+func GetApplicationRoleContext(ctx context.Context, key string) ([]byte, error) {
+	return DefaultClient.GetApplicationRoleContext(ctx, key)
+}
+
+// Attachment APIs
+func GetAttachmentContent(id string, redirect bool) ([]byte, error) {
+	return DefaultClient.GetAttachmentContent(id, redirect)
+}
+
+func GetAttachmentContentContext(ctx context.Context, id string, redirect bool) ([]byte, error) {
+	return DefaultClient.GetAttachmentContentContext(ctx, id, redirect)
+}
+
+func GetAttachmentMeta() ([]byte, error) {
+	return DefaultClient.GetAttachmentMeta()
+}
+
+func GetAttachmentMetaContext(ctx context.Context) ([]byte, error) {
+	return DefaultClient.GetAttachmentMetaContext(ctx)
+}
+
+func GetAttachmentThumbnail(id string, redirect, fallbackToDefault bool, width, height int) ([]byte, error) {
+	return DefaultClient.GetAttachmentThumbnail(id, redirect, fallbackToDefault, width, height)
+}
+
+func GetAttachmentThumbnailContext(ctx context.Context, id string, redirect, fallbackToDefault bool, width, height int) ([]byte, error) {
+	return DefaultClient.GetAttachmentThumbnailContext(ctx, id, redirect, fallbackToDefault, width, height)
+}
+
+func DeleteAttachment(id string) ([]byte, error) {
+	return DefaultClient.DeleteAttachment(id)
+}
+
+func DeleteAttachmentContext(ctx context.Context, id string) ([]byte, error) {
+	return DefaultClient.DeleteAttachmentContext(ctx, id)
+}
+
+func GetAttachmentExpandHuman(id string) ([]byte, error) {
+	return DefaultClient.GetAttachmentExpandHuman(id)
+}
+
+func GetAttachmentExpandHumanContext(ctx context.Context, id string) ([]byte, error) {
+	return DefaultClient.GetAttachmentExpandHumanContext(ctx, id)
+}
+
+func GetAttachmentExpandRaw(id string) ([]byte, error) {
+	return DefaultClient.GetAttachmentExpandRaw(id)
+}
+
+func GetAttachmentExpandRawContext(ctx context.Context, id string) ([]byte, error) {
+	return DefaultClient.GetAttachmentExpandRawContext(ctx, id)
+}
+
+// Bulk Operations APIs
+func BulkDeleteIssues(issuesUpdate interface{}) ([]byte, error) {
+	return DefaultClient.BulkDeleteIssues(issuesUpdate)
+}
+
+func BulkDeleteIssuesContext(ctx context.Context, issuesUpdate interface{}) ([]byte, error) {
+	return DefaultClient.BulkDeleteIssuesContext(ctx, issuesUpdate)
+}
+
+func GetBulkIssueFields(issueIdsOrKeys []string, searchText string) ([]byte, error) {
+	return DefaultClient.GetBulkIssueFields(issueIdsOrKeys, searchText)
+}
+
+func GetBulkIssueFieldsContext(ctx context.Context, issueIdsOrKeys []string, searchText string) ([]byte, error) {
+	return DefaultClient.GetBulkIssueFieldsContext(ctx, issueIdsOrKeys, searchText)
+}
+
+func BulkMoveIssues(moveRequest interface{}) ([]byte, error) {
+	return DefaultClient.BulkMoveIssues(moveRequest)
+}
+
+func BulkMoveIssuesContext(ctx context.Context, moveRequest interface{}) ([]byte, error) {
+	return DefaultClient.BulkMoveIssuesContext(ctx, moveRequest)
+}
+
+func GetBulkIssueTransitions(issueIdsOrKeys []string) ([]byte, error) {
+	return DefaultClient.GetBulkIssueTransitions(issueIdsOrKeys)
+}
+
+func GetBulkIssueTransitionsContext(ctx context.Context, issueIdsOrKeys []string) ([]byte, error) {
+	return DefaultClient.GetBulkIssueTransitionsContext(ctx, issueIdsOrKeys)
+}
+
+func BulkUnwatchIssues(unwatchRequest interface{}) ([]byte, error) {
+	return DefaultClient.BulkUnwatchIssues(unwatchRequest)
+}
+
+func BulkUnwatchIssuesContext(ctx context.Context, unwatchRequest interface{}) ([]byte, error) {
+	return DefaultClient.BulkUnwatchIssuesContext(ctx, unwatchRequest)
+}
+
+func BulkWatchIssues(watchRequest interface{}) ([]byte, error) {
+	return DefaultClient.BulkWatchIssues(watchRequest)
+}
+
+func BulkWatchIssuesContext(ctx context.Context, watchRequest interface{}) ([]byte, error) {
+	return DefaultClient.BulkWatchIssuesContext(ctx, watchRequest)
+}
+
+func GetBulkOperationStatus(taskId string) ([]byte, error) {
+	return DefaultClient.GetBulkOperationStatus(taskId)
+}
+
+func GetBulkOperationStatusContext(ctx context.Context, taskId string) ([]byte, error) {
+	return DefaultClient.GetBulkOperationStatusContext(ctx, taskId)
+}
+
+// Field APIs
+func GetFields() ([]byte, error) {
+	return DefaultClient.GetFields()
+}
+
+func GetFieldsContext(ctx context.Context) ([]byte, error) {
+	return DefaultClient.GetFieldsContext(ctx)
+}
+
+func CreateCustomField(fieldData interface{}) ([]byte, error) {
+	return DefaultClient.CreateCustomField(fieldData)
+}
+
+func CreateCustomFieldContext(ctx context.Context, fieldData interface{}) ([]byte, error) {
+	return DefaultClient.CreateCustomFieldContext(ctx, fieldData)
+}
+
+func SearchFields(expand string, startAt, maxResults int, types, ids []string, query, orderBy string) ([]byte, error) {
+	return DefaultClient.SearchFields(expand, startAt, maxResults, types, ids, query, orderBy)
+}
+
+func SearchFieldsContext(ctx context.Context, expand string, startAt, maxResults int, types, ids []string, query, orderBy string) ([]byte, error) {
+	return DefaultClient.SearchFieldsContext(ctx, expand, startAt, maxResults, types, ids, query, orderBy)
+}
+
+func GetField(fieldId string) ([]byte, error) {
+	return DefaultClient.GetField(fieldId)
+}
+
+func GetFieldContext(ctx context.Context, fieldId string) ([]byte, error) {
+	return DefaultClient.GetFieldContext(ctx, fieldId)
+}
+
+func UpdateField(fieldId string, fieldData interface{}) ([]byte, error) {
+	return DefaultClient.UpdateField(fieldId, fieldData)
+}
+
+func UpdateFieldContext(ctx context.Context, fieldId string, fieldData interface{}) ([]byte, error) {
+	return DefaultClient.UpdateFieldContext(ctx, fieldId, fieldData)
+}
+
+func DeleteField(fieldId string) ([]byte, error) {
+	return DefaultClient.DeleteField(fieldId)
+}
+
+func DeleteFieldContext(ctx context.Context, fieldId string) ([]byte, error) {
+	return DefaultClient.DeleteFieldContext(ctx, fieldId)
+}
+
+// Group APIs
+func GetGroup(groupname, groupId, expand string) ([]byte, error) {
+	return DefaultClient.GetGroup(groupname, groupId, expand)
+}
+
+func GetGroupContext(ctx context.Context, groupname, groupId, expand string) ([]byte, error) {
+	return DefaultClient.GetGroupContext(ctx, groupname, groupId, expand)
+}
+
+func CreateGroup(groupData interface{}) ([]byte, error) {
+	return DefaultClient.CreateGroup(groupData)
+}
+
+func CreateGroupContext(ctx context.Context, groupData interface{}) ([]byte, error) {
+	return DefaultClient.CreateGroupContext(ctx, groupData)
+}
+
+func DeleteGroup(groupname, groupId, swapGroup, swapGroupId string) ([]byte, error) {
+	return DefaultClient.DeleteGroup(groupname, groupId, swapGroup, swapGroupId)
+}
+
+func DeleteGroupContext(ctx context.Context, groupname, groupId, swapGroup, swapGroupId string) ([]byte, error) {
+	return DefaultClient.DeleteGroupContext(ctx, groupname, groupId, swapGroup, swapGroupId)
+}
+
+func FindGroups(query string, exclude []string, maxResults int, userName string) ([]byte, error) {
+	return DefaultClient.FindGroups(query, exclude, maxResults, userName)
+}
+
+func FindGroupsContext(ctx context.Context, query string, exclude []string, maxResults int, userName string) ([]byte, error) {
+	return DefaultClient.FindGroupsContext(ctx, query, exclude, maxResults, userName)
+}
+
+// Issue APIs
+func GetIssue(issueIdOrKey string, fields, expand string) ([]byte, error) {
+	return DefaultClient.GetIssue(issueIdOrKey, fields, expand)
+}
+
+func GetIssueContext(ctx context.Context, issueIdOrKey string, fields, expand string) ([]byte, error) {
+	return DefaultClient.GetIssueContext(ctx, issueIdOrKey, fields, expand)
+}
+
+func CreateIssue(issueData interface{}) ([]byte, error) {
+	return DefaultClient.CreateIssue(issueData)
+}
+
+func CreateIssueContext(ctx context.Context, issueData interface{}) ([]byte, error) {
+	return DefaultClient.CreateIssueContext(ctx, issueData)
+}
+
+func UpdateIssue(issueIdOrKey string, issueData interface{}) ([]byte, error) {
+	return DefaultClient.UpdateIssue(issueIdOrKey, issueData)
+}
+
+func UpdateIssueContext(ctx context.Context, issueIdOrKey string, issueData interface{}) ([]byte, error) {
+	return DefaultClient.UpdateIssueContext(ctx, issueIdOrKey, issueData)
+}
+
+func DeleteIssue(issueIdOrKey, deleteSubtasks string) ([]byte, error) {
+	return DefaultClient.DeleteIssue(issueIdOrKey, deleteSubtasks)
+}
+
+func DeleteIssueContext(ctx context.Context, issueIdOrKey, deleteSubtasks string) ([]byte, error) {
+	return DefaultClient.DeleteIssueContext(ctx, issueIdOrKey, deleteSubtasks)
+}
+
+func GetIssueTransitions(issueIdOrKey, expand string) ([]byte, error) {
+	return DefaultClient.GetIssueTransitions(issueIdOrKey, expand)
+}
+
+func GetIssueTransitionsContext(ctx context.Context, issueIdOrKey, expand string) ([]byte, error) {
+	return DefaultClient.GetIssueTransitionsContext(ctx, issueIdOrKey, expand)
+}
+
+func TransitionIssue(issueIdOrKey string, transitionData interface{}) ([]byte, error) {
+	return DefaultClient.TransitionIssue(issueIdOrKey, transitionData)
+}
+
+func TransitionIssueContext(ctx context.Context, issueIdOrKey string, transitionData interface{}) ([]byte, error) {
+	return DefaultClient.TransitionIssueContext(ctx, issueIdOrKey, transitionData)
+}
+
+func GetIssueComments(issueIdOrKey string, startAt, maxResults int, orderBy, expand string) ([]byte, error) {
+	return DefaultClient.GetIssueComments(issueIdOrKey, startAt, maxResults, orderBy, expand)
+}
+
+func GetIssueCommentsContext(ctx context.Context, issueIdOrKey string, startAt, maxResults int, orderBy, expand string) ([]byte, error) {
+	return DefaultClient.GetIssueCommentsContext(ctx, issueIdOrKey, startAt, maxResults, orderBy, expand)
+}
+
+func AddComment(issueIdOrKey string, commentData interface{}) ([]byte, error) {
+	return DefaultClient.AddComment(issueIdOrKey, commentData)
+}
+
+func AddCommentContext(ctx context.Context, issueIdOrKey string, commentData interface{}) ([]byte, error) {
+	return DefaultClient.AddCommentContext(ctx, issueIdOrKey, commentData)
+}
+
+func UpdateComment(issueIdOrKey, commentId string, commentData interface{}) ([]byte, error) {
+	return DefaultClient.UpdateComment(issueIdOrKey, commentId, commentData)
+}
+
+func UpdateCommentContext(ctx context.Context, issueIdOrKey, commentId string, commentData interface{}) ([]byte, error) {
+	return DefaultClient.UpdateCommentContext(ctx, issueIdOrKey, commentId, commentData)
+}
+
+func DeleteComment(issueIdOrKey, commentId string) ([]byte, error) {
+	return DefaultClient.DeleteComment(issueIdOrKey, commentId)
+}
+
+func DeleteCommentContext(ctx context.Context, issueIdOrKey, commentId string) ([]byte, error) {
+	return DefaultClient.DeleteCommentContext(ctx, issueIdOrKey, commentId)
+}
+
+func GetIssueWatchers(issueIdOrKey string) ([]byte, error) {
+	return DefaultClient.GetIssueWatchers(issueIdOrKey)
+}
+
+func GetIssueWatchersContext(ctx context.Context, issueIdOrKey string) ([]byte, error) {
+	return DefaultClient.GetIssueWatchersContext(ctx, issueIdOrKey)
+}
+
+func AddWatcher(issueIdOrKey, accountId string) ([]byte, error) {
+	return DefaultClient.AddWatcher(issueIdOrKey, accountId)
+}
+
+func AddWatcherContext(ctx context.Context, issueIdOrKey, accountId string) ([]byte, error) {
+	return DefaultClient.AddWatcherContext(ctx, issueIdOrKey, accountId)
+}
+
+func RemoveWatcher(issueIdOrKey, accountId string) ([]byte, error) {
+	return DefaultClient.RemoveWatcher(issueIdOrKey, accountId)
+}
+
+func RemoveWatcherContext(ctx context.Context, issueIdOrKey, accountId string) ([]byte, error) {
+	return DefaultClient.RemoveWatcherContext(ctx, issueIdOrKey, accountId)
+}
+
+func GetIssueWorklog(issueIdOrKey string, startAt, maxResults int, expand string) ([]byte, error) {
+	return DefaultClient.GetIssueWorklog(issueIdOrKey, startAt, maxResults, expand)
+}
+
+func GetIssueWorklogContext(ctx context.Context, issueIdOrKey string, startAt, maxResults int, expand string) ([]byte, error) {
+	return DefaultClient.GetIssueWorklogContext(ctx, issueIdOrKey, startAt, maxResults, expand)
+}
+
+func AddWorklog(issueIdOrKey string, worklogData interface{}) ([]byte, error) {
+	return DefaultClient.AddWorklog(issueIdOrKey, worklogData)
+}
+
+func AddWorklogContext(ctx context.Context, issueIdOrKey string, worklogData interface{}) ([]byte, error) {
+	return DefaultClient.AddWorklogContext(ctx, issueIdOrKey, worklogData)
+}
+
+func UpdateWorklog(issueIdOrKey, worklogId string, worklogData interface{}) ([]byte, error) {
+	return DefaultClient.UpdateWorklog(issueIdOrKey, worklogId, worklogData)
+}
+
+func UpdateWorklogContext(ctx context.Context, issueIdOrKey, worklogId string, worklogData interface{}) ([]byte, error) {
+	return DefaultClient.UpdateWorklogContext(ctx, issueIdOrKey, worklogId, worklogData)
+}
+
+func DeleteWorklog(issueIdOrKey, worklogId string) ([]byte, error) {
+	return DefaultClient.DeleteWorklog(issueIdOrKey, worklogId)
+}
+
+func DeleteWorklogContext(ctx context.Context, issueIdOrKey, worklogId string) ([]byte, error) {
+	return DefaultClient.DeleteWorklogContext(ctx, issueIdOrKey, worklogId)
+}
+
+// Auditing APIs
+func GetAuditRecords(offset, limit int, filter, from, to string) ([]byte, error) {
+	return DefaultClient.GetAuditRecords(offset, limit, filter, from, to)
+}
+
+func GetAuditRecordsContext(ctx context.Context, offset, limit int, filter, from, to string) ([]byte, error) {
+	return DefaultClient.GetAuditRecordsContext(ctx, offset, limit, filter, from, to)
+}
+
+// Avatar APIs
+func GetSystemAvatars(avatarType string) ([]byte, error) {
+	return DefaultClient.GetSystemAvatars(avatarType)
+}
+
+func GetSystemAvatarsContext(ctx context.Context, avatarType string) ([]byte, error) {
+	return DefaultClient.GetSystemAvatarsContext(ctx, avatarType)
+}
+
+// Changelog APIs
+func GetChangelogsBulk(changelogIds interface{}) ([]byte, error) {
+	return DefaultClient.GetChangelogsBulk(changelogIds)
+}
+
+func GetChangelogsBulkContext(ctx context.Context, changelogIds interface{}) ([]byte, error) {
+	return DefaultClient.GetChangelogsBulkContext(ctx, changelogIds)
+}
+
+// Classification APIs
+func GetClassificationLevels(status []string, orderBy string) ([]byte, error) {
+	return DefaultClient.GetClassificationLevels(status, orderBy)
+}
+
+func GetClassificationLevelsContext(ctx context.Context, status []string, orderBy string) ([]byte, error) {
+	return DefaultClient.GetClassificationLevelsContext(ctx, status, orderBy)
+}
+
+// Comment APIs
+func GetCommentsList(commentRequest interface{}) ([]byte, error) {
+	return DefaultClient.GetCommentsList(commentRequest)
+}
+
+func GetCommentsListContext(ctx context.Context, commentRequest interface{}) ([]byte, error) {
+	return DefaultClient.GetCommentsListContext(ctx, commentRequest)
+}
+
+func GetCommentProperties(commentId string) ([]byte, error) {
+	return DefaultClient.GetCommentProperties(commentId)
+}
+
+func GetCommentPropertiesContext(ctx context.Context, commentId string) ([]byte, error) {
+	return DefaultClient.GetCommentPropertiesContext(ctx, commentId)
+}
+
+func DeleteCommentProperty(commentId, propertyKey string) ([]byte, error) {
+	return DefaultClient.DeleteCommentProperty(commentId, propertyKey)
+}
+
+func DeleteCommentPropertyContext(ctx context.Context, commentId, propertyKey string) ([]byte, error) {
+	return DefaultClient.DeleteCommentPropertyContext(ctx, commentId, propertyKey)
+}
+
+// Component APIs
+func GetComponents(query, projectIdsOrKeys, orderBy string, maxResults int) ([]byte, error) {
+	return DefaultClient.GetComponents(query, projectIdsOrKeys, orderBy, maxResults)
+}
+
+func GetComponentsContext(ctx context.Context, query, projectIdsOrKeys, orderBy string, maxResults int) ([]byte, error) {
+	return DefaultClient.GetComponentsContext(ctx, query, projectIdsOrKeys, orderBy, maxResults)
+}
+
+func DeleteComponent(id, moveIssuesTo string) ([]byte, error) {
+	return DefaultClient.DeleteComponent(id, moveIssuesTo)
+}
+
+func DeleteComponentContext(ctx context.Context, id, moveIssuesTo string) ([]byte, error) {
+	return DefaultClient.DeleteComponentContext(ctx, id, moveIssuesTo)
+}
+
+func GetComponentRelatedIssueCounts(id string) ([]byte, error) {
+	return DefaultClient.GetComponentRelatedIssueCounts(id)
+}
+
+func GetComponentRelatedIssueCountsContext(ctx context.Context, id string) ([]byte, error) {
+	return DefaultClient.GetComponentRelatedIssueCountsContext(ctx, id)
+}
+
+// Configuration APIs
+func GetConfiguration() ([]byte, error) {
+	return DefaultClient.GetConfiguration()
+}
+
+func GetConfigurationContext(ctx context.Context) ([]byte, error) {
+	return DefaultClient.GetConfigurationContext(ctx)
+}
+
+func GetTimeTrackingConfiguration() ([]byte, error) {
+	return DefaultClient.GetTimeTrackingConfiguration()
+}
+
+func GetTimeTrackingConfigurationContext(ctx context.Context) ([]byte, error) {
+	return DefaultClient.GetTimeTrackingConfigurationContext(ctx)
+}
+
+func GetTimeTrackingProviders() ([]byte, error) {
+	return DefaultClient.GetTimeTrackingProviders()
+}
+
+func GetTimeTrackingProvidersContext(ctx context.Context) ([]byte, error) {
+	return DefaultClient.GetTimeTrackingProvidersContext(ctx)
+}
+
+func GetTimeTrackingOptions() ([]byte, error) {
+	return DefaultClient.GetTimeTrackingOptions()
+}
+
+func GetTimeTrackingOptionsContext(ctx context.Context) ([]byte, error) {
+	return DefaultClient.GetTimeTrackingOptionsContext(ctx)
+}
+
+// Custom Field APIs
+func GetCustomFieldOption(id string) ([]byte, error) {
+	return DefaultClient.GetCustomFieldOption(id)
+}
+
+func GetCustomFieldOptionContext(ctx context.Context, id string) ([]byte, error) {
+	return DefaultClient.GetCustomFieldOptionContext(ctx, id)
+}
+
+// Dashboard APIs
+func GetDashboards(filter string, startAt, maxResults int) ([]byte, error) {
+	return DefaultClient.GetDashboards(filter, startAt, maxResults)
+}
+
+func GetDashboardsContext(ctx context.Context, filter string, startAt, maxResults int) ([]byte, error) {
+	return DefaultClient.GetDashboardsContext(ctx, filter, startAt, maxResults)
+}
+
+func BulkEditDashboards(editRequest interface{}) ([]byte, error) {
+	return DefaultClient.BulkEditDashboards(editRequest)
+}
+
+func BulkEditDashboardsContext(ctx context.Context, editRequest interface{}) ([]byte, error) {
+	return DefaultClient.BulkEditDashboardsContext(ctx, editRequest)
+}
+
+func GetAvailableGadgets() ([]byte, error) {
+	return DefaultClient.GetAvailableGadgets()
+}
+
+func GetAvailableGadgetsContext(ctx context.Context) ([]byte, error) {
+	return DefaultClient.GetAvailableGadgetsContext(ctx)
+}
+
+func GetDashboardGadgets(dashboardId string, moduleKey, uri []string, gadgetId []int) ([]byte, error) {
+	return DefaultClient.GetDashboardGadgets(dashboardId, moduleKey, uri, gadgetId)
+}
+
+func GetDashboardGadgetsContext(ctx context.Context, dashboardId string, moduleKey, uri []string, gadgetId []int) ([]byte, error) {
+	return DefaultClient.GetDashboardGadgetsContext(ctx, dashboardId, moduleKey, uri, gadgetId)
+}
+
+func SearchDashboards(dashboardName, accountId, owner, groupname, groupId string, projectId int, orderBy, status, expand string, startAt, maxResults int) ([]byte, error) {
+	return DefaultClient.SearchDashboards(dashboardName, accountId, owner, groupname, groupId, projectId, orderBy, status, expand, startAt, maxResults)
+}
+
+func SearchDashboardsContext(ctx context.Context, dashboardName, accountId, owner, groupname, groupId string, projectId int, orderBy, status, expand string, startAt, maxResults int) ([]byte, error) {
+	return DefaultClient.SearchDashboardsContext(ctx, dashboardName, accountId, owner, groupname, groupId, projectId, orderBy, status, expand, startAt, maxResults)
+}
+
+// Data Policy APIs
+func GetDataPolicy() ([]byte, error) {
+	return DefaultClient.GetDataPolicy()
+}
+
+func GetDataPolicyContext(ctx context.Context) ([]byte, error) {
+	return DefaultClient.GetDataPolicyContext(ctx)
+}
+
+func GetProjectDataPolicy(ids string) ([]byte, error) {
+	return DefaultClient.GetProjectDataPolicy(ids)
+}
+
+func GetProjectDataPolicyContext(ctx context.Context, ids string) ([]byte, error) {
+	return DefaultClient.GetProjectDataPolicyContext(ctx, ids)
+}
+
+// Tenant Info APIs
+func GetTenantInfo() ([]byte, error) {
+	return DefaultClient.GetTenantInfo()
+}
+
+func GetTenantInfoContext(ctx context.Context) ([]byte, error) {
+	return DefaultClient.GetTenantInfoContext(ctx)
+}
+
+// Project APIs
+func GetProjects(expand string, recent int, properties []string) ([]byte, error) {
+	return DefaultClient.GetProjects(expand, recent, properties)
+}
+
+func GetProjectsContext(ctx context.Context, expand string, recent int, properties []string) ([]byte, error) {
+	return DefaultClient.GetProjectsContext(ctx, expand, recent, properties)
+}
+
+func CreateProject(projectData interface{}) ([]byte, error) {
+	return DefaultClient.CreateProject(projectData)
+}
+
+func CreateProjectContext(ctx context.Context, projectData interface{}) ([]byte, error) {
+	return DefaultClient.CreateProjectContext(ctx, projectData)
+}
+
+func GetProject(projectIdOrKey, expand string, properties []string) ([]byte, error) {
+	return DefaultClient.GetProject(projectIdOrKey, expand, properties)
+}
+
+func GetProjectContext(ctx context.Context, projectIdOrKey, expand string, properties []string) ([]byte, error) {
+	return DefaultClient.GetProjectContext(ctx, projectIdOrKey, expand, properties)
+}
+
+func UpdateProject(projectIdOrKey string, projectData interface{}) ([]byte, error) {
+	return DefaultClient.UpdateProject(projectIdOrKey, projectData)
+}
+
+func UpdateProjectContext(ctx context.Context, projectIdOrKey string, projectData interface{}) ([]byte, error) {
+	return DefaultClient.UpdateProjectContext(ctx, projectIdOrKey, projectData)
+}
+
+func DeleteProject(projectIdOrKey string, enableUndo bool) ([]byte, error) {
+	return DefaultClient.DeleteProject(projectIdOrKey, enableUndo)
+}
+
+func DeleteProjectContext(ctx context.Context, projectIdOrKey string, enableUndo bool) ([]byte, error) {
+	return DefaultClient.DeleteProjectContext(ctx, projectIdOrKey, enableUndo)
+}
+
+func GetProjectComponents(projectIdOrKey string) ([]byte, error) {
+	return DefaultClient.GetProjectComponents(projectIdOrKey)
+}
+
+func GetProjectComponentsContext(ctx context.Context, projectIdOrKey string) ([]byte, error) {
+	return DefaultClient.GetProjectComponentsContext(ctx, projectIdOrKey)
+}
+
+func GetProjectVersions(projectIdOrKey, expand string) ([]byte, error) {
+	return DefaultClient.GetProjectVersions(projectIdOrKey, expand)
+}
+
+func GetProjectVersionsContext(ctx context.Context, projectIdOrKey, expand string) ([]byte, error) {
+	return DefaultClient.GetProjectVersionsContext(ctx, projectIdOrKey, expand)
+}
+
+// Search APIs
+func SearchIssues(jql, expand string, fields []string, startAt, maxResults int, validateQuery bool) ([]byte, error) {
+	return DefaultClient.SearchIssues(jql, expand, fields, startAt, maxResults, validateQuery)
+}
+
+func SearchIssuesContext(ctx context.Context, jql, expand string, fields []string, startAt, maxResults int, validateQuery bool) ([]byte, error) {
+	return DefaultClient.SearchIssuesContext(ctx, jql, expand, fields, startAt, maxResults, validateQuery)
+}
+
+func SearchIssuesPost(searchRequest interface{}) ([]byte, error) {
+	return DefaultClient.SearchIssuesPost(searchRequest)
+}
+
+func SearchIssuesPostContext(ctx context.Context, searchRequest interface{}) ([]byte, error) {
+	return DefaultClient.SearchIssuesPostContext(ctx, searchRequest)
+}
+
+func SearchJQL(jql string, fields []string, maxResults int) ([]byte, error) {
+	return DefaultClient.SearchJQL(jql, fields, maxResults)
+}
+
+func SearchJQLContext(ctx context.Context, jql string, fields []string, maxResults int) ([]byte, error) {
+	return DefaultClient.SearchJQLContext(ctx, jql, fields, maxResults)
+}
+
+// User APIs
+func GetCurrentUser(expand string) ([]byte, error) {
+	return DefaultClient.GetCurrentUser(expand)
+}
+
+func GetCurrentUserContext(ctx context.Context, expand string) ([]byte, error) {
+	return DefaultClient.GetCurrentUserContext(ctx, expand)
+}
+
+func GetUser(accountId, username, key, expand string) ([]byte, error) {
+	return DefaultClient.GetUser(accountId, username, key, expand)
+}
+
+func GetUserContext(ctx context.Context, accountId, username, key, expand string) ([]byte, error) {
+	return DefaultClient.GetUserContext(ctx, accountId, username, key, expand)
+}
+
+func CreateUser(userData interface{}) ([]byte, error) {
+	return DefaultClient.CreateUser(userData)
+}
+
+func CreateUserContext(ctx context.Context, userData interface{}) ([]byte, error) {
+	return DefaultClient.CreateUserContext(ctx, userData)
+}
+
+func DeleteUser(accountId, username, key string) ([]byte, error) {
+	return DefaultClient.DeleteUser(accountId, username, key)
+}
+
+func DeleteUserContext(ctx context.Context, accountId, username, key string) ([]byte, error) {
+	return DefaultClient.DeleteUserContext(ctx, accountId, username, key)
+}
+
+func FindUsers(query string, startAt, maxResults int, property string) ([]byte, error) {
+	return DefaultClient.FindUsers(query, startAt, maxResults, property)
+}
+
+func FindUsersContext(ctx context.Context, query string, startAt, maxResults int, property string) ([]byte, error) {
+	return DefaultClient.FindUsersContext(ctx, query, startAt, maxResults, property)
+}
+
+func GetUserGroups(accountId, username, key string) ([]byte, error) {
+	return DefaultClient.GetUserGroups(accountId, username, key)
+}
+
+func GetUserGroupsContext(ctx context.Context, accountId, username, key string) ([]byte, error) {
+	return DefaultClient.GetUserGroupsContext(ctx, accountId, username, key)
+}
+
+// Typed APIs
+func Issue(ctx context.Context, issueIdOrKey string, fields []string, expand string) (*jiraModels.Issue, error) {
+	return DefaultClient.Issue(ctx, issueIdOrKey, fields, expand)
+}
+
+func Transitions(ctx context.Context, issueIdOrKey string) ([]jiraModels.Transition, error) {
+	return DefaultClient.Transitions(ctx, issueIdOrKey)
+}
+
+func Myself(ctx context.Context) (*jiraModels.User, error) {
+	return DefaultClient.Myself(ctx)
+}
+
+func CreateWorklog(ctx context.Context, issueIdOrKey string, worklogData interface{}) (*jiraModels.Worklog, error) {
+	return DefaultClient.CreateWorklog(ctx, issueIdOrKey, worklogData)
+}
+
+func Search(ctx context.Context, jql string, fields []string, opts PageOptions) *Iterator[jiraModels.Issue] {
+	return DefaultClient.Search(ctx, jql, fields, opts)
+}
+
+func Worklogs(ctx context.Context, issueIdOrKey string, opts PageOptions) *Iterator[jiraModels.Worklog] {
+	return DefaultClient.Worklogs(ctx, issueIdOrKey, opts)
+}
+
+func Comments(ctx context.Context, issueIdOrKey string, opts PageOptions) *Iterator[jiraModels.Comment] {
+	return DefaultClient.Comments(ctx, issueIdOrKey, opts)
+}
@@ -0,0 +1,82 @@
+package jiraApiFunctions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Deployment says which kind of Jira a client talks to. Endpoints in this
+// package are written against Cloud's REST API v3; Data Center and Server
+// only have v2, which takes plain-text comments, identifies users by
+// username rather than accountId and has no enhanced JQL search.
+type Deployment string
+
+const (
+	DeploymentCloud      Deployment = "cloud"
+	DeploymentDataCenter Deployment = "datacenter"
+)
+
+const (
+	restAPIv3Prefix = "/rest/api/3/"
+	restAPIv2Prefix = "/rest/api/2/"
+)
+
+// ParseDeployment reads a deployment type as written in configuration.
+// An empty string is Cloud, and "server" is treated like Data Center.
+func ParseDeployment(s string) (Deployment, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", string(DeploymentCloud):
+		return DeploymentCloud, nil
+	case string(DeploymentDataCenter), "data-center", "server":
+		return DeploymentDataCenter, nil
+	default:
+		return "", fmt.Errorf("unknown Jira deployment %q (use %q or %q)", s, DeploymentCloud, DeploymentDataCenter)
+	}
+}
+
+// IsDataCenter reports whether the client talks to Jira Data Center or
+// Server.
+func (c *Client) IsDataCenter() bool {
+	return c.Deployment == DeploymentDataCenter
+}
+
+// apiPath maps a REST API v3 endpoint to the version the site speaks.
+func (c *Client) apiPath(endpoint string) string {
+	if c.IsDataCenter() && strings.HasPrefix(endpoint, restAPIv3Prefix) {
+		return restAPIv2Prefix + strings.TrimPrefix(endpoint, restAPIv3Prefix)
+	}
+	return endpoint
+}
+
+// TextBody returns text in the form the site expects for rich-text fields
+// such as comments: an Atlassian Document Format paragraph on Cloud and
+// plain text (wiki markup) on Data Center.
+func (c *Client) TextBody(text string) interface{} {
+	if c.IsDataCenter() {
+		return text
+	}
+	return map[string]interface{}{
+		"type":    "doc",
+		"version": 1,
+		"content": []map[string]interface{}{
+			{
+				"type": "paragraph",
+				"content": []map[string]interface{}{
+					{
+						"text": text,
+						"type": "text",
+					},
+				},
+			},
+		},
+	}
+}
+
+// UserID picks the identifier the site uses for a user out of a user
+// object's fields: the accountId on Cloud and the username on Data Center.
+func (c *Client) UserID(accountID, name string) string {
+	if c.IsDataCenter() {
+		return name
+	}
+	return accountID
+}
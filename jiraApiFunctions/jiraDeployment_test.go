@@ -0,0 +1,70 @@
+package jiraApiFunctions
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestDataCenter_UsesAPIv2(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if got := r.Header.Get("Authorization"); got != "Bearer pat-token" {
+			t.Errorf("Expected the PAT as a Bearer token, got %q", got)
+		}
+		w.Write([]byte(`{"issues":[]}`))
+	}))
+	defer server.Close()
+
+	cloud := NewClient(server.URL, "", "pat-token")
+	// An email left in the profile doesn't turn the PAT into Basic auth
+	dc := NewClient(server.URL, "me@example.com", "pat-token")
+	dc.Deployment = DeploymentDataCenter
+
+	cloud.GetIssue("TEST-1", "", "")
+	dc.GetIssue("TEST-1", "", "")
+	cloud.SearchJQL("project = TEST", []string{"summary"}, 10)
+	dc.SearchJQL("project = TEST", []string{"summary"}, 10)
+
+	want := []string{"/rest/api/3/issue/TEST-1", "/rest/api/2/issue/TEST-1", "/rest/api/3/search/jql", "/rest/api/2/search"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Expected paths %v, got %v", want, paths)
+	}
+}
+
+func TestDataCenter_TextBodyAndUserID(t *testing.T) {
+	dc := &Client{Deployment: DeploymentDataCenter}
+	if got := dc.TextBody("Worked on it"); got != "Worked on it" {
+		t.Errorf("Expected a plain-text body on Data Center, got %#v", got)
+	}
+	if got := dc.UserID("account-1", "jdoe"); got != "jdoe" {
+		t.Errorf("Expected the username on Data Center, got %q", got)
+	}
+
+	cloud := &Client{}
+	doc, ok := cloud.TextBody("Worked on it").(map[string]interface{})
+	if !ok || doc["type"] != "doc" {
+		t.Errorf("Expected an ADF document on Cloud, got %#v", cloud.TextBody("Worked on it"))
+	}
+	if got := cloud.UserID("account-1", "jdoe"); got != "account-1" {
+		t.Errorf("Expected the accountId on Cloud, got %q", got)
+	}
+}
+
+func TestParseDeployment(t *testing.T) {
+	for in, want := range map[string]Deployment{
+		"":           DeploymentCloud,
+		"cloud":      DeploymentCloud,
+		"DataCenter": DeploymentDataCenter,
+		"server":     DeploymentDataCenter,
+	} {
+		if got, err := ParseDeployment(in); err != nil || got != want {
+			t.Errorf("ParseDeployment(%q) = %q, %v; expected %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseDeployment("mainframe"); err == nil {
+		t.Error("Expected an unknown deployment to be rejected")
+	}
+}
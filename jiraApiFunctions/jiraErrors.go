@@ -0,0 +1,165 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// APIError is returned for every response outside the 2xx range.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Endpoint   string
+	// ErrorMessages and Errors come from Jira's standard error body;
+	// Errors maps field names to problems with them.
+	ErrorMessages []string
+	Errors        map[string]string
+	// RequestID is Jira's X-AREQUESTID, useful when raising a support ticket.
+	RequestID string
+	// RetryAfter is how long Jira asked us to wait before trying again,
+	// taken from Retry-After or X-RateLimit-Reset. Zero if it didn't say.
+	RetryAfter time.Duration
+	// Body holds the raw response when it isn't a Jira error document.
+	Body []byte
+}
+
+func newAPIError(resp *http.Response, method, endpoint string, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     method,
+		Endpoint:   endpoint,
+		RequestID:  resp.Header.Get("X-Arequestid"),
+		RetryAfter: retryAfter(resp.Header, time.Now()),
+	}
+
+	var errorBody struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		ErrorMessage  string            `json:"errorMessage"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if json.Unmarshal(body, &errorBody) == nil {
+		apiErr.ErrorMessages = errorBody.ErrorMessages
+		if errorBody.ErrorMessage != "" {
+			apiErr.ErrorMessages = append(apiErr.ErrorMessages, errorBody.ErrorMessage)
+		}
+		apiErr.Errors = errorBody.Errors
+	}
+	if len(apiErr.ErrorMessages) == 0 && len(apiErr.Errors) == 0 {
+		apiErr.Body = body
+	}
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s: %d %s", e.Method, e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+	if msg := e.Message(); msg != "" {
+		b.WriteString(": ")
+		b.WriteString(msg)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// Message returns Jira's explanation of the error, if it gave one.
+func (e *APIError) Message() string {
+	messages := append([]string(nil), e.ErrorMessages...)
+
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, field+": "+e.Errors[field])
+	}
+
+	if len(messages) == 0 && len(e.Body) > 0 && len(e.Body) <= 200 {
+		messages = append(messages, strings.TrimSpace(string(e.Body)))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// StatusCode returns the HTTP status of an *APIError in err's chain, or 0.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether Jira answered 404, which it also uses for
+// issues the user isn't allowed to see.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether Jira rejected the credentials.
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// IsForbidden reports whether the user lacks permission for the request.
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsRateLimited reports whether Jira throttled the request.
+func IsRateLimited(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests
+}
+
+// IsServerError reports whether Jira failed with a 5xx status.
+func IsServerError(err error) bool {
+	return StatusCode(err) >= 500
+}
+
+// IsRetryable reports whether the same request may succeed later: network
+// failures, timeouts, throttling and server errors. Other API errors, OAuth
+// token errors, and errors raised after Jira answered, such as a response
+// that can't be decoded, will fail the same way or may already have taken
+// effect, so they are not retried.
+func IsRetryable(err error) bool {
+	if errors.Is(err, ErrCreatedUnconfirmed) {
+		return false
+	}
+	if IsNetworkError(err) {
+		return true
+	}
+	status := StatusCode(err)
+	return status == http.StatusRequestTimeout ||
+		status == http.StatusTooManyRequests || status >= 500
+}
+
+// IsNetworkError reports whether err is a failure to reach Jira or to hear
+// back from it: a timeout, a refused or reset connection, or another
+// net.Error from the transport.
+func IsNetworkError(err error) bool {
+	var oauthErr *OAuthError
+	if err == nil || errors.As(err, &oauthErr) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	// The client wraps whatever the transport returned in a *url.Error,
+	// which is itself a net.Error, so look at what it wraps
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -1,20 +1,33 @@
 package jiraApiFunctions
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
 // Field APIs
-func GetFields() ([]byte, error) {
-	return MakeJiraAPICall("GET", "/rest/api/3/field", nil, nil)
+func (c *Client) GetFields() ([]byte, error) {
+	return c.GetFieldsContext(context.Background())
 }
 
-func CreateCustomField(fieldData interface{}) ([]byte, error) {
-	return MakeJiraAPICall("POST", "/rest/api/3/field", fieldData, nil)
+func (c *Client) GetFieldsContext(ctx context.Context) ([]byte, error) {
+	return c.CallContext(ctx, "GET", "/rest/api/3/field", nil, nil)
 }
 
-func SearchFields(expand string, startAt, maxResults int, types, ids []string, query, orderBy string) ([]byte, error) {
+func (c *Client) CreateCustomField(fieldData interface{}) ([]byte, error) {
+	return c.CreateCustomFieldContext(context.Background(), fieldData)
+}
+
+func (c *Client) CreateCustomFieldContext(ctx context.Context, fieldData interface{}) ([]byte, error) {
+	return c.CallContext(ctx, "POST", "/rest/api/3/field", fieldData, nil)
+}
+
+func (c *Client) SearchFields(expand string, startAt, maxResults int, types, ids []string, query, orderBy string) ([]byte, error) {
+	return c.SearchFieldsContext(context.Background(), expand, startAt, maxResults, types, ids, query, orderBy)
+}
+
+func (c *Client) SearchFieldsContext(ctx context.Context, expand string, startAt, maxResults int, types, ids []string, query, orderBy string) ([]byte, error) {
 	params := map[string]string{
 		"expand":  expand,
 		"query":   query,
@@ -32,20 +45,33 @@ func SearchFields(expand string, startAt, maxResults int, types, ids []string, q
 	if maxResults > 0 {
 		params["maxResults"] = fmt.Sprintf("%d", maxResults)
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/field/search", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/field/search", nil, params)
 }
 
-func GetField(fieldId string) ([]byte, error) {
-	endpoint := fmt.Sprintf("/rest/api/3/field/%s", fieldId)
-	return MakeJiraAPICall("GET", endpoint, nil, nil)
+func (c *Client) GetField(fieldId string) ([]byte, error) {
+	return c.GetFieldContext(context.Background(), fieldId)
 }
 
-func UpdateField(fieldId string, fieldData interface{}) ([]byte, error) {
+// GetFieldContext looks a field up through the field search, as Jira has
+// no endpoint for a single field; the response is a page of at most one.
+func (c *Client) GetFieldContext(ctx context.Context, fieldId string) ([]byte, error) {
+	return c.SearchFieldsContext(ctx, "", 0, 0, nil, []string{fieldId}, "", "")
+}
+
+func (c *Client) UpdateField(fieldId string, fieldData interface{}) ([]byte, error) {
+	return c.UpdateFieldContext(context.Background(), fieldId, fieldData)
+}
+
+func (c *Client) UpdateFieldContext(ctx context.Context, fieldId string, fieldData interface{}) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/field/%s", fieldId)
-	return MakeJiraAPICall("PUT", endpoint, fieldData, nil)
+	return c.CallContext(ctx, "PUT", endpoint, fieldData, nil)
+}
+
+func (c *Client) DeleteField(fieldId string) ([]byte, error) {
+	return c.DeleteFieldContext(context.Background(), fieldId)
 }
 
-func DeleteField(fieldId string) ([]byte, error) {
+func (c *Client) DeleteFieldContext(ctx context.Context, fieldId string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/field/%s", fieldId)
-	return MakeJiraAPICall("DELETE", endpoint, nil, nil)
+	return c.CallContext(ctx, "DELETE", endpoint, nil, nil)
 }
\ No newline at end of file
@@ -0,0 +1,341 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// graphqlFiles holds the named operations and fragments the client can run
+// with GraphQLQuery.
+//
+//go:embed graphql/*.graphql
+var graphqlFiles embed.FS
+
+// GraphQLRequest is the body posted to the GraphQL gateway.
+type GraphQLRequest struct {
+	Query         string `json:"query"`
+	OperationName string `json:"operationName,omitempty"`
+	// Variables is anything that marshals to a JSON object: a map or a
+	// struct with json tags.
+	Variables interface{} `json:"variables,omitempty"`
+}
+
+// GraphQLResponse is a decoded gateway response.
+type GraphQLResponse struct {
+	Data       json.RawMessage   `json:"data"`
+	Errors     []GraphQLError    `json:"errors"`
+	Extensions GraphQLExtensions `json:"extensions"`
+}
+
+// GraphQLExtensions holds the extensions the Atlassian gateway adds to
+// every response.
+type GraphQLExtensions struct {
+	Gateway GraphQLGateway `json:"gateway"`
+}
+
+// GraphQLGateway identifies the request within the gateway; quote the
+// RequestID when raising a support ticket.
+type GraphQLGateway struct {
+	RequestID       string `json:"request_id"`
+	TraceID         string `json:"trace_id"`
+	CrossRegion     bool   `json:"crossRegion"`
+	EdgeCrossRegion bool   `json:"edgeCrossRegion"`
+}
+
+// GraphQLError is one entry of a response's errors.
+type GraphQLError struct {
+	Message string `json:"message"`
+	// Path leads to the field that failed; its elements are field names
+	// and list indexes.
+	Path       []interface{}          `json:"path"`
+	Locations  []GraphQLLocation      `json:"locations"`
+	Extensions map[string]interface{} `json:"extensions"`
+}
+
+// GraphQLLocation points into the query document.
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// PathString returns the error's path as dotted text, e.g.
+// "jira.issueByKey.fields.edges.0".
+func (e GraphQLError) PathString() string {
+	parts := make([]string, len(e.Path))
+	for i, p := range e.Path {
+		parts[i] = fmt.Sprint(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+func (e GraphQLError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (at %s)", e.Message, e.PathString())
+}
+
+// GraphQLErrors is returned when a response carries errors. The gateway
+// may still have answered part of the query, and that data is decoded
+// regardless.
+type GraphQLErrors struct {
+	Operation string
+	Errors    []GraphQLError
+	RequestID string
+}
+
+func (e *GraphQLErrors) Error() string {
+	var b strings.Builder
+	b.WriteString("GraphQL")
+	if e.Operation != "" {
+		b.WriteString(" " + e.Operation)
+	}
+	b.WriteString(": ")
+	for i, err := range e.Errors {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(err.Error())
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// IsGraphQLError reports whether err came from errors in a GraphQL
+// response rather than from the transport.
+func IsGraphQLError(err error) bool {
+	var gqlErr *GraphQLErrors
+	return errors.As(err, &gqlErr)
+}
+
+// GraphQLQuery runs the operation called name from the embedded .graphql
+// files, sending along the fragments it uses, and decodes the response's
+// data into data. Queries are retried like GETs; mutations are not.
+func (c *Client) GraphQLQuery(ctx context.Context, name string, variables, data interface{}) (*GraphQLResponse, error) {
+	doc, err := GraphQLDocument(name)
+	if err != nil {
+		return nil, err
+	}
+	kind, _ := operationKind(doc)
+	request := GraphQLRequest{Query: doc, OperationName: name, Variables: variables}
+	return c.graphQL(ctx, request, kind == "query", data)
+}
+
+// GraphQLExec runs an ad hoc query document and decodes the response's
+// data into data.
+func (c *Client) GraphQLExec(ctx context.Context, query string, variables, data interface{}) (*GraphQLResponse, error) {
+	kind, name := operationKind(query)
+	request := GraphQLRequest{Query: query, OperationName: name, Variables: variables}
+	return c.graphQL(ctx, request, kind == "query", data)
+}
+
+func (c *Client) graphQL(ctx context.Context, request GraphQLRequest, retrySafe bool, data interface{}) (*GraphQLResponse, error) {
+	body, err := c.postGraphQLRetry(ctx, request, retrySafe)
+	if err != nil {
+		return nil, err
+	}
+	var response GraphQLResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("unexpected GraphQL response: %w", err)
+	}
+	if data != nil && len(response.Data) > 0 && string(response.Data) != "null" {
+		if err := json.Unmarshal(response.Data, data); err != nil {
+			return &response, fmt.Errorf("decoding GraphQL data: %w", err)
+		}
+	}
+	if len(response.Errors) > 0 {
+		return &response, &GraphQLErrors{
+			Operation: request.OperationName,
+			Errors:    response.Errors,
+			RequestID: response.Extensions.Gateway.RequestID,
+		}
+	}
+	return &response, nil
+}
+
+func (c *Client) postGraphQL(ctx context.Context, request GraphQLRequest) ([]byte, error) {
+	return c.postGraphQLRetry(ctx, request, false)
+}
+
+func (c *Client) postGraphQLRetry(ctx context.Context, request GraphQLRequest, retrySafe bool) ([]byte, error) {
+	jsonBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, "POST", graphQlGatewayPath, c.GraphQLURL(), jsonBody, retrySafe)
+}
+
+// graphQLDefinitions holds the operations and fragments of the embedded
+// files, keyed by name.
+type graphQLDefinitions struct {
+	operations map[string]string
+	fragments  map[string]string
+}
+
+var (
+	definitionsOnce  sync.Once
+	definitions      *graphQLDefinitions
+	definitionsErr   error
+	definitionHeader = regexp.MustCompile(`^(query|mutation|subscription|fragment)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+	fragmentSpread   = regexp.MustCompile(`\.\.\.\s*([_A-Za-z][_0-9A-Za-z]*)`)
+)
+
+// GraphQLDocument returns the embedded operation called name followed by
+// every fragment it uses, directly or through other fragments.
+func GraphQLDocument(name string) (string, error) {
+	definitionsOnce.Do(func() {
+		definitions, definitionsErr = parseGraphQLFiles(graphqlFiles)
+	})
+	if definitionsErr != nil {
+		return "", definitionsErr
+	}
+	return definitions.document(name)
+}
+
+func (d *graphQLDefinitions) document(name string) (string, error) {
+	operation, ok := d.operations[name]
+	if !ok {
+		return "", fmt.Errorf("no GraphQL operation named %q", name)
+	}
+
+	used := map[string]bool{}
+	pending := []string{operation}
+	for len(pending) > 0 {
+		text := pending[0]
+		pending = pending[1:]
+		for _, m := range fragmentSpread.FindAllStringSubmatch(text, -1) {
+			fragment := m[1]
+			if fragment == "on" || used[fragment] {
+				continue
+			}
+			def, ok := d.fragments[fragment]
+			if !ok {
+				return "", fmt.Errorf("GraphQL operation %s uses unknown fragment %s", name, fragment)
+			}
+			used[fragment] = true
+			pending = append(pending, def)
+		}
+	}
+
+	names := make([]string, 0, len(used))
+	for fragment := range used {
+		names = append(names, fragment)
+	}
+	sort.Strings(names)
+	parts := []string{operation}
+	for _, fragment := range names {
+		parts = append(parts, d.fragments[fragment])
+	}
+	return strings.Join(parts, "\n\n"), nil
+}
+
+func parseGraphQLFiles(fsys fs.FS) (*graphQLDefinitions, error) {
+	defs := &graphQLDefinitions{operations: map[string]string{}, fragments: map[string]string{}}
+	files, err := fs.Glob(fsys, "graphql/*.graphql")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		definitions, err := splitGraphQLDefinitions(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		for _, def := range definitions {
+			m := definitionHeader.FindStringSubmatch(def)
+			if m == nil {
+				return nil, fmt.Errorf("%s: definitions must be named queries, mutations or fragments", file)
+			}
+			target := defs.operations
+			if m[1] == "fragment" {
+				target = defs.fragments
+			}
+			if _, dup := target[m[2]]; dup {
+				return nil, fmt.Errorf("%s: %s %s is defined twice", file, m[1], m[2])
+			}
+			target[m[2]] = def
+		}
+	}
+	return defs, nil
+}
+
+// splitGraphQLDefinitions cuts a document into its top-level definitions,
+// dropping comments.
+func splitGraphQLDefinitions(doc string) ([]string, error) {
+	var defs []string
+	var current strings.Builder
+	depth := 0
+	inString := false
+	for i := 0; i < len(doc); i++ {
+		ch := doc[i]
+		switch {
+		case inString:
+			if ch == '\\' && i+1 < len(doc) {
+				current.WriteByte(ch)
+				i++
+				ch = doc[i]
+			} else if ch == '"' {
+				inString = false
+			}
+		case ch == '#':
+			for i < len(doc) && doc[i] != '\n' {
+				i++
+			}
+			ch = '\n'
+		case ch == '"':
+			inString = true
+		case ch == '{':
+			depth++
+		case ch == '}':
+			depth--
+			if depth < 0 {
+				return nil, errors.New("unbalanced braces")
+			}
+			if depth == 0 {
+				current.WriteByte(ch)
+				defs = append(defs, strings.TrimSpace(current.String()))
+				current.Reset()
+				continue
+			}
+		}
+		current.WriteByte(ch)
+	}
+	if depth != 0 || inString {
+		return nil, errors.New("unterminated definition")
+	}
+	if strings.TrimSpace(current.String()) != "" {
+		return nil, errors.New("text after the last definition")
+	}
+	return defs, nil
+}
+
+// operationKind returns the type and name of a document's first
+// operation. A bare selection set is an anonymous query.
+func operationKind(doc string) (kind, name string) {
+	defs, err := splitGraphQLDefinitions(doc)
+	if err != nil {
+		return "", ""
+	}
+	for _, def := range defs {
+		if strings.HasPrefix(def, "{") {
+			return "query", ""
+		}
+		if m := definitionHeader.FindStringSubmatch(def); m != nil && m[1] != "fragment" {
+			return m[1], m[2]
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,138 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestGraphQLQuery_SendsNamedOperationWithFragments(t *testing.T) {
+	var request GraphQLRequest
+	var variables map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != graphQlGatewayPath {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		var raw struct {
+			GraphQLRequest
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&raw)
+		request, variables = raw.GraphQLRequest, raw.Variables
+		w.Write([]byte(`{"data":{"me":{"user":{"accountId":"abc"}}},"extensions":{"gateway":{"request_id":"req-1"}}}`))
+	}))
+	defer server.Close()
+
+	var data struct {
+		Me struct {
+			User struct {
+				AccountID string `json:"accountId"`
+			} `json:"user"`
+		} `json:"me"`
+	}
+	vars := struct {
+		Limit int `json:"limit"`
+	}{Limit: 3}
+	response, err := NewClient(server.URL, "", "key").GraphQLQuery(context.Background(), "currentUser", vars, &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Me.User.AccountID != "abc" || response.Extensions.Gateway.RequestID != "req-1" {
+		t.Errorf("Unexpected response %+v, %+v", data, response.Extensions)
+	}
+	if request.OperationName != "currentUser" || !strings.Contains(request.Query, "fragment AccountUserFields on AtlassianAccountUser") {
+		t.Errorf("Expected the operation with its fragment, got %+v", request)
+	}
+	if strings.Contains(request.Query, "#") {
+		t.Errorf("Expected comments to be stripped, got %q", request.Query)
+	}
+	if variables["limit"] != float64(3) {
+		t.Errorf("Expected typed variables to be sent, got %v", variables)
+	}
+}
+
+func TestGraphQLQuery_ReturnsErrorsWithPaths(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"me":{"user":{"accountId":"abc"}}},
+			"errors":[{"message":"Not permitted","path":["me","user","picture"]},{"message":"Slow down"}],
+			"extensions":{"gateway":{"request_id":"req-2"}}}`))
+	}))
+	defer server.Close()
+
+	var data map[string]interface{}
+	response, err := NewClient(server.URL, "", "key").GraphQLQuery(context.Background(), "currentUser", nil, &data)
+	if !IsGraphQLError(err) {
+		t.Fatalf("Expected a GraphQL error, got %v", err)
+	}
+	want := "GraphQL currentUser: Not permitted (at me.user.picture); Slow down (request ID req-2)"
+	if err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+	if response == nil || data["me"] == nil {
+		t.Errorf("Expected partial data alongside the error, got %v", data)
+	}
+}
+
+func TestGraphQLExec_AnonymousQueryIsRetried(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"data":{"ok":true}}`))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "", "key")
+	client.Retry = &RetryPolicy{MaxAttempts: 2}
+
+	var data struct{ OK bool }
+	if _, err := client.GraphQLExec(context.Background(), "{ ok }", nil, &data); err != nil || !data.OK || calls != 2 {
+		t.Errorf("Expected a retried query, got %+v after %d calls (%v)", data, calls, err)
+	}
+
+	calls = 0
+	if _, err := client.GraphQLExec(context.Background(), "mutation doIt { ok }", nil, nil); err == nil || calls != 1 {
+		t.Errorf("Expected a mutation not to be retried, got %d calls (%v)", calls, err)
+	}
+}
+
+func TestParseGraphQLFiles(t *testing.T) {
+	defs, err := parseGraphQLFiles(fstest.MapFS{
+		"graphql/a.graphql": {Data: []byte(`
+# A comment with a { brace
+query issue($key: String!) { issue(key: $key) { ...IssueFields ... on Bug { severity } } }
+fragment IssueFields on Issue { key status { ...StatusFields } }`)},
+		"graphql/b.graphql": {Data: []byte(`fragment StatusFields on Status { name description(format: "}") }
+query broken { ...Missing }`)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := defs.document("issue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(doc, "query issue") || !strings.Contains(doc, "fragment IssueFields") || !strings.Contains(doc, "fragment StatusFields") {
+		t.Errorf("Expected the query and both fragments, got:\n%s", doc)
+	}
+	if _, err := defs.document("broken"); err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Errorf("Expected an unknown fragment error, got %v", err)
+	}
+	if _, err := defs.document("nope"); err == nil {
+		t.Error("Expected an error for an unknown operation")
+	}
+
+	_, err = parseGraphQLFiles(fstest.MapFS{
+		"graphql/a.graphql": {Data: []byte(`query one { a }`)},
+		"graphql/b.graphql": {Data: []byte(`query one { b }`)},
+	})
+	if err == nil || !strings.Contains(err.Error(), "defined twice") {
+		t.Errorf("Expected a duplicate definition error, got %v", err)
+	}
+}
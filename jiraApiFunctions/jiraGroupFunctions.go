@@ -1,35 +1,52 @@
 package jiraApiFunctions
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
 // Group APIs
-func GetGroup(groupname, groupId, expand string) ([]byte, error) {
+func (c *Client) GetGroup(groupname, groupId, expand string) ([]byte, error) {
+	return c.GetGroupContext(context.Background(), groupname, groupId, expand)
+}
+
+func (c *Client) GetGroupContext(ctx context.Context, groupname, groupId, expand string) ([]byte, error) {
 	params := map[string]string{
 		"groupname": groupname,
 		"groupId":   groupId,
 		"expand":    expand,
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/group", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/group", nil, params)
+}
+
+func (c *Client) CreateGroup(groupData interface{}) ([]byte, error) {
+	return c.CreateGroupContext(context.Background(), groupData)
 }
 
-func CreateGroup(groupData interface{}) ([]byte, error) {
-	return MakeJiraAPICall("POST", "/rest/api/3/group", groupData, nil)
+func (c *Client) CreateGroupContext(ctx context.Context, groupData interface{}) ([]byte, error) {
+	return c.CallContext(ctx, "POST", "/rest/api/3/group", groupData, nil)
 }
 
-func DeleteGroup(groupname, groupId, swapGroup, swapGroupId string) ([]byte, error) {
+func (c *Client) DeleteGroup(groupname, groupId, swapGroup, swapGroupId string) ([]byte, error) {
+	return c.DeleteGroupContext(context.Background(), groupname, groupId, swapGroup, swapGroupId)
+}
+
+func (c *Client) DeleteGroupContext(ctx context.Context, groupname, groupId, swapGroup, swapGroupId string) ([]byte, error) {
 	params := map[string]string{
 		"groupname":   groupname,
 		"groupId":     groupId,
 		"swapGroup":   swapGroup,
 		"swapGroupId": swapGroupId,
 	}
-	return MakeJiraAPICall("DELETE", "/rest/api/3/group", nil, params)
+	return c.CallContext(ctx, "DELETE", "/rest/api/3/group", nil, params)
+}
+
+func (c *Client) FindGroups(query string, exclude []string, maxResults int, userName string) ([]byte, error) {
+	return c.FindGroupsContext(context.Background(), query, exclude, maxResults, userName)
 }
 
-func FindGroups(query string, exclude []string, maxResults int, userName string) ([]byte, error) {
+func (c *Client) FindGroupsContext(ctx context.Context, query string, exclude []string, maxResults int, userName string) ([]byte, error) {
 	params := map[string]string{
 		"query":    query,
 		"userName": userName,
@@ -40,5 +57,5 @@ func FindGroups(query string, exclude []string, maxResults int, userName string)
 	if maxResults > 0 {
 		params["maxResults"] = fmt.Sprintf("%d", maxResults)
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/groups/picker", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/groups/picker", nil, params)
 }
\ No newline at end of file
@@ -1,50 +1,79 @@
 package jiraApiFunctions
 
 import (
+	"context"
 	"fmt"
 )
 
 // Issue APIs
-func GetIssue(issueIdOrKey string, fields, expand string) ([]byte, error) {
+func (c *Client) GetIssue(issueIdOrKey string, fields, expand string) ([]byte, error) {
+	return c.GetIssueContext(context.Background(), issueIdOrKey, fields, expand)
+}
+
+func (c *Client) GetIssueContext(ctx context.Context, issueIdOrKey string, fields, expand string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s", issueIdOrKey)
 	params := map[string]string{
 		"fields": fields,
 		"expand": expand,
 	}
-	return MakeJiraAPICall("GET", endpoint, nil, params)
+	return c.CallContext(ctx, "GET", endpoint, nil, params)
 }
 
-func CreateIssue(issueData interface{}) ([]byte, error) {
-	return MakeJiraAPICall("POST", "/rest/api/3/issue", issueData, nil)
+func (c *Client) CreateIssue(issueData interface{}) ([]byte, error) {
+	return c.CreateIssueContext(context.Background(), issueData)
 }
 
-func UpdateIssue(issueIdOrKey string, issueData interface{}) ([]byte, error) {
+func (c *Client) CreateIssueContext(ctx context.Context, issueData interface{}) ([]byte, error) {
+	return c.CallContext(ctx, "POST", "/rest/api/3/issue", issueData, nil)
+}
+
+func (c *Client) UpdateIssue(issueIdOrKey string, issueData interface{}) ([]byte, error) {
+	return c.UpdateIssueContext(context.Background(), issueIdOrKey, issueData)
+}
+
+func (c *Client) UpdateIssueContext(ctx context.Context, issueIdOrKey string, issueData interface{}) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s", issueIdOrKey)
-	return MakeJiraAPICall("PUT", endpoint, issueData, nil)
+	return c.CallContext(ctx, "PUT", endpoint, issueData, nil)
+}
+
+func (c *Client) DeleteIssue(issueIdOrKey, deleteSubtasks string) ([]byte, error) {
+	return c.DeleteIssueContext(context.Background(), issueIdOrKey, deleteSubtasks)
 }
 
-func DeleteIssue(issueIdOrKey, deleteSubtasks string) ([]byte, error) {
+func (c *Client) DeleteIssueContext(ctx context.Context, issueIdOrKey, deleteSubtasks string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s", issueIdOrKey)
 	params := map[string]string{
 		"deleteSubtasks": deleteSubtasks,
 	}
-	return MakeJiraAPICall("DELETE", endpoint, nil, params)
+	return c.CallContext(ctx, "DELETE", endpoint, nil, params)
 }
 
-func GetIssueTransitions(issueIdOrKey, expand string) ([]byte, error) {
+func (c *Client) GetIssueTransitions(issueIdOrKey, expand string) ([]byte, error) {
+	return c.GetIssueTransitionsContext(context.Background(), issueIdOrKey, expand)
+}
+
+func (c *Client) GetIssueTransitionsContext(ctx context.Context, issueIdOrKey, expand string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s/transitions", issueIdOrKey)
 	params := map[string]string{
 		"expand": expand,
 	}
-	return MakeJiraAPICall("GET", endpoint, nil, params)
+	return c.CallContext(ctx, "GET", endpoint, nil, params)
+}
+
+func (c *Client) TransitionIssue(issueIdOrKey string, transitionData interface{}) ([]byte, error) {
+	return c.TransitionIssueContext(context.Background(), issueIdOrKey, transitionData)
 }
 
-func TransitionIssue(issueIdOrKey string, transitionData interface{}) ([]byte, error) {
+func (c *Client) TransitionIssueContext(ctx context.Context, issueIdOrKey string, transitionData interface{}) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s/transitions", issueIdOrKey)
-	return MakeJiraAPICall("POST", endpoint, transitionData, nil)
+	return c.CallContext(ctx, "POST", endpoint, transitionData, nil)
+}
+
+func (c *Client) GetIssueComments(issueIdOrKey string, startAt, maxResults int, orderBy, expand string) ([]byte, error) {
+	return c.GetIssueCommentsContext(context.Background(), issueIdOrKey, startAt, maxResults, orderBy, expand)
 }
 
-func GetIssueComments(issueIdOrKey string, startAt, maxResults int, orderBy, expand string) ([]byte, error) {
+func (c *Client) GetIssueCommentsContext(ctx context.Context, issueIdOrKey string, startAt, maxResults int, orderBy, expand string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s/comment", issueIdOrKey)
 	params := map[string]string{
 		"orderBy": orderBy,
@@ -56,43 +85,71 @@ func GetIssueComments(issueIdOrKey string, startAt, maxResults int, orderBy, exp
 	if maxResults > 0 {
 		params["maxResults"] = fmt.Sprintf("%d", maxResults)
 	}
-	return MakeJiraAPICall("GET", endpoint, nil, params)
+	return c.CallContext(ctx, "GET", endpoint, nil, params)
+}
+
+func (c *Client) AddComment(issueIdOrKey string, commentData interface{}) ([]byte, error) {
+	return c.AddCommentContext(context.Background(), issueIdOrKey, commentData)
 }
 
-func AddComment(issueIdOrKey string, commentData interface{}) ([]byte, error) {
+func (c *Client) AddCommentContext(ctx context.Context, issueIdOrKey string, commentData interface{}) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s/comment", issueIdOrKey)
-	return MakeJiraAPICall("POST", endpoint, commentData, nil)
+	return c.CallContext(ctx, "POST", endpoint, commentData, nil)
+}
+
+func (c *Client) UpdateComment(issueIdOrKey, commentId string, commentData interface{}) ([]byte, error) {
+	return c.UpdateCommentContext(context.Background(), issueIdOrKey, commentId, commentData)
 }
 
-func UpdateComment(issueIdOrKey, commentId string, commentData interface{}) ([]byte, error) {
+func (c *Client) UpdateCommentContext(ctx context.Context, issueIdOrKey, commentId string, commentData interface{}) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s/comment/%s", issueIdOrKey, commentId)
-	return MakeJiraAPICall("PUT", endpoint, commentData, nil)
+	return c.CallContext(ctx, "PUT", endpoint, commentData, nil)
+}
+
+func (c *Client) DeleteComment(issueIdOrKey, commentId string) ([]byte, error) {
+	return c.DeleteCommentContext(context.Background(), issueIdOrKey, commentId)
 }
 
-func DeleteComment(issueIdOrKey, commentId string) ([]byte, error) {
+func (c *Client) DeleteCommentContext(ctx context.Context, issueIdOrKey, commentId string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s/comment/%s", issueIdOrKey, commentId)
-	return MakeJiraAPICall("DELETE", endpoint, nil, nil)
+	return c.CallContext(ctx, "DELETE", endpoint, nil, nil)
+}
+
+func (c *Client) GetIssueWatchers(issueIdOrKey string) ([]byte, error) {
+	return c.GetIssueWatchersContext(context.Background(), issueIdOrKey)
 }
 
-func GetIssueWatchers(issueIdOrKey string) ([]byte, error) {
+func (c *Client) GetIssueWatchersContext(ctx context.Context, issueIdOrKey string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s/watchers", issueIdOrKey)
-	return MakeJiraAPICall("GET", endpoint, nil, nil)
+	return c.CallContext(ctx, "GET", endpoint, nil, nil)
 }
 
-func AddWatcher(issueIdOrKey, accountId string) ([]byte, error) {
+func (c *Client) AddWatcher(issueIdOrKey, accountId string) ([]byte, error) {
+	return c.AddWatcherContext(context.Background(), issueIdOrKey, accountId)
+}
+
+func (c *Client) AddWatcherContext(ctx context.Context, issueIdOrKey, accountId string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s/watchers", issueIdOrKey)
-	return MakeJiraAPICall("POST", endpoint, accountId, nil)
+	return c.CallContext(ctx, "POST", endpoint, accountId, nil)
+}
+
+func (c *Client) RemoveWatcher(issueIdOrKey, accountId string) ([]byte, error) {
+	return c.RemoveWatcherContext(context.Background(), issueIdOrKey, accountId)
 }
 
-func RemoveWatcher(issueIdOrKey, accountId string) ([]byte, error) {
+func (c *Client) RemoveWatcherContext(ctx context.Context, issueIdOrKey, accountId string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s/watchers", issueIdOrKey)
 	params := map[string]string{
 		"accountId": accountId,
 	}
-	return MakeJiraAPICall("DELETE", endpoint, nil, params)
+	return c.CallContext(ctx, "DELETE", endpoint, nil, params)
 }
 
-func GetIssueWorklog(issueIdOrKey string, startAt, maxResults int, expand string) ([]byte, error) {
+func (c *Client) GetIssueWorklog(issueIdOrKey string, startAt, maxResults int, expand string) ([]byte, error) {
+	return c.GetIssueWorklogContext(context.Background(), issueIdOrKey, startAt, maxResults, expand)
+}
+
+func (c *Client) GetIssueWorklogContext(ctx context.Context, issueIdOrKey string, startAt, maxResults int, expand string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s/worklog", issueIdOrKey)
 	params := map[string]string{
 		"expand": expand,
@@ -103,20 +160,32 @@ func GetIssueWorklog(issueIdOrKey string, startAt, maxResults int, expand string
 	if maxResults > 0 {
 		params["maxResults"] = fmt.Sprintf("%d", maxResults)
 	}
-	return MakeJiraAPICall("GET", endpoint, nil, params)
+	return c.CallContext(ctx, "GET", endpoint, nil, params)
+}
+
+func (c *Client) AddWorklog(issueIdOrKey string, worklogData interface{}) ([]byte, error) {
+	return c.AddWorklogContext(context.Background(), issueIdOrKey, worklogData)
 }
 
-func AddWorklog(issueIdOrKey string, worklogData interface{}) ([]byte, error) {
+func (c *Client) AddWorklogContext(ctx context.Context, issueIdOrKey string, worklogData interface{}) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s/worklog", issueIdOrKey)
-	return MakeJiraAPICall("POST", endpoint, worklogData, nil)
+	return c.CallContext(ctx, "POST", endpoint, worklogData, nil)
 }
 
-func UpdateWorklog(issueIdOrKey, worklogId string, worklogData interface{}) ([]byte, error) {
+func (c *Client) UpdateWorklog(issueIdOrKey, worklogId string, worklogData interface{}) ([]byte, error) {
+	return c.UpdateWorklogContext(context.Background(), issueIdOrKey, worklogId, worklogData)
+}
+
+func (c *Client) UpdateWorklogContext(ctx context.Context, issueIdOrKey, worklogId string, worklogData interface{}) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s/worklog/%s", issueIdOrKey, worklogId)
-	return MakeJiraAPICall("PUT", endpoint, worklogData, nil)
+	return c.CallContext(ctx, "PUT", endpoint, worklogData, nil)
+}
+
+func (c *Client) DeleteWorklog(issueIdOrKey, worklogId string) ([]byte, error) {
+	return c.DeleteWorklogContext(context.Background(), issueIdOrKey, worklogId)
 }
 
-func DeleteWorklog(issueIdOrKey, worklogId string) ([]byte, error) {
+func (c *Client) DeleteWorklogContext(ctx context.Context, issueIdOrKey, worklogId string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/issue/%s/worklog/%s", issueIdOrKey, worklogId)
-	return MakeJiraAPICall("DELETE", endpoint, nil, nil)
+	return c.CallContext(ctx, "DELETE", endpoint, nil, nil)
 }
\ No newline at end of file
@@ -1,12 +1,17 @@
 package jiraApiFunctions
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
 // Auditing APIs
-func GetAuditRecords(offset, limit int, filter, from, to string) ([]byte, error) {
+func (c *Client) GetAuditRecords(offset, limit int, filter, from, to string) ([]byte, error) {
+	return c.GetAuditRecordsContext(context.Background(), offset, limit, filter, from, to)
+}
+
+func (c *Client) GetAuditRecordsContext(ctx context.Context, offset, limit int, filter, from, to string) ([]byte, error) {
 	params := map[string]string{
 		"filter": filter,
 		"from":   from,
@@ -18,97 +23,157 @@ func GetAuditRecords(offset, limit int, filter, from, to string) ([]byte, error)
 	if limit > 0 {
 		params["limit"] = fmt.Sprintf("%d", limit)
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/auditing/record", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/auditing/record", nil, params)
 }
 
 // Avatar APIs
-func GetSystemAvatars(avatarType string) ([]byte, error) {
+func (c *Client) GetSystemAvatars(avatarType string) ([]byte, error) {
+	return c.GetSystemAvatarsContext(context.Background(), avatarType)
+}
+
+func (c *Client) GetSystemAvatarsContext(ctx context.Context, avatarType string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/avatar/%s/system", avatarType)
-	return MakeJiraAPICall("GET", endpoint, nil, nil)
+	return c.CallContext(ctx, "GET", endpoint, nil, nil)
 }
 
 // Changelog APIs
-func GetChangelogsBulk(changelogIds interface{}) ([]byte, error) {
-	return MakeJiraAPICall("POST", "/rest/api/3/changelog/bulkfetch", changelogIds, nil)
+func (c *Client) GetChangelogsBulk(changelogIds interface{}) ([]byte, error) {
+	return c.GetChangelogsBulkContext(context.Background(), changelogIds)
+}
+
+func (c *Client) GetChangelogsBulkContext(ctx context.Context, changelogIds interface{}) ([]byte, error) {
+	return c.CallRetryableContext(ctx, "POST", "/rest/api/3/changelog/bulkfetch", changelogIds, nil)
 }
 
 // Classification APIs
-func GetClassificationLevels(status []string, orderBy string) ([]byte, error) {
+func (c *Client) GetClassificationLevels(status []string, orderBy string) ([]byte, error) {
+	return c.GetClassificationLevelsContext(context.Background(), status, orderBy)
+}
+
+func (c *Client) GetClassificationLevelsContext(ctx context.Context, status []string, orderBy string) ([]byte, error) {
 	params := map[string]string{
 		"orderBy": orderBy,
 	}
 	if len(status) > 0 {
 		params["status"] = strings.Join(status, ",")
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/classification-levels", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/classification-levels", nil, params)
 }
 
 // Comment APIs
-func GetCommentsList(commentRequest interface{}) ([]byte, error) {
-	return MakeJiraAPICall("POST", "/rest/api/3/comment/list", commentRequest, nil)
+func (c *Client) GetCommentsList(commentRequest interface{}) ([]byte, error) {
+	return c.GetCommentsListContext(context.Background(), commentRequest)
+}
+
+func (c *Client) GetCommentsListContext(ctx context.Context, commentRequest interface{}) ([]byte, error) {
+	return c.CallRetryableContext(ctx, "POST", "/rest/api/3/comment/list", commentRequest, nil)
 }
 
-func GetCommentProperties(commentId string) ([]byte, error) {
+func (c *Client) GetCommentProperties(commentId string) ([]byte, error) {
+	return c.GetCommentPropertiesContext(context.Background(), commentId)
+}
+
+func (c *Client) GetCommentPropertiesContext(ctx context.Context, commentId string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/comment/%s/properties", commentId)
-	return MakeJiraAPICall("GET", endpoint, nil, nil)
+	return c.CallContext(ctx, "GET", endpoint, nil, nil)
 }
 
-func DeleteCommentProperty(commentId, propertyKey string) ([]byte, error) {
+func (c *Client) DeleteCommentProperty(commentId, propertyKey string) ([]byte, error) {
+	return c.DeleteCommentPropertyContext(context.Background(), commentId, propertyKey)
+}
+
+func (c *Client) DeleteCommentPropertyContext(ctx context.Context, commentId, propertyKey string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/comment/%s/properties/%s", commentId, propertyKey)
-	return MakeJiraAPICall("DELETE", endpoint, nil, nil)
+	return c.CallContext(ctx, "DELETE", endpoint, nil, nil)
 }
 
 // Component APIs
-func GetComponents(query, projectIdOrKey, orderBy string, maxResults int) ([]byte, error) {
+func (c *Client) GetComponents(query, projectIdsOrKeys, orderBy string, maxResults int) ([]byte, error) {
+	return c.GetComponentsContext(context.Background(), query, projectIdsOrKeys, orderBy, maxResults)
+}
+
+func (c *Client) GetComponentsContext(ctx context.Context, query, projectIdsOrKeys, orderBy string, maxResults int) ([]byte, error) {
 	params := map[string]string{
-		"query":          query,
-		"projectIdOrKey": projectIdOrKey,
-		"orderBy":        orderBy,
+		"query":            query,
+		"projectIdsOrKeys": projectIdsOrKeys,
+		"orderBy":          orderBy,
 	}
 	if maxResults > 0 {
 		params["maxResults"] = fmt.Sprintf("%d", maxResults)
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/component", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/component", nil, params)
 }
 
-func DeleteComponent(id, moveIssuesTo string) ([]byte, error) {
+func (c *Client) DeleteComponent(id, moveIssuesTo string) ([]byte, error) {
+	return c.DeleteComponentContext(context.Background(), id, moveIssuesTo)
+}
+
+func (c *Client) DeleteComponentContext(ctx context.Context, id, moveIssuesTo string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/component/%s", id)
 	params := map[string]string{
 		"moveIssuesTo": moveIssuesTo,
 	}
-	return MakeJiraAPICall("DELETE", endpoint, nil, params)
+	return c.CallContext(ctx, "DELETE", endpoint, nil, params)
+}
+
+func (c *Client) GetComponentRelatedIssueCounts(id string) ([]byte, error) {
+	return c.GetComponentRelatedIssueCountsContext(context.Background(), id)
 }
 
-func GetComponentRelatedIssueCounts(id string) ([]byte, error) {
+func (c *Client) GetComponentRelatedIssueCountsContext(ctx context.Context, id string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/component/%s/relatedIssueCounts", id)
-	return MakeJiraAPICall("GET", endpoint, nil, nil)
+	return c.CallContext(ctx, "GET", endpoint, nil, nil)
 }
 
 // Configuration APIs
-func GetConfiguration() ([]byte, error) {
-	return MakeJiraAPICall("GET", "/rest/api/3/configuration", nil, nil)
+func (c *Client) GetConfiguration() ([]byte, error) {
+	return c.GetConfigurationContext(context.Background())
+}
+
+func (c *Client) GetConfigurationContext(ctx context.Context) ([]byte, error) {
+	return c.CallContext(ctx, "GET", "/rest/api/3/configuration", nil, nil)
+}
+
+func (c *Client) GetTimeTrackingConfiguration() ([]byte, error) {
+	return c.GetTimeTrackingConfigurationContext(context.Background())
+}
+
+func (c *Client) GetTimeTrackingConfigurationContext(ctx context.Context) ([]byte, error) {
+	return c.CallContext(ctx, "GET", "/rest/api/3/configuration/timetracking", nil, nil)
+}
+
+func (c *Client) GetTimeTrackingProviders() ([]byte, error) {
+	return c.GetTimeTrackingProvidersContext(context.Background())
 }
 
-func GetTimeTrackingConfiguration() ([]byte, error) {
-	return MakeJiraAPICall("GET", "/rest/api/3/configuration/timetracking", nil, nil)
+func (c *Client) GetTimeTrackingProvidersContext(ctx context.Context) ([]byte, error) {
+	return c.CallContext(ctx, "GET", "/rest/api/3/configuration/timetracking/list", nil, nil)
 }
 
-func GetTimeTrackingProviders() ([]byte, error) {
-	return MakeJiraAPICall("GET", "/rest/api/3/configuration/timetracking/list", nil, nil)
+func (c *Client) GetTimeTrackingOptions() ([]byte, error) {
+	return c.GetTimeTrackingOptionsContext(context.Background())
 }
 
-func GetTimeTrackingOptions() ([]byte, error) {
-	return MakeJiraAPICall("GET", "/rest/api/3/configuration/timetracking/options", nil, nil)
+func (c *Client) GetTimeTrackingOptionsContext(ctx context.Context) ([]byte, error) {
+	return c.CallContext(ctx, "GET", "/rest/api/3/configuration/timetracking/options", nil, nil)
 }
 
 // Custom Field APIs
-func GetCustomFieldOption(id string) ([]byte, error) {
+func (c *Client) GetCustomFieldOption(id string) ([]byte, error) {
+	return c.GetCustomFieldOptionContext(context.Background(), id)
+}
+
+func (c *Client) GetCustomFieldOptionContext(ctx context.Context, id string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/customFieldOption/%s", id)
-	return MakeJiraAPICall("GET", endpoint, nil, nil)
+	return c.CallContext(ctx, "GET", endpoint, nil, nil)
 }
 
 // Dashboard APIs
-func GetDashboards(filter string, startAt, maxResults int) ([]byte, error) {
+func (c *Client) GetDashboards(filter string, startAt, maxResults int) ([]byte, error) {
+	return c.GetDashboardsContext(context.Background(), filter, startAt, maxResults)
+}
+
+func (c *Client) GetDashboardsContext(ctx context.Context, filter string, startAt, maxResults int) ([]byte, error) {
 	params := map[string]string{
 		"filter": filter,
 	}
@@ -118,14 +183,31 @@ func GetDashboards(filter string, startAt, maxResults int) ([]byte, error) {
 	if maxResults > 0 {
 		params["maxResults"] = fmt.Sprintf("%d", maxResults)
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/dashboard", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/dashboard", nil, params)
+}
+
+func (c *Client) BulkEditDashboards(editRequest interface{}) ([]byte, error) {
+	return c.BulkEditDashboardsContext(context.Background(), editRequest)
+}
+
+func (c *Client) BulkEditDashboardsContext(ctx context.Context, editRequest interface{}) ([]byte, error) {
+	return c.CallContext(ctx, "PUT", "/rest/api/3/dashboard/bulk/edit", editRequest, nil)
+}
+
+func (c *Client) GetAvailableGadgets() ([]byte, error) {
+	return c.GetAvailableGadgetsContext(context.Background())
 }
 
-func BulkEditDashboards(editRequest interface{}) ([]byte, error) {
-	return MakeJiraAPICall("PUT", "/rest/api/3/dashboard/bulk/edit", editRequest, nil)
+func (c *Client) GetAvailableGadgetsContext(ctx context.Context) ([]byte, error) {
+	return c.CallContext(ctx, "GET", "/rest/api/3/dashboard/gadgets", nil, nil)
 }
 
-func GetAvailableGadgets(moduleKey, uri []string, gadgetId []int) ([]byte, error) {
+func (c *Client) GetDashboardGadgets(dashboardId string, moduleKey, uri []string, gadgetId []int) ([]byte, error) {
+	return c.GetDashboardGadgetsContext(context.Background(), dashboardId, moduleKey, uri, gadgetId)
+}
+
+func (c *Client) GetDashboardGadgetsContext(ctx context.Context, dashboardId string, moduleKey, uri []string, gadgetId []int) ([]byte, error) {
+	endpoint := fmt.Sprintf("/rest/api/3/dashboard/%s/gadget", dashboardId)
 	params := map[string]string{}
 	if len(moduleKey) > 0 {
 		params["moduleKey"] = strings.Join(moduleKey, ",")
@@ -140,10 +222,14 @@ func GetAvailableGadgets(moduleKey, uri []string, gadgetId []int) ([]byte, error
 		}
 		params["gadgetId"] = strings.Join(gadgetIds, ",")
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/dashboard/gadgets", nil, params)
+	return c.CallContext(ctx, "GET", endpoint, nil, params)
+}
+
+func (c *Client) SearchDashboards(dashboardName, accountId, owner, groupname, groupId string, projectId int, orderBy, status, expand string, startAt, maxResults int) ([]byte, error) {
+	return c.SearchDashboardsContext(context.Background(), dashboardName, accountId, owner, groupname, groupId, projectId, orderBy, status, expand, startAt, maxResults)
 }
 
-func SearchDashboards(dashboardName, accountId, owner, groupname, groupId string, projectId int, orderBy, status, expand string, startAt, maxResults int) ([]byte, error) {
+func (c *Client) SearchDashboardsContext(ctx context.Context, dashboardName, accountId, owner, groupname, groupId string, projectId int, orderBy, status, expand string, startAt, maxResults int) ([]byte, error) {
 	params := map[string]string{
 		"dashboardName": dashboardName,
 		"accountId":     accountId,
@@ -163,17 +249,34 @@ func SearchDashboards(dashboardName, accountId, owner, groupname, groupId string
 	if maxResults > 0 {
 		params["maxResults"] = fmt.Sprintf("%d", maxResults)
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/dashboard/search", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/dashboard/search", nil, params)
 }
 
 // Data Policy APIs
-func GetDataPolicy() ([]byte, error) {
-	return MakeJiraAPICall("GET", "/rest/api/3/data-policy", nil, nil)
+func (c *Client) GetDataPolicy() ([]byte, error) {
+	return c.GetDataPolicyContext(context.Background())
+}
+
+func (c *Client) GetDataPolicyContext(ctx context.Context) ([]byte, error) {
+	return c.CallContext(ctx, "GET", "/rest/api/3/data-policy", nil, nil)
 }
 
-func GetProjectDataPolicy(ids string) ([]byte, error) {
+func (c *Client) GetProjectDataPolicy(ids string) ([]byte, error) {
+	return c.GetProjectDataPolicyContext(context.Background(), ids)
+}
+
+func (c *Client) GetProjectDataPolicyContext(ctx context.Context, ids string) ([]byte, error) {
 	params := map[string]string{
 		"ids": ids,
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/data-policy/project", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/data-policy/project", nil, params)
+}
+
+// Tenant Info APIs
+func (c *Client) GetTenantInfo() ([]byte, error) {
+	return c.GetTenantInfoContext(context.Background())
+}
+
+func (c *Client) GetTenantInfoContext(ctx context.Context) ([]byte, error) {
+	return c.CallContext(ctx, "GET", "/_edge/tenant_info", nil, nil)
 }
\ No newline at end of file
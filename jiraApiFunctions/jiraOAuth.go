@@ -0,0 +1,375 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Atlassian's OAuth 2.0 (3LO) endpoints. Apps authorised this way call Jira
+// through the API gateway rather than the site itself.
+const (
+	AtlassianAuthURL  = "https://auth.atlassian.com/authorize"
+	AtlassianTokenURL = "https://auth.atlassian.com/oauth/token"
+	AtlassianAPIURL   = "https://api.atlassian.com"
+	AtlassianAudience = "api.atlassian.com"
+)
+
+// tokenExpiryDelta refreshes access tokens a little before they expire, so
+// a token doesn't run out while a request is in flight.
+const tokenExpiryDelta = time.Minute
+
+// TokenSource supplies the Bearer token for each request. When a client
+// has one, it is used instead of Email and APIKey.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// OAuthConfig describes an OAuth 2.0 app using the authorization code flow
+// with PKCE.
+type OAuthConfig struct {
+	ClientID string
+	// ClientSecret is optional for public clients that rely on PKCE alone.
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	RedirectURL  string
+	Scopes       []string
+	// Audience is sent with the authorization request; Atlassian needs
+	// AtlassianAudience.
+	Audience string
+	// HTTPClient defaults to http.DefaultClient when nil.
+	HTTPClient *http.Client
+}
+
+// OAuthToken is an access token and the refresh token that renews it.
+type OAuthToken struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken,omitempty"`
+	TokenType    string    `json:"tokenType,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether the access token can still be used at now.
+func (t *OAuthToken) Valid(now time.Time) bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || now.Add(tokenExpiryDelta).Before(t.Expiry)
+}
+
+// OAuthError is an error response from the token endpoint, as described in
+// RFC 6749 section 5.2.
+type OAuthError struct {
+	StatusCode  int
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	msg := fmt.Sprintf("OAuth token request failed: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Code != "" {
+		msg += ": " + e.Code
+	}
+	if e.Description != "" {
+		msg += " (" + e.Description + ")"
+	}
+	return msg
+}
+
+// IsInvalidGrant reports whether the authorization server rejected a code
+// or refresh token, meaning the user has to log in again.
+func IsInvalidGrant(err error) bool {
+	var oauthErr *OAuthError
+	return errors.As(err, &oauthErr) && oauthErr.Code == "invalid_grant"
+}
+
+// NewPKCE returns a random code verifier and its S256 code challenge.
+func NewPKCE() (verifier, challenge string, err error) {
+	verifier, err = randomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// NewOAuthState returns a random value for the state parameter.
+func NewOAuthState() (string, error) {
+	return randomString(16)
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// AuthCodeURL returns the URL the user opens to authorise the app.
+func (c *OAuthConfig) AuthCodeURL(state, challenge string) string {
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.ClientID},
+		"redirect_uri":          {c.RedirectURL},
+		"state":                 {state},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+		"prompt":                {"consent"},
+	}
+	if len(c.Scopes) > 0 {
+		params.Set("scope", strings.Join(c.Scopes, " "))
+	}
+	if c.Audience != "" {
+		params.Set("audience", c.Audience)
+	}
+
+	sep := "?"
+	if strings.Contains(c.AuthURL, "?") {
+		sep = "&"
+	}
+	return c.AuthURL + sep + params.Encode()
+}
+
+// Exchange trades the code from the redirect for a token.
+func (c *OAuthConfig) Exchange(ctx context.Context, code, verifier string) (*OAuthToken, error) {
+	return c.tokenRequest(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.RedirectURL},
+		"code_verifier": {verifier},
+	})
+}
+
+// Refresh gets a new access token. Servers that rotate refresh tokens
+// return a new one, and the old one stops working.
+func (c *OAuthConfig) Refresh(ctx context.Context, refreshToken string) (*OAuthToken, error) {
+	if refreshToken == "" {
+		return nil, &OAuthError{StatusCode: http.StatusBadRequest, Code: "invalid_grant", Description: "no refresh token"}
+	}
+	token, err := c.tokenRequest(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+func (c *OAuthConfig) tokenRequest(ctx context.Context, form url.Values) (*OAuthToken, error) {
+	form.Set("client_id", c.ClientID)
+	if c.ClientSecret != "" {
+		form.Set("client_secret", c.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokenResponse struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		TokenType        string `json:"token_type"`
+		Scope            string `json:"scope"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	jsonErr := json.Unmarshal(body, &tokenResponse)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 || tokenResponse.Error != "" {
+		return nil, &OAuthError{StatusCode: resp.StatusCode, Code: tokenResponse.Error, Description: tokenResponse.ErrorDescription}
+	}
+	if jsonErr != nil {
+		return nil, fmt.Errorf("unexpected token response: %w", jsonErr)
+	}
+	if tokenResponse.AccessToken == "" {
+		return nil, errors.New("token response has no access token")
+	}
+
+	token := &OAuthToken{
+		AccessToken:  tokenResponse.AccessToken,
+		RefreshToken: tokenResponse.RefreshToken,
+		TokenType:    tokenResponse.TokenType,
+		Scope:        tokenResponse.Scope,
+	}
+	if tokenResponse.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// OAuthTokenStore keeps the token where every process using the login
+// finds it. Servers that rotate refresh tokens invalidate the old one on
+// each refresh, so a process still holding the token it started with has
+// to read the stored one before refreshing.
+type OAuthTokenStore interface {
+	// Lock serialises refreshes across processes and returns the function
+	// that releases it.
+	Lock() (func(), error)
+	Load() (*OAuthToken, error)
+	Save(token *OAuthToken) error
+}
+
+// OAuthTokenSource hands out an access token, refreshing it when it is
+// about to expire. It is safe for concurrent use.
+type OAuthTokenSource struct {
+	config *OAuthConfig
+	// store, when set, is reloaded before each refresh and saved to after
+	// it, so a rotated refresh token isn't lost.
+	store OAuthTokenStore
+
+	mu    sync.Mutex
+	token *OAuthToken
+}
+
+// NewOAuthTokenSource returns a source starting from token. store may be
+// nil.
+func NewOAuthTokenSource(config *OAuthConfig, token *OAuthToken, store OAuthTokenStore) *OAuthTokenSource {
+	return &OAuthTokenSource{config: config, token: token, store: store}
+}
+
+// Token returns a valid access token, refreshing it first if needed.
+// Refreshes hold the store's lock and start from the stored token, which
+// another process may have refreshed already.
+func (s *OAuthTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid(time.Now()) {
+		return s.token.AccessToken, nil
+	}
+	if s.store != nil {
+		unlock, err := s.store.Lock()
+		if err != nil {
+			return "", err
+		}
+		defer unlock()
+		if s.reload() {
+			return s.token.AccessToken, nil
+		}
+	}
+
+	token, err := s.config.Refresh(ctx, s.refreshToken())
+	if IsInvalidGrant(err) && s.store != nil {
+		// Another process may have rotated the refresh token without
+		// taking the lock; try once more with the one it stored
+		used := s.refreshToken()
+		if s.reload() {
+			return s.token.AccessToken, nil
+		}
+		if refreshToken := s.refreshToken(); refreshToken != used {
+			token, err = s.config.Refresh(ctx, refreshToken)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	if s.store != nil {
+		if err := s.store.Save(token); err != nil {
+			log.Printf("Warning: failed to save the refreshed OAuth token: %v", err)
+		}
+	}
+	return token.AccessToken, nil
+}
+
+// reload replaces the token with the stored one, reporting whether that
+// one is still valid. A store that can't be read leaves the token as it
+// is. The caller must hold mu.
+func (s *OAuthTokenSource) reload() bool {
+	stored, err := s.store.Load()
+	if err != nil {
+		log.Printf("Warning: failed to reload the OAuth token: %v", err)
+		return false
+	}
+	if stored != nil {
+		s.token = stored
+	}
+	return s.token.Valid(time.Now())
+}
+
+// refreshToken returns the refresh token held. The caller must hold mu.
+func (s *OAuthTokenSource) refreshToken() string {
+	if s.token == nil {
+		return ""
+	}
+	return s.token.RefreshToken
+}
+
+// Current returns a copy of the token as last issued.
+func (s *OAuthTokenSource) Current() OAuthToken {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == nil {
+		return OAuthToken{}
+	}
+	return *s.token
+}
+
+// NewOAuthClient returns a client that reaches the Jira site with cloudID
+// through the API gateway at apiURL, authorised by tokens.
+func NewOAuthClient(apiURL, cloudID string, tokens TokenSource) *Client {
+	apiURL = strings.TrimSuffix(apiURL, "/")
+	return &Client{
+		BaseURL:         apiURL + "/ex/jira/" + cloudID,
+		GraphQLEndpoint: apiURL + "/graphql",
+		Tokens:          tokens,
+		UserAgent:       defaultUserAgent,
+	}
+}
+
+// AccessibleResources lists the sites an OAuth token was granted for.
+func AccessibleResources(ctx context.Context, apiURL string, tokens TokenSource) ([]AccessibleResource, error) {
+	client := &Client{BaseURL: strings.TrimSuffix(apiURL, "/"), Tokens: tokens, UserAgent: defaultUserAgent}
+	response, err := client.CallContext(ctx, "GET", "/oauth/token/accessible-resources", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resources []AccessibleResource
+	if err := json.Unmarshal(response, &resources); err != nil {
+		return nil, fmt.Errorf("unexpected accessible resources response: %w", err)
+	}
+	return resources, nil
+}
+
+// AccessibleResource is a site an OAuth app may access.
+type AccessibleResource struct {
+	ID     string   `json:"id"`
+	URL    string   `json:"url"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
@@ -0,0 +1,268 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAuthServer is a minimal OAuth 2.0 authorization server that checks
+// PKCE and rotates refresh tokens.
+type fakeAuthServer struct {
+	*httptest.Server
+	t *testing.T
+
+	mu         sync.Mutex
+	challenges map[string]string // code -> code challenge
+	refresh    map[string]bool   // refresh tokens still valid
+	issued     int
+	expiresIn  int
+}
+
+func newFakeAuthServer(t *testing.T) *fakeAuthServer {
+	s := &fakeAuthServer{t: t, challenges: map[string]string{}, refresh: map[string]bool{}, expiresIn: 3600}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeAuthServer) config() *OAuthConfig {
+	return &OAuthConfig{
+		ClientID:    "client-1",
+		AuthURL:     s.URL + "/authorize",
+		TokenURL:    s.URL + "/oauth/token",
+		RedirectURL: "http://127.0.0.1:1/callback",
+		Scopes:      []string{"read:jira-work", "offline_access"},
+	}
+}
+
+func (s *fakeAuthServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/authorize":
+		q := r.URL.Query()
+		if q.Get("code_challenge_method") != "S256" {
+			s.t.Errorf("Expected an S256 challenge, got %q", q.Get("code_challenge_method"))
+		}
+		code := fmt.Sprintf("code-%d", len(s.challenges)+1)
+		s.challenges[code] = q.Get("code_challenge")
+		http.Redirect(w, r, q.Get("redirect_uri")+"?code="+code+"&state="+url.QueryEscape(q.Get("state")), http.StatusFound)
+	case "/oauth/token":
+		r.ParseForm()
+		if r.Form.Get("client_id") != "client-1" {
+			oauthFail(w, "invalid_client")
+			return
+		}
+		switch r.Form.Get("grant_type") {
+		case "authorization_code":
+			challenge, ok := s.challenges[r.Form.Get("code")]
+			sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+			if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != challenge {
+				oauthFail(w, "invalid_grant")
+				return
+			}
+			delete(s.challenges, r.Form.Get("code"))
+		case "refresh_token":
+			if !s.refresh[r.Form.Get("refresh_token")] {
+				oauthFail(w, "invalid_grant")
+				return
+			}
+			delete(s.refresh, r.Form.Get("refresh_token"))
+		default:
+			oauthFail(w, "unsupported_grant_type")
+			return
+		}
+		s.issued++
+		refreshToken := fmt.Sprintf("refresh-%d", s.issued)
+		s.refresh[refreshToken] = true
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  fmt.Sprintf("access-%d", s.issued),
+			"refresh_token": refreshToken,
+			"token_type":    "Bearer",
+			"expires_in":    s.expiresIn,
+		})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func oauthFail(w http.ResponseWriter, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	fmt.Fprintf(w, `{"error":%q,"error_description":"rejected by the fake server"}`, code)
+}
+
+// authorize follows the authorization URL and returns the code and state
+// the server redirected with.
+func authorize(t *testing.T, authURL string) (code, state string) {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func TestOAuth_ExchangeChecksPKCE(t *testing.T) {
+	server := newFakeAuthServer(t)
+	config := server.config()
+
+	verifier, challenge, err := NewPKCE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, state := authorize(t, config.AuthCodeURL("state-1", challenge))
+	if state != "state-1" {
+		t.Errorf("Expected the state to come back, got %q", state)
+	}
+
+	if _, err := config.Exchange(context.Background(), code, "wrong-verifier"); !IsInvalidGrant(err) {
+		t.Fatalf("Expected invalid_grant for a wrong verifier, got %v", err)
+	}
+
+	code, _ = authorize(t, config.AuthCodeURL("state-2", challenge))
+	token, err := config.Exchange(context.Background(), code, verifier)
+	if err != nil {
+		t.Fatalf("Exchange failed: %v", err)
+	}
+	if token.AccessToken != "access-1" || token.RefreshToken != "refresh-1" {
+		t.Errorf("Unexpected token %+v", token)
+	}
+	if !token.Valid(time.Now()) || token.Valid(time.Now().Add(2*time.Hour)) {
+		t.Errorf("Unexpected expiry %v", token.Expiry)
+	}
+}
+
+// memTokenStore is an OAuthTokenStore that sources standing in for
+// separate processes can share.
+type memTokenStore struct {
+	lock sync.Mutex
+
+	mu    sync.Mutex
+	token *OAuthToken
+	saved []*OAuthToken
+}
+
+func (m *memTokenStore) Lock() (func(), error) {
+	m.lock.Lock()
+	return m.lock.Unlock, nil
+}
+
+func (m *memTokenStore) Load() (*OAuthToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.token == nil {
+		return nil, nil
+	}
+	token := *m.token
+	return &token, nil
+}
+
+func (m *memTokenStore) Save(token *OAuthToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	saved := *token
+	m.token = &saved
+	m.saved = append(m.saved, &saved)
+	return nil
+}
+
+func TestOAuthTokenSource_RefreshRotates(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.refresh["refresh-0"] = true
+
+	expired := &OAuthToken{AccessToken: "old", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Minute)}
+	store := &memTokenStore{}
+	source := NewOAuthTokenSource(server.config(), expired, store)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer api.Close()
+	client := &Client{BaseURL: api.URL, Tokens: source, APIKey: "ignored"}
+
+	for i := 0; i < 2; i++ {
+		body, err := client.Call("GET", "/rest/api/3/myself", nil, nil)
+		if err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+		if string(body) != "Bearer access-1" {
+			t.Errorf("Expected the refreshed token, got %q", body)
+		}
+	}
+	if len(store.saved) != 1 || store.saved[0].RefreshToken != "refresh-1" {
+		t.Fatalf("Expected one rotated refresh token to be saved, got %+v", store.saved)
+	}
+	if server.refresh["refresh-0"] {
+		t.Error("Expected the old refresh token to be used up")
+	}
+
+	// Once the rotated token is revoked, the user has to log in again
+	server.mu.Lock()
+	server.refresh = map[string]bool{}
+	server.mu.Unlock()
+	source.token.Expiry = time.Now()
+	store.token.Expiry = time.Now()
+	_, err := client.Call("GET", "/rest/api/3/myself", nil, nil)
+	if !IsInvalidGrant(err) {
+		t.Errorf("Expected invalid_grant, got %v", err)
+	}
+	if IsRetryable(err) {
+		t.Error("Expected OAuth errors not to be retried")
+	}
+}
+
+func TestOAuthTokenSource_ReloadsBeforeRefreshing(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.refresh["refresh-0"] = true
+
+	// Two processes start from the same stored token
+	expired := OAuthToken{AccessToken: "old", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Minute)}
+	store := &memTokenStore{token: &expired}
+	first, second := expired, expired
+	gui := NewOAuthTokenSource(server.config(), &first, store)
+	cli := NewOAuthTokenSource(server.config(), &second, store)
+
+	if token, err := gui.Token(context.Background()); err != nil || token != "access-1" {
+		t.Fatalf("Token = %q, %v", token, err)
+	}
+	// The other picks up the rotated token instead of spending the old one
+	if token, err := cli.Token(context.Background()); err != nil || token != "access-1" {
+		t.Fatalf("Token after another refreshed = %q, %v", token, err)
+	}
+	if server.issued != 1 {
+		t.Errorf("Expected one refresh, got %d", server.issued)
+	}
+
+	// Once that expires too, the stored refresh token is the one used
+	store.token.Expiry = time.Now()
+	cli.token.Expiry = time.Now()
+	if token, err := cli.Token(context.Background()); err != nil || token != "access-2" {
+		t.Fatalf("Token after both expired = %q, %v", token, err)
+	}
+}
+
+func TestNewOAuthClient(t *testing.T) {
+	client := NewOAuthClient("https://api.example.com/", "cloud-1", nil)
+	if client.BaseURL != "https://api.example.com/ex/jira/cloud-1" {
+		t.Errorf("Unexpected base URL %s", client.BaseURL)
+	}
+	if client.GraphQLURL() != "https://api.example.com/graphql" {
+		t.Errorf("Unexpected GraphQL URL %s", client.GraphQLURL())
+	}
+}
@@ -0,0 +1,260 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// PageOptions controls how an Iterator pages through results.
+type PageOptions struct {
+	// PageSize is how many items to ask for per request. Zero lets Jira
+	// pick its default.
+	PageSize int
+	// MaxItems stops the iteration after that many items. Zero means all.
+	MaxItems int
+}
+
+// pageCursor says where the next page starts: an offset for endpoints
+// paged with startAt, or the token from the previous page for
+// /rest/api/3/search/jql.
+type pageCursor struct {
+	startAt int
+	token   string
+}
+
+// page is one response of a paged endpoint.
+type page struct {
+	items []json.RawMessage
+	next  pageCursor
+	last  bool
+}
+
+type fetchPageFunc func(ctx context.Context, cursor pageCursor, pageSize int) (page, error)
+
+// Iterator streams the items of a paged endpoint, fetching the next page
+// only once the current one has been consumed:
+//
+//	it := IterateIssueWorklogs[Worklog](ctx, client, "PROJ-1", "", PageOptions{})
+//	for it.Next() {
+//		worklog := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch fetchPageFunc
+	opts  PageOptions
+
+	cursor pageCursor
+	buf    []json.RawMessage
+	last   bool
+	seen   int
+	value  T
+	err    error
+}
+
+func newIterator[T any](ctx context.Context, opts PageOptions, fetch fetchPageFunc) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch, opts: opts}
+}
+
+// Next advances to the next item, fetching another page if needed. It
+// returns false when there are no more items or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil || (it.opts.MaxItems > 0 && it.seen >= it.opts.MaxItems) {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if it.last {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		p, err := it.fetch(it.ctx, it.cursor, it.pageSize())
+		if err != nil {
+			it.err = err
+			return false
+		}
+		// An empty page ends the iteration even if the server claims
+		// there is more, so a confused server can't loop us forever.
+		it.buf, it.cursor, it.last = p.items, p.next, p.last || len(p.items) == 0
+	}
+
+	var value T
+	if err := json.Unmarshal(it.buf[0], &value); err != nil {
+		it.err = fmt.Errorf("decoding item %d: %w", it.seen, err)
+		return false
+	}
+	it.buf = it.buf[1:]
+	it.value = value
+	it.seen++
+	return true
+}
+
+// Value returns the item Next advanced to.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All collects the remaining items. Prefer Next for large result sets.
+func (it *Iterator[T]) All() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
+
+// pageSize asks for no more than the items still wanted.
+func (it *Iterator[T]) pageSize() int {
+	if it.opts.MaxItems <= 0 {
+		return it.opts.PageSize
+	}
+	remaining := it.opts.MaxItems - it.seen
+	if it.opts.PageSize <= 0 || remaining < it.opts.PageSize {
+		return remaining
+	}
+	return it.opts.PageSize
+}
+
+// IterateSearchJQL iterates over the issues matching jql. On Cloud it
+// follows the nextPageToken of /rest/api/3/search/jql; on Data Center it
+// pages /rest/api/2/search by offset.
+func IterateSearchJQL[T any](ctx context.Context, c *Client, jql string, fields []string, opts PageOptions) *Iterator[T] {
+	return newIterator[T](ctx, opts, func(ctx context.Context, cursor pageCursor, pageSize int) (page, error) {
+		response, err := c.searchJQLPage(ctx, jql, fields, pageSize, cursor)
+		if err != nil {
+			return page{}, err
+		}
+		if c.IsDataCenter() {
+			return offsetPage(response, "issues", cursor.startAt, pageSize)
+		}
+		return tokenPage(response, "issues")
+	})
+}
+
+// IterateSearchIssues iterates over the results of the classic search
+// endpoint, paged by offset.
+func IterateSearchIssues[T any](ctx context.Context, c *Client, jql, expand string, fields []string, opts PageOptions) *Iterator[T] {
+	return newIterator[T](ctx, opts, func(ctx context.Context, cursor pageCursor, pageSize int) (page, error) {
+		response, err := c.SearchIssuesContext(ctx, jql, expand, fields, cursor.startAt, pageSize, false)
+		if err != nil {
+			return page{}, err
+		}
+		return offsetPage(response, "issues", cursor.startAt, pageSize)
+	})
+}
+
+// IterateIssueWorklogs iterates over the worklogs of an issue.
+func IterateIssueWorklogs[T any](ctx context.Context, c *Client, issueIdOrKey, expand string, opts PageOptions) *Iterator[T] {
+	return newIterator[T](ctx, opts, func(ctx context.Context, cursor pageCursor, pageSize int) (page, error) {
+		response, err := c.GetIssueWorklogContext(ctx, issueIdOrKey, cursor.startAt, pageSize, expand)
+		if err != nil {
+			return page{}, err
+		}
+		return offsetPage(response, "worklogs", cursor.startAt, pageSize)
+	})
+}
+
+// IterateIssueComments iterates over the comments on an issue.
+func IterateIssueComments[T any](ctx context.Context, c *Client, issueIdOrKey, orderBy, expand string, opts PageOptions) *Iterator[T] {
+	return newIterator[T](ctx, opts, func(ctx context.Context, cursor pageCursor, pageSize int) (page, error) {
+		response, err := c.GetIssueCommentsContext(ctx, issueIdOrKey, cursor.startAt, pageSize, orderBy, expand)
+		if err != nil {
+			return page{}, err
+		}
+		return offsetPage(response, "comments", cursor.startAt, pageSize)
+	})
+}
+
+// IterateUsers iterates over the users matching query. The user search
+// answers with a bare array, so the last page is the first short one.
+func IterateUsers[T any](ctx context.Context, c *Client, query string, opts PageOptions) *Iterator[T] {
+	return newIterator[T](ctx, opts, func(ctx context.Context, cursor pageCursor, pageSize int) (page, error) {
+		response, err := c.FindUsersContext(ctx, query, cursor.startAt, pageSize, "")
+		if err != nil {
+			return page{}, err
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(response, &items); err != nil {
+			return page{}, fmt.Errorf("unexpected user search response: %w", err)
+		}
+		return page{
+			items: items,
+			next:  pageCursor{startAt: cursor.startAt + len(items)},
+			last:  pageSize > 0 && len(items) < pageSize,
+		}, nil
+	})
+}
+
+// offsetPage reads a response paged with startAt, maxResults and total,
+// holding its items under key.
+func offsetPage(response []byte, key string, startAt, pageSize int) (page, error) {
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(response, &body); err != nil {
+		return page{}, fmt.Errorf("unexpected paged response: %w", err)
+	}
+	var items []json.RawMessage
+	if raw, ok := body[key]; ok {
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return page{}, fmt.Errorf("unexpected %q in paged response: %w", key, err)
+		}
+	}
+	var meta struct {
+		StartAt *int  `json:"startAt"`
+		Total   *int  `json:"total"`
+		IsLast  *bool `json:"isLast"`
+	}
+	if err := json.Unmarshal(response, &meta); err != nil {
+		return page{}, fmt.Errorf("unexpected paged response: %w", err)
+	}
+	if meta.StartAt != nil {
+		startAt = *meta.StartAt
+	}
+
+	p := page{items: items, next: pageCursor{startAt: startAt + len(items)}}
+	switch {
+	case meta.IsLast != nil:
+		p.last = *meta.IsLast
+	case meta.Total != nil:
+		p.last = p.next.startAt >= *meta.Total
+	default:
+		p.last = pageSize > 0 && len(items) < pageSize
+	}
+	return p, nil
+}
+
+// tokenPage reads a response paged with nextPageToken.
+func tokenPage(response []byte, key string) (page, error) {
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(response, &body); err != nil {
+		return page{}, fmt.Errorf("unexpected paged response: %w", err)
+	}
+	var items []json.RawMessage
+	if raw, ok := body[key]; ok {
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return page{}, fmt.Errorf("unexpected %q in paged response: %w", key, err)
+		}
+	}
+	var meta struct {
+		NextPageToken string `json:"nextPageToken"`
+		IsLast        bool   `json:"isLast"`
+	}
+	if err := json.Unmarshal(response, &meta); err != nil {
+		return page{}, fmt.Errorf("unexpected paged response: %w", err)
+	}
+	return page{
+		items: items,
+		next:  pageCursor{token: meta.NextPageToken},
+		last:  meta.IsLast || meta.NextPageToken == "",
+	}, nil
+}
@@ -0,0 +1,154 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type testIssue struct {
+	Key string `json:"key"`
+}
+
+// issuesServer pages 7 issues through the token-based /search/jql.
+func issuesServer(t *testing.T, requests *[]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/search/jql" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		*requests = append(*requests, r.URL.RawQuery)
+		start, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Query().Get("nextPageToken"), "tok-"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		if size == 0 {
+			size = 3
+		}
+		var keys []string
+		for i := start; i < start+size && i < 7; i++ {
+			keys = append(keys, fmt.Sprintf(`{"key":"T-%d"}`, i+1))
+		}
+		next := ""
+		if start+size < 7 {
+			next = fmt.Sprintf(`,"nextPageToken":"tok-%d"`, start+size)
+		}
+		fmt.Fprintf(w, `{"issues":[%s]%s,"isLast":%t}`, strings.Join(keys, ","), next, next == "")
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestIterateSearchJQL_FollowsPageTokens(t *testing.T) {
+	var requests []string
+	client := NewClient(issuesServer(t, &requests).URL, "", "key")
+
+	issues, err := IterateSearchJQL[testIssue](context.Background(), client, "project = T", []string{"key"}, PageOptions{}).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 7 || issues[0].Key != "T-1" || issues[6].Key != "T-7" {
+		t.Errorf("Unexpected issues %+v", issues)
+	}
+	if len(requests) != 3 {
+		t.Errorf("Expected 3 pages, got %d: %v", len(requests), requests)
+	}
+}
+
+func TestIterator_MaxItemsAndStreaming(t *testing.T) {
+	var requests []string
+	client := NewClient(issuesServer(t, &requests).URL, "", "key")
+
+	it := IterateSearchJQL[testIssue](context.Background(), client, "project = T", nil, PageOptions{PageSize: 3, MaxItems: 5})
+	if !it.Next() || it.Value().Key != "T-1" {
+		t.Fatalf("Expected T-1, got %+v (%v)", it.Value(), it.Err())
+	}
+	if len(requests) != 1 {
+		t.Errorf("Expected pages to be fetched lazily, got %d requests", len(requests))
+	}
+
+	count := 1
+	for it.Next() {
+		count++
+	}
+	if it.Err() != nil || count != 5 {
+		t.Errorf("Expected 5 items, got %d (%v)", count, it.Err())
+	}
+	// The last page only asks for the 2 items still wanted
+	if len(requests) != 2 || !strings.Contains(requests[1], "maxResults=2") {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+}
+
+func TestIterateIssueWorklogs_Offset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		var logs []string
+		for i := start; i < start+2 && i < 5; i++ {
+			logs = append(logs, fmt.Sprintf(`{"id":"%d"}`, i))
+		}
+		fmt.Fprintf(w, `{"startAt":%d,"maxResults":2,"total":5,"worklogs":[%s]}`, start, strings.Join(logs, ","))
+	}))
+	defer server.Close()
+
+	var ids []string
+	it := IterateIssueWorklogs[struct{ ID string }](context.Background(), NewClient(server.URL, "", "key"), "T-1", "", PageOptions{PageSize: 2})
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if it.Err() != nil || strings.Join(ids, ",") != "0,1,2,3,4" {
+		t.Errorf("Unexpected worklogs %v (%v)", ids, it.Err())
+	}
+}
+
+func TestIterateUsers_StopsAtShortPage(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("startAt") == "" {
+			w.Write([]byte(`[{"accountId":"a"},{"accountId":"b"}]`))
+			return
+		}
+		w.Write([]byte(`[{"accountId":"c"}]`))
+	}))
+	defer server.Close()
+
+	users, err := IterateUsers[struct {
+		AccountID string `json:"accountId"`
+	}](context.Background(), NewClient(server.URL, "", "key"), "jo", PageOptions{PageSize: 2}).All()
+	if err != nil || len(users) != 3 || calls != 2 {
+		t.Errorf("Expected 3 users in 2 calls, got %+v in %d (%v)", users, calls, err)
+	}
+}
+
+func TestIterator_ErrorsStopIteration(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls > 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"startAt":0,"total":4,"comments":[{"id":"1"},{"id":"2"}]}`))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "", "key")
+
+	it := IterateIssueComments[struct{ ID string }](context.Background(), client, "T-1", "", "", PageOptions{})
+	items := 0
+	for it.Next() {
+		items++
+	}
+	if items != 2 || StatusCode(it.Err()) != http.StatusBadRequest {
+		t.Errorf("Expected 2 items then a 400, got %d and %v", items, it.Err())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it = IterateIssueComments[struct{ ID string }](ctx, client, "T-1", "", "", PageOptions{})
+	if it.Next() || !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Expected a cancelled iterator to stop, got %v", it.Err())
+	}
+}
@@ -1,12 +1,17 @@
 package jiraApiFunctions
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
 // Project APIs
-func GetProjects(expand string, recent int, properties []string) ([]byte, error) {
+func (c *Client) GetProjects(expand string, recent int, properties []string) ([]byte, error) {
+	return c.GetProjectsContext(context.Background(), expand, recent, properties)
+}
+
+func (c *Client) GetProjectsContext(ctx context.Context, expand string, recent int, properties []string) ([]byte, error) {
 	params := map[string]string{
 		"expand": expand,
 	}
@@ -16,14 +21,22 @@ func GetProjects(expand string, recent int, properties []string) ([]byte, error)
 	if len(properties) > 0 {
 		params["properties"] = strings.Join(properties, ",")
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/project", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/project", nil, params)
+}
+
+func (c *Client) CreateProject(projectData interface{}) ([]byte, error) {
+	return c.CreateProjectContext(context.Background(), projectData)
 }
 
-func CreateProject(projectData interface{}) ([]byte, error) {
-	return MakeJiraAPICall("POST", "/rest/api/3/project", projectData, nil)
+func (c *Client) CreateProjectContext(ctx context.Context, projectData interface{}) ([]byte, error) {
+	return c.CallContext(ctx, "POST", "/rest/api/3/project", projectData, nil)
 }
 
-func GetProject(projectIdOrKey, expand string, properties []string) ([]byte, error) {
+func (c *Client) GetProject(projectIdOrKey, expand string, properties []string) ([]byte, error) {
+	return c.GetProjectContext(context.Background(), projectIdOrKey, expand, properties)
+}
+
+func (c *Client) GetProjectContext(ctx context.Context, projectIdOrKey, expand string, properties []string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/project/%s", projectIdOrKey)
 	params := map[string]string{
 		"expand": expand,
@@ -31,32 +44,48 @@ func GetProject(projectIdOrKey, expand string, properties []string) ([]byte, err
 	if len(properties) > 0 {
 		params["properties"] = strings.Join(properties, ",")
 	}
-	return MakeJiraAPICall("GET", endpoint, nil, params)
+	return c.CallContext(ctx, "GET", endpoint, nil, params)
+}
+
+func (c *Client) UpdateProject(projectIdOrKey string, projectData interface{}) ([]byte, error) {
+	return c.UpdateProjectContext(context.Background(), projectIdOrKey, projectData)
 }
 
-func UpdateProject(projectIdOrKey string, projectData interface{}) ([]byte, error) {
+func (c *Client) UpdateProjectContext(ctx context.Context, projectIdOrKey string, projectData interface{}) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/project/%s", projectIdOrKey)
-	return MakeJiraAPICall("PUT", endpoint, projectData, nil)
+	return c.CallContext(ctx, "PUT", endpoint, projectData, nil)
+}
+
+func (c *Client) DeleteProject(projectIdOrKey string, enableUndo bool) ([]byte, error) {
+	return c.DeleteProjectContext(context.Background(), projectIdOrKey, enableUndo)
 }
 
-func DeleteProject(projectIdOrKey string, enableUndo bool) ([]byte, error) {
+func (c *Client) DeleteProjectContext(ctx context.Context, projectIdOrKey string, enableUndo bool) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/project/%s", projectIdOrKey)
 	params := map[string]string{}
 	if enableUndo {
 		params["enableUndo"] = "true"
 	}
-	return MakeJiraAPICall("DELETE", endpoint, nil, params)
+	return c.CallContext(ctx, "DELETE", endpoint, nil, params)
 }
 
-func GetProjectComponents(projectIdOrKey string) ([]byte, error) {
+func (c *Client) GetProjectComponents(projectIdOrKey string) ([]byte, error) {
+	return c.GetProjectComponentsContext(context.Background(), projectIdOrKey)
+}
+
+func (c *Client) GetProjectComponentsContext(ctx context.Context, projectIdOrKey string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/project/%s/components", projectIdOrKey)
-	return MakeJiraAPICall("GET", endpoint, nil, nil)
+	return c.CallContext(ctx, "GET", endpoint, nil, nil)
+}
+
+func (c *Client) GetProjectVersions(projectIdOrKey, expand string) ([]byte, error) {
+	return c.GetProjectVersionsContext(context.Background(), projectIdOrKey, expand)
 }
 
-func GetProjectVersions(projectIdOrKey, expand string) ([]byte, error) {
+func (c *Client) GetProjectVersionsContext(ctx context.Context, projectIdOrKey, expand string) ([]byte, error) {
 	endpoint := fmt.Sprintf("/rest/api/3/project/%s/versions", projectIdOrKey)
 	params := map[string]string{
 		"expand": expand,
 	}
-	return MakeJiraAPICall("GET", endpoint, nil, params)
+	return c.CallContext(ctx, "GET", endpoint, nil, params)
 }
\ No newline at end of file
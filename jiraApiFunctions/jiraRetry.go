@@ -0,0 +1,126 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how a Client retries failed requests.
+//
+// Only requests that are safe to repeat are retried: idempotent methods,
+// and POSTs explicitly sent through CallRetryable. Network errors, 408, 429
+// and 5xx responses trigger a retry. When Jira says how long to wait, via
+// Retry-After or X-RateLimit-Reset, that wait is used instead of the
+// jittered exponential backoff.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles for each
+	// further retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. If Jira asks for a longer wait than this,
+	// the request fails instead of blocking for that long.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is used by clients without a policy of their own.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// NoRetries disables retries when assigned to Client.Retry.
+var NoRetries = &RetryPolicy{MaxAttempts: 1}
+
+// sleep waits for d or until ctx is done. Tests swap it out to avoid real
+// waits.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) retryPolicy() RetryPolicy {
+	if c.Retry != nil {
+		return *c.Retry
+	}
+	return DefaultRetryPolicy
+}
+
+// delay returns how long to wait before the next attempt after attempt
+// failed with err, and false if the request should not be retried.
+func (p RetryPolicy) delay(attempt int, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || !IsRetryable(err) {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		if p.MaxDelay > 0 && apiErr.RetryAfter > p.MaxDelay {
+			return 0, false
+		}
+		return apiErr.RetryAfter, true
+	}
+
+	backoff := p.BaseDelay << (attempt - 1)
+	if backoff <= 0 || (p.MaxDelay > 0 && backoff > p.MaxDelay) {
+		backoff = p.MaxDelay
+	}
+	// Equal jitter: wait at least half the backoff so retries stay spaced
+	// out, and randomise the rest so clients don't retry in lockstep.
+	half := backoff / 2
+	if half <= 0 {
+		return backoff, true
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1)), true
+}
+
+// isIdempotent reports whether repeating a request with method has the same
+// effect as sending it once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryAfter reads how long the server wants us to wait from the response
+// headers. Retry-After may be in seconds or an HTTP date; Jira Cloud also
+// sends X-RateLimit-Reset as an ISO 8601 timestamp when throttling.
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return positive(at.Sub(now))
+		}
+	}
+	if value := header.Get("X-RateLimit-Reset"); value != "" {
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z07:00"} {
+			if at, err := time.Parse(layout, value); err == nil {
+				return positive(at.Sub(now))
+			}
+		}
+	}
+	return 0
+}
+
+func positive(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,253 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stubSleep records requested waits instead of sleeping.
+func stubSleep(t *testing.T) *[]time.Duration {
+	var waits []time.Duration
+	original := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	t.Cleanup(func() { sleep = original })
+	return &waits
+}
+
+// flakyServer fails the first failures requests via fail, then succeeds.
+func flakyServer(t *testing.T, failures int32, fail func(w http.ResponseWriter)) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if r.Body != nil {
+			body, _ := io.ReadAll(r.Body)
+			if r.Method == http.MethodPost && len(body) == 0 {
+				t.Errorf("Attempt %d was sent without its body", n)
+			}
+		}
+		if n <= failures {
+			fail(w)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRetry_GetRetriesServerErrors(t *testing.T) {
+	waits := stubSleep(t)
+	server, calls := flakyServer(t, 2, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client := NewClient(server.URL, "", "token")
+	client.Retry = &RetryPolicy{MaxAttempts: 4, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	body, err := client.GetIssue("TEST-1", "", "")
+	if err != nil {
+		t.Fatalf("Expected success after retries, got %v", err)
+	}
+	if string(body) != `{"ok":true}` || *calls != 3 {
+		t.Errorf("Expected 3 calls ending in success, got %d calls and %s", *calls, body)
+	}
+
+	// Jittered backoff stays between half and all of the doubling delay
+	if len(*waits) != 2 {
+		t.Fatalf("Expected 2 waits, got %v", *waits)
+	}
+	for i, max := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond} {
+		if w := (*waits)[i]; w < max/2 || w > max {
+			t.Errorf("Wait %d = %v, expected between %v and %v", i, w, max/2, max)
+		}
+	}
+}
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	waits := stubSleep(t)
+	server, calls := flakyServer(t, 1, func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	client := NewClient(server.URL, "", "token")
+	if _, err := client.GetCurrentUser(""); err != nil {
+		t.Fatalf("Expected success after rate limit, got %v", err)
+	}
+	if *calls != 2 || len(*waits) != 1 || (*waits)[0] != 7*time.Second {
+		t.Errorf("Expected one 7s wait, got %d calls and waits %v", *calls, *waits)
+	}
+}
+
+func TestRetry_HonorsRateLimitReset(t *testing.T) {
+	waits := stubSleep(t)
+	server, _ := flakyServer(t, 1, func(w http.ResponseWriter) {
+		w.Header().Set("X-RateLimit-Reset", time.Now().Add(5*time.Second).UTC().Format(time.RFC3339))
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	client := NewClient(server.URL, "", "token")
+	if _, err := client.GetCurrentUser(""); err != nil {
+		t.Fatalf("Expected success after rate limit, got %v", err)
+	}
+	if len(*waits) != 1 || (*waits)[0] < 3*time.Second || (*waits)[0] > 5*time.Second {
+		t.Errorf("Expected a wait of about 5s, got %v", *waits)
+	}
+}
+
+func TestRetry_GivesUpWhenRetryAfterTooLong(t *testing.T) {
+	waits := stubSleep(t)
+	server, calls := flakyServer(t, 1, func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	client := NewClient(server.URL, "", "token")
+	_, err := client.GetCurrentUser("")
+	if !IsRateLimited(err) {
+		t.Fatalf("Expected rate limit error, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != time.Hour {
+		t.Errorf("Expected RetryAfter of 1h on the error, got %+v", apiErr)
+	}
+	if *calls != 1 || len(*waits) != 0 {
+		t.Errorf("Expected no retry, got %d calls and waits %v", *calls, *waits)
+	}
+}
+
+func TestRetry_NeverRetriesWorklogPost(t *testing.T) {
+	stubSleep(t)
+	server, calls := flakyServer(t, 1, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	client := NewClient(server.URL, "", "token")
+	_, err := client.AddWorklog("TEST-1", map[string]string{"timeSpent": "1h"})
+	if !IsServerError(err) {
+		t.Fatalf("Expected server error, got %v", err)
+	}
+	if *calls != 1 {
+		t.Errorf("Expected AddWorklog to be sent once, got %d calls", *calls)
+	}
+}
+
+func TestRetry_RetryablePostIsRetried(t *testing.T) {
+	stubSleep(t)
+	server, calls := flakyServer(t, 1, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	client := NewClient(server.URL, "", "token")
+	if _, err := client.SearchIssuesPost(map[string]string{"jql": "project = TEST"}); err != nil {
+		t.Fatalf("Expected search to succeed after retry, got %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", *calls)
+	}
+}
+
+func TestRetry_StopsAfterMaxAttempts(t *testing.T) {
+	stubSleep(t)
+	server, calls := flakyServer(t, 100, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	client := NewClient(server.URL, "", "token")
+	client.Retry = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	if _, err := client.GetFields(); !IsServerError(err) {
+		t.Fatalf("Expected server error, got %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", *calls)
+	}
+}
+
+func TestRetry_ClientErrorsAreNotRetried(t *testing.T) {
+	stubSleep(t)
+	server, calls := flakyServer(t, 1, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	client := NewClient(server.URL, "", "token")
+	if _, err := client.GetIssue("NOPE-1", "", ""); !IsNotFound(err) {
+		t.Fatalf("Expected not found, got %v", err)
+	}
+	if *calls != 1 {
+		t.Errorf("Expected a single attempt, got %d", *calls)
+	}
+}
+
+func TestTimeout_AttemptIsAbortedAndRetried(t *testing.T) {
+	stubSleep(t)
+	var calls int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL, "", "token")
+	client.Timeout = 50 * time.Millisecond
+	body, err := client.GetIssue("TEST-1", "", "")
+	if err != nil {
+		t.Fatalf("Expected the retry after a timeout to succeed, got %v", err)
+	}
+	if string(body) != `{"ok":true}` || atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("Expected 2 calls ending in success, got %d calls and %s", calls, body)
+	}
+}
+
+func TestContext_CancelStopsRetries(t *testing.T) {
+	server, calls := flakyServer(t, 100, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	original := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return original(ctx, d)
+	}
+	t.Cleanup(func() { sleep = original })
+
+	client := NewClient(server.URL, "", "token")
+	_, err := client.GetIssueContext(ctx, "TEST-1", "", "")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if *calls != 1 {
+		t.Errorf("Expected no attempts after cancelling, got %d calls", *calls)
+	}
+}
+
+func TestContext_CancelledBeforeSending(t *testing.T) {
+	server, calls := flakyServer(t, 0, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := NewClient(server.URL, "", "token")
+	if _, err := client.GetIssueContext(ctx, "TEST-1", "", ""); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if *calls != 0 {
+		t.Errorf("Expected no request to be sent, got %d", *calls)
+	}
+}
@@ -1,12 +1,17 @@
 package jiraApiFunctions
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
 // Search APIs
-func SearchIssues(jql, expand string, fields []string, startAt, maxResults int, validateQuery bool) ([]byte, error) {
+func (c *Client) SearchIssues(jql, expand string, fields []string, startAt, maxResults int, validateQuery bool) ([]byte, error) {
+	return c.SearchIssuesContext(context.Background(), jql, expand, fields, startAt, maxResults, validateQuery)
+}
+
+func (c *Client) SearchIssuesContext(ctx context.Context, jql, expand string, fields []string, startAt, maxResults int, validateQuery bool) ([]byte, error) {
 	params := map[string]string{
 		"jql":    jql,
 		"expand": expand,
@@ -23,9 +28,44 @@ func SearchIssues(jql, expand string, fields []string, startAt, maxResults int,
 	if validateQuery {
 		params["validateQuery"] = "true"
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/search", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/search", nil, params)
+}
+
+func (c *Client) SearchIssuesPost(searchRequest interface{}) ([]byte, error) {
+	return c.SearchIssuesPostContext(context.Background(), searchRequest)
+}
+
+func (c *Client) SearchIssuesPostContext(ctx context.Context, searchRequest interface{}) ([]byte, error) {
+	return c.CallRetryableContext(ctx, "POST", "/rest/api/3/search", searchRequest, nil)
+}
+
+// SearchJQL runs a JQL search with the site's search endpoint: Cloud's
+// enhanced /search/jql, or /search on Data Center. Both answer with an
+// "issues" array.
+func (c *Client) SearchJQL(jql string, fields []string, maxResults int) ([]byte, error) {
+	return c.SearchJQLContext(context.Background(), jql, fields, maxResults)
+}
+
+func (c *Client) SearchJQLContext(ctx context.Context, jql string, fields []string, maxResults int) ([]byte, error) {
+	return c.searchJQLPage(ctx, jql, fields, maxResults, pageCursor{})
 }
 
-func SearchIssuesPost(searchRequest interface{}) ([]byte, error) {
-	return MakeJiraAPICall("POST", "/rest/api/3/search", searchRequest, nil)
-}
\ No newline at end of file
+// searchJQLPage fetches the page of a JQL search at cursor: a
+// nextPageToken on Cloud, or an offset on Data Center.
+func (c *Client) searchJQLPage(ctx context.Context, jql string, fields []string, maxResults int, cursor pageCursor) ([]byte, error) {
+	params := map[string]string{
+		"jql":    jql,
+		"fields": strings.Join(fields, ","),
+	}
+	if maxResults > 0 {
+		params["maxResults"] = fmt.Sprintf("%d", maxResults)
+	}
+	if c.IsDataCenter() {
+		if cursor.startAt > 0 {
+			params["startAt"] = fmt.Sprintf("%d", cursor.startAt)
+		}
+		return c.CallContext(ctx, "GET", "/rest/api/2/search", nil, params)
+	}
+	params["nextPageToken"] = cursor.token
+	return c.CallContext(ctx, "GET", "/rest/api/3/search/jql", nil, params)
+}
@@ -0,0 +1,117 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"jiraTimeWidget/internal/openapi"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const specPath = "../jiraRestApiDoc/OpenApi.json"
+
+// notInSpec lists wrappers whose endpoints the bundled spec doesn't
+// describe, so they can't drift from it.
+var notInSpec = map[string]bool{
+	"GetTenantInfoContext": true,
+}
+
+// TestWrappersMatchSpec calls every hand-written XxxContext wrapper with
+// non-empty arguments and checks that the endpoint it hits and the query
+// parameters it sends are ones the bundled OpenAPI spec knows about.
+func TestWrappersMatchSpec(t *testing.T) {
+	spec, err := openapi.Load(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var method, path string
+	var query []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		query = query[:0]
+		for name := range r.URL.Query() {
+			query = append(query, name)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "", "key")
+	client.Retry = NoRetries
+
+	clientValue := reflect.ValueOf(client)
+	clientType := clientValue.Type()
+	checked := 0
+	for i := 0; i < clientType.NumMethod(); i++ {
+		m := clientType.Method(i)
+		if !isEndpointWrapper(m) || notInSpec[m.Name] {
+			continue
+		}
+		method, path = "", ""
+		args := []reflect.Value{clientValue, reflect.ValueOf(context.Background())}
+		for j := 2; j < m.Type.NumIn(); j++ {
+			args = append(args, sampleArgument(m.Type.In(j)))
+		}
+		m.Func.Call(args)
+		if path == "" {
+			t.Errorf("%s sent no request", m.Name)
+			continue
+		}
+
+		op := spec.Match(method, path)
+		if op == nil {
+			t.Errorf("%s: %s %s is not in the spec", m.Name, method, path)
+			continue
+		}
+		known := map[string]bool{}
+		for _, name := range op.ParametersIn("query") {
+			known[name] = true
+		}
+		sort.Strings(query)
+		for _, name := range query {
+			if !known[name] {
+				t.Errorf("%s: query parameter %q is not a parameter of %s (%s %s), which takes %v",
+					m.Name, name, op.OperationID, op.Method, op.Path, op.ParametersIn("query"))
+			}
+		}
+		checked++
+	}
+	if checked < 50 {
+		t.Errorf("Only %d wrappers were checked; is the method filter wrong?", checked)
+	}
+}
+
+// isEndpointWrapper picks out the XxxContext methods that wrap a single
+// endpoint, skipping the generic request helpers.
+func isEndpointWrapper(m reflect.Method) bool {
+	if !strings.HasSuffix(m.Name, "Context") || strings.HasPrefix(m.Name, "Call") || m.Name == "GraphQLContext" {
+		return false
+	}
+	return m.Type.NumIn() >= 2 && m.Type.In(1) == reflect.TypeOf((*context.Context)(nil)).Elem()
+}
+
+// sampleArgument returns a non-empty value of t, so that wrappers send
+// every optional parameter they know about.
+func sampleArgument(t reflect.Type) reflect.Value {
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.ValueOf("x").Convert(t)
+	case reflect.Int:
+		return reflect.ValueOf(1).Convert(t)
+	case reflect.Bool:
+		return reflect.ValueOf(true).Convert(t)
+	case reflect.Slice:
+		slice := reflect.MakeSlice(t, 1, 1)
+		slice.Index(0).Set(sampleArgument(t.Elem()))
+		return slice
+	case reflect.Map:
+		return reflect.MakeMap(t)
+	case reflect.Interface:
+		return reflect.ValueOf(map[string]interface{}{}).Convert(t)
+	default:
+		return reflect.Zero(t)
+	}
+}
@@ -0,0 +1,101 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"jiraTimeWidget/jiraModels"
+	"strings"
+)
+
+// The functions in this file decode responses into jiraModels types, for
+// callers that don't need the raw JSON.
+
+// ErrCreatedUnconfirmed is returned when Jira accepted a request creating
+// something but its response couldn't be read, so the new ID is unknown.
+// The request must not be repeated, or Jira would create it twice.
+var ErrCreatedUnconfirmed = errors.New("created, but Jira's response could not be read")
+
+// Issue fetches an issue with the given fields; nil fields returns all of
+// them. Use expand "transitions" to include the available transitions.
+func (c *Client) Issue(ctx context.Context, issueIdOrKey string, fields []string, expand string) (*jiraModels.Issue, error) {
+	response, err := c.GetIssueContext(ctx, issueIdOrKey, strings.Join(fields, ","), expand)
+	if err != nil {
+		return nil, err
+	}
+	var issue jiraModels.Issue
+	if err := decode(response, "issue "+issueIdOrKey, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// Transitions returns the transitions currently available on an issue,
+// including the fields each transition's screen asks for.
+func (c *Client) Transitions(ctx context.Context, issueIdOrKey string) ([]jiraModels.Transition, error) {
+	response, err := c.GetIssueTransitionsContext(ctx, issueIdOrKey, "transitions.fields")
+	if err != nil {
+		return nil, err
+	}
+	var body struct {
+		Transitions []jiraModels.Transition `json:"transitions"`
+	}
+	if err := decode(response, "transitions of "+issueIdOrKey, &body); err != nil {
+		return nil, err
+	}
+	return body.Transitions, nil
+}
+
+// Myself returns the user the client is authenticated as.
+func (c *Client) Myself(ctx context.Context) (*jiraModels.User, error) {
+	response, err := c.GetCurrentUserContext(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	var user jiraModels.User
+	if err := decode(response, "current user", &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// CreateWorklog logs work on an issue and returns the worklog Jira
+// created.
+func (c *Client) CreateWorklog(ctx context.Context, issueIdOrKey string, worklogData interface{}) (*jiraModels.Worklog, error) {
+	response, err := c.AddWorklogContext(ctx, issueIdOrKey, worklogData)
+	if err != nil {
+		return nil, err
+	}
+	var worklog jiraModels.Worklog
+	if err := decode(response, "worklog", &worklog); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCreatedUnconfirmed, err)
+	}
+	if worklog.ID == "" {
+		return nil, fmt.Errorf("%w: worklog response has no ID", ErrCreatedUnconfirmed)
+	}
+	return &worklog, nil
+}
+
+// Search iterates over the issues matching jql with the site's search
+// endpoint.
+func (c *Client) Search(ctx context.Context, jql string, fields []string, opts PageOptions) *Iterator[jiraModels.Issue] {
+	return IterateSearchJQL[jiraModels.Issue](ctx, c, jql, fields, opts)
+}
+
+// Worklogs iterates over the worklogs of an issue.
+func (c *Client) Worklogs(ctx context.Context, issueIdOrKey string, opts PageOptions) *Iterator[jiraModels.Worklog] {
+	return IterateIssueWorklogs[jiraModels.Worklog](ctx, c, issueIdOrKey, "", opts)
+}
+
+// Comments iterates over the comments on an issue, oldest first.
+func (c *Client) Comments(ctx context.Context, issueIdOrKey string, opts PageOptions) *Iterator[jiraModels.Comment] {
+	return IterateIssueComments[jiraModels.Comment](ctx, c, issueIdOrKey, "created", "", opts)
+}
+
+func decode(response []byte, what string, v interface{}) error {
+	if err := json.Unmarshal(response, v); err != nil {
+		return fmt.Errorf("unexpected %s response: %w", what, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package jiraApiFunctions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTypedFunctions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/issue/TEST-1":
+			if r.URL.Query().Get("fields") != "summary,status" {
+				t.Errorf("Unexpected fields %q", r.URL.Query().Get("fields"))
+			}
+			w.Write([]byte(`{"key":"TEST-1","fields":{"summary":"Typed","status":{"id":"1","name":"Open","statusCategory":{"key":"new"}}}}`))
+		case "/rest/api/3/issue/TEST-1/transitions":
+			if r.URL.Query().Get("expand") != "transitions.fields" {
+				t.Errorf("Expected transition fields to be expanded, got %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"transitions":[{"id":"31","name":"Done","to":{"id":"5","name":"Done","statusCategory":{"key":"done"}},
+				"fields":{"resolution":{"required":true,"name":"Resolution"}}}]}`))
+		case "/rest/api/3/issue/TEST-1/worklog":
+			w.Write([]byte(`{"id":"200","timeSpentSeconds":3600,"started":"2024-03-04T09:00:00.000+0000"}`))
+		case "/rest/api/3/issue/TEST-2/worklog":
+			w.Write([]byte(`{}`))
+		case "/rest/api/3/myself":
+			w.Write([]byte(`{"accountId":"abc","displayName":"Jane Doe"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "", "key")
+	ctx := context.Background()
+
+	issue, err := client.Issue(ctx, "TEST-1", []string{"summary", "status"}, "")
+	if err != nil || issue.Fields.Summary != "Typed" || issue.Fields.Status.StatusCategory.Key != "new" {
+		t.Errorf("Issue = %+v, %v", issue, err)
+	}
+
+	transitions, err := client.Transitions(ctx, "TEST-1")
+	if err != nil || len(transitions) != 1 || transitions[0].To.StatusCategory.Key != "done" || !transitions[0].Fields["resolution"].Required {
+		t.Errorf("Transitions = %+v, %v", transitions, err)
+	}
+
+	worklog, err := client.CreateWorklog(ctx, "TEST-1", map[string]interface{}{"timeSpent": "1h"})
+	if err != nil || worklog.ID != "200" || worklog.Started.IsZero() {
+		t.Errorf("CreateWorklog = %+v, %v", worklog, err)
+	}
+	if _, err := client.CreateWorklog(ctx, "TEST-2", nil); !errors.Is(err, ErrCreatedUnconfirmed) || IsRetryable(err) {
+		t.Errorf("Expected a worklog without an ID to be created but unconfirmed, got %v", err)
+	}
+
+	user, err := client.Myself(ctx)
+	if err != nil || user.AccountID != "abc" {
+		t.Errorf("Myself = %+v, %v", user, err)
+	}
+
+	if _, err := client.Issue(ctx, "MISSING-1", nil, ""); !IsNotFound(err) {
+		t.Errorf("Expected a 404 to come through as an APIError, got %v", err)
+	}
+}
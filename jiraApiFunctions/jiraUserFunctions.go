@@ -1,39 +1,62 @@
 package jiraApiFunctions
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // User APIs
-func GetCurrentUser(expand string) ([]byte, error) {
+func (c *Client) GetCurrentUser(expand string) ([]byte, error) {
+	return c.GetCurrentUserContext(context.Background(), expand)
+}
+
+func (c *Client) GetCurrentUserContext(ctx context.Context, expand string) ([]byte, error) {
 	params := map[string]string{
 		"expand": expand,
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/myself", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/myself", nil, params)
+}
+
+func (c *Client) GetUser(accountId, username, key, expand string) ([]byte, error) {
+	return c.GetUserContext(context.Background(), accountId, username, key, expand)
 }
 
-func GetUser(accountId, username, key, expand string) ([]byte, error) {
+func (c *Client) GetUserContext(ctx context.Context, accountId, username, key, expand string) ([]byte, error) {
 	params := map[string]string{
 		"accountId": accountId,
 		"username":  username,
 		"key":       key,
 		"expand":    expand,
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/user", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/user", nil, params)
+}
+
+func (c *Client) CreateUser(userData interface{}) ([]byte, error) {
+	return c.CreateUserContext(context.Background(), userData)
 }
 
-func CreateUser(userData interface{}) ([]byte, error) {
-	return MakeJiraAPICall("POST", "/rest/api/3/user", userData, nil)
+func (c *Client) CreateUserContext(ctx context.Context, userData interface{}) ([]byte, error) {
+	return c.CallContext(ctx, "POST", "/rest/api/3/user", userData, nil)
 }
 
-func DeleteUser(accountId, username, key string) ([]byte, error) {
+func (c *Client) DeleteUser(accountId, username, key string) ([]byte, error) {
+	return c.DeleteUserContext(context.Background(), accountId, username, key)
+}
+
+func (c *Client) DeleteUserContext(ctx context.Context, accountId, username, key string) ([]byte, error) {
 	params := map[string]string{
 		"accountId": accountId,
 		"username":  username,
 		"key":       key,
 	}
-	return MakeJiraAPICall("DELETE", "/rest/api/3/user", nil, params)
+	return c.CallContext(ctx, "DELETE", "/rest/api/3/user", nil, params)
+}
+
+func (c *Client) FindUsers(query string, startAt, maxResults int, property string) ([]byte, error) {
+	return c.FindUsersContext(context.Background(), query, startAt, maxResults, property)
 }
 
-func FindUsers(query string, startAt, maxResults int, property string) ([]byte, error) {
+func (c *Client) FindUsersContext(ctx context.Context, query string, startAt, maxResults int, property string) ([]byte, error) {
 	params := map[string]string{
 		"query":    query,
 		"property": property,
@@ -44,14 +67,18 @@ func FindUsers(query string, startAt, maxResults int, property string) ([]byte,
 	if maxResults > 0 {
 		params["maxResults"] = fmt.Sprintf("%d", maxResults)
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/user/search", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/user/search", nil, params)
+}
+
+func (c *Client) GetUserGroups(accountId, username, key string) ([]byte, error) {
+	return c.GetUserGroupsContext(context.Background(), accountId, username, key)
 }
 
-func GetUserGroups(accountId, username, key string) ([]byte, error) {
+func (c *Client) GetUserGroupsContext(ctx context.Context, accountId, username, key string) ([]byte, error) {
 	params := map[string]string{
 		"accountId": accountId,
 		"username":  username,
 		"key":       key,
 	}
-	return MakeJiraAPICall("GET", "/rest/api/3/user/groups", nil, params)
+	return c.CallContext(ctx, "GET", "/rest/api/3/user/groups", nil, params)
 }
\ No newline at end of file
@@ -0,0 +1,85 @@
+// Package jiraModels holds typed versions of the Jira REST API responses
+// the widget uses, so callers don't each unmarshal their own anonymous
+// structs. The types work for both Cloud (API v3) and Data Center (API v2)
+// responses; fields only one of them sends are left empty on the other.
+package jiraModels
+
+// Issue is a Jira issue. Only the fields asked for in the request are set.
+type Issue struct {
+	ID     string      `json:"id"`
+	Key    string      `json:"key"`
+	Self   string      `json:"self"`
+	Fields IssueFields `json:"fields"`
+	// Transitions is set when the issue is requested with
+	// expand=transitions.
+	Transitions []Transition `json:"transitions,omitempty"`
+}
+
+// IssueFields are the standard fields of an issue.
+type IssueFields struct {
+	Summary     string     `json:"summary"`
+	Description RichText   `json:"description"`
+	Status      *Status    `json:"status"`
+	IssueType   *IssueType `json:"issuetype"`
+	Project     *Project   `json:"project"`
+	Priority    *Priority  `json:"priority"`
+	Assignee    *User      `json:"assignee"`
+	Reporter    *User      `json:"reporter"`
+	Created     Time       `json:"created"`
+	Updated     Time       `json:"updated"`
+	// TimeSpent is the total time logged on the issue, in seconds.
+	TimeSpent    int           `json:"timespent"`
+	TimeTracking *TimeTracking `json:"timetracking"`
+}
+
+// TimeTracking holds an issue's estimates and logged time, both as Jira
+// formats them (e.g. "1d 2h") and in seconds.
+type TimeTracking struct {
+	OriginalEstimate         string `json:"originalEstimate"`
+	RemainingEstimate        string `json:"remainingEstimate"`
+	TimeSpent                string `json:"timeSpent"`
+	OriginalEstimateSeconds  int    `json:"originalEstimateSeconds"`
+	RemainingEstimateSeconds int    `json:"remainingEstimateSeconds"`
+	TimeSpentSeconds         int    `json:"timeSpentSeconds"`
+}
+
+// Status is an issue's workflow status.
+type Status struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Description    string         `json:"description"`
+	StatusCategory StatusCategory `json:"statusCategory"`
+}
+
+// StatusCategory groups statuses into to do ("new"), in progress
+// ("indeterminate") and done ("done").
+type StatusCategory struct {
+	ID        int    `json:"id"`
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	ColorName string `json:"colorName"`
+}
+
+// Status category keys.
+const (
+	StatusCategoryToDo       = "new"
+	StatusCategoryInProgress = "indeterminate"
+	StatusCategoryDone       = "done"
+)
+
+type IssueType struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Subtask bool   `json:"subtask"`
+}
+
+type Project struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type Priority struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
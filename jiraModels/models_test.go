@@ -0,0 +1,88 @@
+package jiraModels
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestIssue_Decode(t *testing.T) {
+	data := `{"id":"10001","key":"TEST-1","fields":{
+		"summary":"Fix the widget",
+		"status":{"id":"3","name":"In Progress","statusCategory":{"id":4,"key":"indeterminate","name":"In Progress"}},
+		"assignee":{"accountId":"abc","displayName":"Jane Doe"},
+		"created":"2024-03-04T09:00:00.000+0100",
+		"updated":null,
+		"description":{"type":"doc","version":1,"content":[
+			{"type":"paragraph","content":[{"type":"text","text":"First "},{"type":"text","text":"line"}]},
+			{"type":"paragraph","content":[{"type":"text","text":"Second"},{"type":"hardBreak"},{"type":"text","text":"line"}]}]}}}`
+
+	var issue Issue
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatal(err)
+	}
+	if issue.Key != "TEST-1" || issue.Fields.Summary != "Fix the widget" {
+		t.Errorf("Unexpected issue %+v", issue)
+	}
+	if issue.Fields.Status.StatusCategory.Key != StatusCategoryInProgress {
+		t.Errorf("Unexpected status %+v", issue.Fields.Status)
+	}
+	if issue.Fields.Assignee.AccountID != "abc" || issue.Fields.Reporter != nil {
+		t.Errorf("Unexpected users %+v %+v", issue.Fields.Assignee, issue.Fields.Reporter)
+	}
+	want := time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC)
+	if !issue.Fields.Created.Equal(want) || !issue.Fields.Updated.IsZero() {
+		t.Errorf("Unexpected times %v, %v", issue.Fields.Created, issue.Fields.Updated)
+	}
+	if got := issue.Fields.Description.Text(); got != "First line\nSecond\nline" {
+		t.Errorf("Unexpected description %q", got)
+	}
+}
+
+func TestRichText_PlainAndRoundTrip(t *testing.T) {
+	var comment Comment
+	if err := json.Unmarshal([]byte(`{"id":"1","body":"Plain *wiki* text","created":"2024-03-04T09:00:00.000+0000"}`), &comment); err != nil {
+		t.Fatal(err)
+	}
+	if comment.Body.Text() != "Plain *wiki* text" {
+		t.Errorf("Unexpected body %q", comment.Body.Text())
+	}
+
+	data, err := json.Marshal(comment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again Comment
+	json.Unmarshal(data, &again)
+	if again.Body.Text() != comment.Body.Text() || !again.Created.Equal(comment.Created.Time) {
+		t.Errorf("Round trip changed the comment: %s", data)
+	}
+}
+
+func TestTime_Formats(t *testing.T) {
+	for _, in := range []string{`"2024-03-04T09:00:00.000+0000"`, `"2024-03-04T09:00:00Z"`} {
+		var tm Time
+		if err := json.Unmarshal([]byte(in), &tm); err != nil || !tm.Equal(time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)) {
+			t.Errorf("Unmarshal(%s) = %v, %v", in, tm, err)
+		}
+	}
+	var tm Time
+	if err := json.Unmarshal([]byte(`"yesterday"`), &tm); err == nil {
+		t.Error("Expected an invalid time to fail")
+	}
+}
+
+func TestTransition_RequiredFields(t *testing.T) {
+	var transition Transition
+	json.Unmarshal([]byte(`{"id":"31","name":"Done","to":{"name":"Done"},"fields":{
+		"resolution":{"required":true,"name":"Resolution","schema":{"type":"resolution","system":"resolution"}},
+		"comment":{"required":false,"name":"Comment"}}}`), &transition)
+
+	required := transition.RequiredFields()
+	if len(required) != 1 || required[0] != "resolution" {
+		t.Errorf("Expected resolution to be required, got %v", required)
+	}
+	if transition.Fields["resolution"].Schema.System != "resolution" {
+		t.Errorf("Unexpected schema %+v", transition.Fields["resolution"].Schema)
+	}
+}
@@ -0,0 +1,49 @@
+package jiraModels
+
+import "encoding/json"
+
+// Transition moves an issue to another status.
+type Transition struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	To            Status `json:"to"`
+	HasScreen     bool   `json:"hasScreen"`
+	IsGlobal      bool   `json:"isGlobal"`
+	IsInitial     bool   `json:"isInitial"`
+	IsConditional bool   `json:"isConditional"`
+	// Fields lists what the transition's screen asks for, keyed by field
+	// ID. It is only set when requested with expand=transitions.fields.
+	Fields map[string]TransitionField `json:"fields,omitempty"`
+}
+
+// RequiredFields returns the IDs of the fields that must be set to perform
+// the transition.
+func (t Transition) RequiredFields() []string {
+	var required []string
+	for id, field := range t.Fields {
+		if field.Required {
+			required = append(required, id)
+		}
+	}
+	return required
+}
+
+// TransitionField is a field on a transition screen.
+type TransitionField struct {
+	Key             string      `json:"key"`
+	Name            string      `json:"name"`
+	Required        bool        `json:"required"`
+	Schema          FieldSchema `json:"schema"`
+	HasDefaultValue bool        `json:"hasDefaultValue"`
+	Operations      []string    `json:"operations"`
+	// AllowedValues are left raw since their shape depends on the field.
+	AllowedValues []json.RawMessage `json:"allowedValues,omitempty"`
+}
+
+// FieldSchema describes a field's type.
+type FieldSchema struct {
+	Type   string `json:"type"`
+	Items  string `json:"items,omitempty"`
+	System string `json:"system,omitempty"`
+	Custom string `json:"custom,omitempty"`
+}
@@ -0,0 +1,115 @@
+package jiraModels
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// TimeFormat is how Jira writes timestamps, e.g. 2024-03-04T09:00:00.000+0000.
+const TimeFormat = "2006-01-02T15:04:05.000-0700"
+
+// Time is a timestamp in Jira's format. A missing or null value is the
+// zero time.
+type Time struct {
+	time.Time
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	parsed, err := time.Parse(TimeFormat, s)
+	if err != nil {
+		// Some endpoints use RFC 3339 instead
+		var rfcErr error
+		if parsed, rfcErr = time.Parse(time.RFC3339, s); rfcErr != nil {
+			return err
+		}
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Format(TimeFormat))
+}
+
+// RichText is a text field as Jira sends it: an Atlassian Document Format
+// document on Cloud, or a plain (wiki markup) string on Data Center.
+type RichText struct {
+	raw json.RawMessage
+}
+
+func (r *RichText) UnmarshalJSON(data []byte) error {
+	r.raw = append(r.raw[:0], data...)
+	return nil
+}
+
+func (r RichText) MarshalJSON() ([]byte, error) {
+	if len(r.raw) == 0 {
+		return []byte("null"), nil
+	}
+	return r.raw, nil
+}
+
+// Raw returns the field as Jira sent it.
+func (r RichText) Raw() json.RawMessage {
+	return r.raw
+}
+
+// Text returns the field as plain text, with paragraphs on separate lines.
+func (r RichText) Text() string {
+	if len(r.raw) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(r.raw, &s) == nil {
+		return s
+	}
+	var doc adfNode
+	if json.Unmarshal(r.raw, &doc) != nil {
+		return ""
+	}
+	var b strings.Builder
+	doc.writeText(&b)
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// adfNode is a node of an Atlassian Document Format document.
+type adfNode struct {
+	Type    string    `json:"type"`
+	Text    string    `json:"text"`
+	Content []adfNode `json:"content"`
+}
+
+func (n adfNode) writeText(b *strings.Builder) {
+	switch n.Type {
+	case "text":
+		b.WriteString(n.Text)
+	case "hardBreak":
+		b.WriteString("\n")
+	}
+	for _, child := range n.Content {
+		child.writeText(b)
+	}
+	switch n.Type {
+	case "paragraph", "heading", "codeBlock", "blockquote", "rule":
+		if !strings.HasSuffix(b.String(), "\n") {
+			b.WriteString("\n")
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package jiraModels
+
+// User is a Jira user. Cloud identifies users by AccountID; Data Center
+// by Name (the username) and Key.
+type User struct {
+	AccountID    string `json:"accountId,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Key          string `json:"key,omitempty"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+	Active       bool   `json:"active"`
+	TimeZone     string `json:"timeZone,omitempty"`
+}
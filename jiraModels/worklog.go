@@ -0,0 +1,24 @@
+package jiraModels
+
+// Worklog is time logged against an issue.
+type Worklog struct {
+	ID               string   `json:"id"`
+	IssueID          string   `json:"issueId"`
+	Author           *User    `json:"author"`
+	UpdateAuthor     *User    `json:"updateAuthor"`
+	Comment          RichText `json:"comment"`
+	Started          Time     `json:"started"`
+	Created          Time     `json:"created"`
+	Updated          Time     `json:"updated"`
+	TimeSpent        string   `json:"timeSpent"`
+	TimeSpentSeconds int      `json:"timeSpentSeconds"`
+}
+
+// Comment is a comment on an issue.
+type Comment struct {
+	ID      string   `json:"id"`
+	Author  *User    `json:"author"`
+	Body    RichText `json:"body"`
+	Created Time     `json:"created"`
+	Updated Time     `json:"updated"`
+}
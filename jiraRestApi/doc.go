@@ -0,0 +1,11 @@
+// Package jiraRestApi holds typed request and response code generated from
+// the bundled OpenAPI description, for the operations listed in
+// operations.txt. Each operation gets a Params struct and a function that
+// sends it through a *jiraApiFunctions.Client, so the retry, auth and Data
+// Center handling of the hand-written client apply here too.
+//
+// To add an operation, append its operationId to operations.txt and run
+// go generate ./jiraRestApi.
+package jiraRestApi
+
+//go:generate go run ../internal/openapi/gen -spec ../jiraRestApiDoc/OpenApi.json -operations operations.txt -package jiraRestApi -out jiraRestApiGenerated.go
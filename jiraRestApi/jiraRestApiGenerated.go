@@ -0,0 +1,1286 @@
+// Code generated by jiraTimeWidget/internal/openapi/gen from jiraRestApiDoc/OpenApi.json. DO NOT EDIT.
+
+package jiraRestApi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"jiraTimeWidget/jiraApiFunctions"
+	"net/url"
+	"strconv"
+)
+
+// GetCurrentUserParams holds the parameters of GetCurrentUser.
+type GetCurrentUserParams struct {
+	// Use expand to include additional information about user in the response.
+	Expand string
+}
+
+// GetCurrentUser calls GET /rest/api/3/myself.
+//
+// Returns details for the current user.
+func GetCurrentUser(ctx context.Context, c *jiraApiFunctions.Client, params GetCurrentUserParams) (*User, error) {
+	endpoint := "/rest/api/3/myself"
+	query := url.Values{}
+	if params.Expand != "" {
+		query.Set("expand", params.Expand)
+	}
+	response, err := c.CallValuesContext(ctx, "GET", endpoint, nil, query)
+	if err != nil {
+		return nil, err
+	}
+	var result User
+	if len(response) > 0 {
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("decoding getCurrentUser response: %w", err)
+		}
+	}
+	return &result, nil
+}
+
+// FindUsersParams holds the parameters of FindUsers.
+type FindUsersParams struct {
+	// A query string that is matched against user attributes ( `displayName`, and `emailAddress`) to find relevant users.
+	Query    string
+	Username string
+	// A query string that is matched exactly against a user `accountId`.
+	AccountId string
+	// The index of the first item to return in a page of filtered results (page offset).
+	StartAt int32
+	// The maximum number of items to return per page.
+	MaxResults int32
+	// A query string used to search properties.
+	Property string
+}
+
+// FindUsers calls GET /rest/api/3/user/search.
+//
+// Returns a list of active users that match the search string and property.
+func FindUsers(ctx context.Context, c *jiraApiFunctions.Client, params FindUsersParams) ([]User, error) {
+	endpoint := "/rest/api/3/user/search"
+	query := url.Values{}
+	if params.Query != "" {
+		query.Set("query", params.Query)
+	}
+	if params.Username != "" {
+		query.Set("username", params.Username)
+	}
+	if params.AccountId != "" {
+		query.Set("accountId", params.AccountId)
+	}
+	if params.StartAt != 0 {
+		query.Set("startAt", strconv.FormatInt(int64(params.StartAt), 10))
+	}
+	if params.MaxResults != 0 {
+		query.Set("maxResults", strconv.FormatInt(int64(params.MaxResults), 10))
+	}
+	if params.Property != "" {
+		query.Set("property", params.Property)
+	}
+	response, err := c.CallValuesContext(ctx, "GET", endpoint, nil, query)
+	if err != nil {
+		return nil, err
+	}
+	var result []User
+	if len(response) > 0 {
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("decoding findUsers response: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// GetIssueParams holds the parameters of GetIssue.
+type GetIssueParams struct {
+	// The ID or key of the issue.
+	IssueIdOrKey string
+	// A list of fields to return for the issue.
+	Fields []string
+	// Whether fields in `fields` are referenced by keys rather than IDs.
+	FieldsByKeys *bool
+	// Use expand to include additional information about the issues in the response.
+	Expand string
+	// A list of issue properties to return for the issue.
+	Properties []string
+	// Whether the project in which the issue is created is added to the user's **Recently viewed** project list, as shown under **Projects** in Jira.
+	UpdateHistory *bool
+	// Whether to fail the request quickly in case of an error while loading fields for an issue.
+	FailFast *bool
+}
+
+// GetIssue calls GET /rest/api/3/issue/{issueIdOrKey}.
+//
+// Returns the details for an issue.
+func GetIssue(ctx context.Context, c *jiraApiFunctions.Client, params GetIssueParams) (*IssueBean, error) {
+	endpoint := "/rest/api/3/issue/" + url.PathEscape(params.IssueIdOrKey)
+	query := url.Values{}
+	for _, v := range params.Fields {
+		query.Add("fields", v)
+	}
+	if params.FieldsByKeys != nil {
+		query.Set("fieldsByKeys", strconv.FormatBool(*params.FieldsByKeys))
+	}
+	if params.Expand != "" {
+		query.Set("expand", params.Expand)
+	}
+	for _, v := range params.Properties {
+		query.Add("properties", v)
+	}
+	if params.UpdateHistory != nil {
+		query.Set("updateHistory", strconv.FormatBool(*params.UpdateHistory))
+	}
+	if params.FailFast != nil {
+		query.Set("failFast", strconv.FormatBool(*params.FailFast))
+	}
+	response, err := c.CallValuesContext(ctx, "GET", endpoint, nil, query)
+	if err != nil {
+		return nil, err
+	}
+	var result IssueBean
+	if len(response) > 0 {
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("decoding getIssue response: %w", err)
+		}
+	}
+	return &result, nil
+}
+
+// SearchAndReconsileIssuesUsingJqlParams holds the parameters of SearchAndReconsileIssuesUsingJql.
+type SearchAndReconsileIssuesUsingJqlParams struct {
+	// A JQL expression.
+	Jql string
+	// The token for a page to fetch that is not the first page.
+	NextPageToken string
+	// The maximum number of items to return per page.
+	MaxResults int32
+	// A list of fields to return for each issue, use it to retrieve a subset of fields.
+	Fields []string
+	// Use expand to include additional information about issues in the response.
+	Expand string
+	// A list of up to 5 issue properties to include in the results.
+	Properties []string
+	// Reference fields by their key (rather than ID).
+	FieldsByKeys *bool
+	// Fail this request early if we can't retrieve all field data.
+	FailFast *bool
+	// Strong consistency issue ids to be reconciled with search results.
+	ReconcileIssues []int64
+}
+
+// SearchAndReconsileIssuesUsingJql calls GET /rest/api/3/search/jql.
+//
+// Searches for issues using JQL.
+func SearchAndReconsileIssuesUsingJql(ctx context.Context, c *jiraApiFunctions.Client, params SearchAndReconsileIssuesUsingJqlParams) (*SearchAndReconcileResults, error) {
+	endpoint := "/rest/api/3/search/jql"
+	query := url.Values{}
+	if params.Jql != "" {
+		query.Set("jql", params.Jql)
+	}
+	if params.NextPageToken != "" {
+		query.Set("nextPageToken", params.NextPageToken)
+	}
+	if params.MaxResults != 0 {
+		query.Set("maxResults", strconv.FormatInt(int64(params.MaxResults), 10))
+	}
+	for _, v := range params.Fields {
+		query.Add("fields", v)
+	}
+	if params.Expand != "" {
+		query.Set("expand", params.Expand)
+	}
+	for _, v := range params.Properties {
+		query.Add("properties", v)
+	}
+	if params.FieldsByKeys != nil {
+		query.Set("fieldsByKeys", strconv.FormatBool(*params.FieldsByKeys))
+	}
+	if params.FailFast != nil {
+		query.Set("failFast", strconv.FormatBool(*params.FailFast))
+	}
+	for _, v := range params.ReconcileIssues {
+		query.Add("reconcileIssues", strconv.FormatInt(int64(v), 10))
+	}
+	response, err := c.CallValuesContext(ctx, "GET", endpoint, nil, query)
+	if err != nil {
+		return nil, err
+	}
+	var result SearchAndReconcileResults
+	if len(response) > 0 {
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("decoding searchAndReconsileIssuesUsingJql response: %w", err)
+		}
+	}
+	return &result, nil
+}
+
+// GetTransitionsParams holds the parameters of GetTransitions.
+type GetTransitionsParams struct {
+	// The ID or key of the issue.
+	IssueIdOrKey string
+	// Use expand to include additional information about transitions in the response.
+	Expand string
+	// The ID of the transition.
+	TransitionId string
+	// Whether transitions with the condition *Hide From User Condition* are included in the response.
+	SkipRemoteOnlyCondition *bool
+	// Whether details of transitions that fail a condition are included in the response
+	IncludeUnavailableTransitions *bool
+	// Whether the transitions are sorted by ops-bar sequence value first then category order (Todo, In Progress, Done) or only by ops-bar sequence value.
+	SortByOpsBarAndStatus *bool
+}
+
+// GetTransitions calls GET /rest/api/3/issue/{issueIdOrKey}/transitions.
+//
+// Returns either all transitions or a transition that can be performed by the user on an issue, based on the issue's status.
+func GetTransitions(ctx context.Context, c *jiraApiFunctions.Client, params GetTransitionsParams) (*Transitions, error) {
+	endpoint := "/rest/api/3/issue/" + url.PathEscape(params.IssueIdOrKey) + "/transitions"
+	query := url.Values{}
+	if params.Expand != "" {
+		query.Set("expand", params.Expand)
+	}
+	if params.TransitionId != "" {
+		query.Set("transitionId", params.TransitionId)
+	}
+	if params.SkipRemoteOnlyCondition != nil {
+		query.Set("skipRemoteOnlyCondition", strconv.FormatBool(*params.SkipRemoteOnlyCondition))
+	}
+	if params.IncludeUnavailableTransitions != nil {
+		query.Set("includeUnavailableTransitions", strconv.FormatBool(*params.IncludeUnavailableTransitions))
+	}
+	if params.SortByOpsBarAndStatus != nil {
+		query.Set("sortByOpsBarAndStatus", strconv.FormatBool(*params.SortByOpsBarAndStatus))
+	}
+	response, err := c.CallValuesContext(ctx, "GET", endpoint, nil, query)
+	if err != nil {
+		return nil, err
+	}
+	var result Transitions
+	if len(response) > 0 {
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("decoding getTransitions response: %w", err)
+		}
+	}
+	return &result, nil
+}
+
+// DoTransitionParams holds the parameters of DoTransition.
+type DoTransitionParams struct {
+	// The ID or key of the issue.
+	IssueIdOrKey string
+	Body         *IssueUpdateDetails
+}
+
+// DoTransition calls POST /rest/api/3/issue/{issueIdOrKey}/transitions.
+//
+// Performs an issue transition and, if the transition has a screen, updates the fields from the transition screen.
+func DoTransition(ctx context.Context, c *jiraApiFunctions.Client, params DoTransitionParams) error {
+	endpoint := "/rest/api/3/issue/" + url.PathEscape(params.IssueIdOrKey) + "/transitions"
+	query := url.Values{}
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	_, err := c.CallValuesContext(ctx, "POST", endpoint, body, query)
+	return err
+}
+
+// GetIssueWorklogParams holds the parameters of GetIssueWorklog.
+type GetIssueWorklogParams struct {
+	// The ID or key of the issue.
+	IssueIdOrKey string
+	// The index of the first item to return in a page of results (page offset).
+	StartAt int64
+	// The maximum number of items to return per page.
+	MaxResults int32
+	// The worklog start date and time, as a UNIX timestamp in milliseconds, after which worklogs are returned.
+	StartedAfter int64
+	// The worklog start date and time, as a UNIX timestamp in milliseconds, before which worklogs are returned.
+	StartedBefore int64
+	// Use expand to include additional information about worklogs in the response.
+	Expand string
+}
+
+// GetIssueWorklog calls GET /rest/api/3/issue/{issueIdOrKey}/worklog.
+//
+// Returns worklogs for an issue (ordered by created time), starting from the oldest worklog or from the worklog started on or after a date and time.
+func GetIssueWorklog(ctx context.Context, c *jiraApiFunctions.Client, params GetIssueWorklogParams) (*PageOfWorklogs, error) {
+	endpoint := "/rest/api/3/issue/" + url.PathEscape(params.IssueIdOrKey) + "/worklog"
+	query := url.Values{}
+	if params.StartAt != 0 {
+		query.Set("startAt", strconv.FormatInt(int64(params.StartAt), 10))
+	}
+	if params.MaxResults != 0 {
+		query.Set("maxResults", strconv.FormatInt(int64(params.MaxResults), 10))
+	}
+	if params.StartedAfter != 0 {
+		query.Set("startedAfter", strconv.FormatInt(int64(params.StartedAfter), 10))
+	}
+	if params.StartedBefore != 0 {
+		query.Set("startedBefore", strconv.FormatInt(int64(params.StartedBefore), 10))
+	}
+	if params.Expand != "" {
+		query.Set("expand", params.Expand)
+	}
+	response, err := c.CallValuesContext(ctx, "GET", endpoint, nil, query)
+	if err != nil {
+		return nil, err
+	}
+	var result PageOfWorklogs
+	if len(response) > 0 {
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("decoding getIssueWorklog response: %w", err)
+		}
+	}
+	return &result, nil
+}
+
+// AddWorklogParams holds the parameters of AddWorklog.
+type AddWorklogParams struct {
+	// The ID or key the issue.
+	IssueIdOrKey string
+	// Whether users watching the issue are notified by email.
+	NotifyUsers *bool
+	// Defines how to update the issue's time estimate, the options are:
+	AdjustEstimate string
+	// The value to set as the issue's remaining time estimate, as days (\#d), hours (\#h), or minutes (\#m or \#).
+	NewEstimate string
+	// The amount to reduce the issue's remaining estimate by, as days (\#d), hours (\#h), or minutes (\#m).
+	ReduceBy string
+	// Use expand to include additional information about work logs in the response.
+	Expand string
+	// Whether the worklog entry should be added to the issue even if the issue is not editable, because jira.issue.editable set to false or missing.
+	OverrideEditableFlag *bool
+	Body                 *Worklog
+}
+
+// AddWorklog calls POST /rest/api/3/issue/{issueIdOrKey}/worklog.
+//
+// Adds a worklog to an issue.
+func AddWorklog(ctx context.Context, c *jiraApiFunctions.Client, params AddWorklogParams) (*Worklog, error) {
+	endpoint := "/rest/api/3/issue/" + url.PathEscape(params.IssueIdOrKey) + "/worklog"
+	query := url.Values{}
+	if params.NotifyUsers != nil {
+		query.Set("notifyUsers", strconv.FormatBool(*params.NotifyUsers))
+	}
+	if params.AdjustEstimate != "" {
+		query.Set("adjustEstimate", params.AdjustEstimate)
+	}
+	if params.NewEstimate != "" {
+		query.Set("newEstimate", params.NewEstimate)
+	}
+	if params.ReduceBy != "" {
+		query.Set("reduceBy", params.ReduceBy)
+	}
+	if params.Expand != "" {
+		query.Set("expand", params.Expand)
+	}
+	if params.OverrideEditableFlag != nil {
+		query.Set("overrideEditableFlag", strconv.FormatBool(*params.OverrideEditableFlag))
+	}
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	response, err := c.CallValuesContext(ctx, "POST", endpoint, body, query)
+	if err != nil {
+		return nil, err
+	}
+	var result Worklog
+	if len(response) > 0 {
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("decoding addWorklog response: %w", err)
+		}
+	}
+	return &result, nil
+}
+
+// UpdateWorklogParams holds the parameters of UpdateWorklog.
+type UpdateWorklogParams struct {
+	// The ID or key the issue.
+	IssueIdOrKey string
+	// The ID of the worklog.
+	Id string
+	// Whether users watching the issue are notified by email.
+	NotifyUsers *bool
+	// Defines how to update the issue's time estimate, the options are:
+	AdjustEstimate string
+	// The value to set as the issue's remaining time estimate, as days (\#d), hours (\#h), or minutes (\#m or \#).
+	NewEstimate string
+	// Use expand to include additional information about worklogs in the response.
+	Expand string
+	// Whether the worklog should be added to the issue even if the issue is not editable.
+	OverrideEditableFlag *bool
+	Body                 *Worklog
+}
+
+// UpdateWorklog calls PUT /rest/api/3/issue/{issueIdOrKey}/worklog/{id}.
+//
+// Updates a worklog.
+func UpdateWorklog(ctx context.Context, c *jiraApiFunctions.Client, params UpdateWorklogParams) (*Worklog, error) {
+	endpoint := "/rest/api/3/issue/" + url.PathEscape(params.IssueIdOrKey) + "/worklog/" + url.PathEscape(params.Id)
+	query := url.Values{}
+	if params.NotifyUsers != nil {
+		query.Set("notifyUsers", strconv.FormatBool(*params.NotifyUsers))
+	}
+	if params.AdjustEstimate != "" {
+		query.Set("adjustEstimate", params.AdjustEstimate)
+	}
+	if params.NewEstimate != "" {
+		query.Set("newEstimate", params.NewEstimate)
+	}
+	if params.Expand != "" {
+		query.Set("expand", params.Expand)
+	}
+	if params.OverrideEditableFlag != nil {
+		query.Set("overrideEditableFlag", strconv.FormatBool(*params.OverrideEditableFlag))
+	}
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	response, err := c.CallValuesContext(ctx, "PUT", endpoint, body, query)
+	if err != nil {
+		return nil, err
+	}
+	var result Worklog
+	if len(response) > 0 {
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("decoding updateWorklog response: %w", err)
+		}
+	}
+	return &result, nil
+}
+
+// DeleteWorklogParams holds the parameters of DeleteWorklog.
+type DeleteWorklogParams struct {
+	// The ID or key of the issue.
+	IssueIdOrKey string
+	// The ID of the worklog.
+	Id string
+	// Whether users watching the issue are notified by email.
+	NotifyUsers *bool
+	// Defines how to update the issue's time estimate, the options are:
+	AdjustEstimate string
+	// The value to set as the issue's remaining time estimate, as days (\#d), hours (\#h), or minutes (\#m or \#).
+	NewEstimate string
+	// The amount to increase the issue's remaining estimate by, as days (\#d), hours (\#h), or minutes (\#m or \#).
+	IncreaseBy string
+	// Whether the work log entry should be added to the issue even if the issue is not editable, because jira.issue.editable set to false or missing.
+	OverrideEditableFlag *bool
+}
+
+// DeleteWorklog calls DELETE /rest/api/3/issue/{issueIdOrKey}/worklog/{id}.
+//
+// Deletes a worklog from an issue.
+func DeleteWorklog(ctx context.Context, c *jiraApiFunctions.Client, params DeleteWorklogParams) error {
+	endpoint := "/rest/api/3/issue/" + url.PathEscape(params.IssueIdOrKey) + "/worklog/" + url.PathEscape(params.Id)
+	query := url.Values{}
+	if params.NotifyUsers != nil {
+		query.Set("notifyUsers", strconv.FormatBool(*params.NotifyUsers))
+	}
+	if params.AdjustEstimate != "" {
+		query.Set("adjustEstimate", params.AdjustEstimate)
+	}
+	if params.NewEstimate != "" {
+		query.Set("newEstimate", params.NewEstimate)
+	}
+	if params.IncreaseBy != "" {
+		query.Set("increaseBy", params.IncreaseBy)
+	}
+	if params.OverrideEditableFlag != nil {
+		query.Set("overrideEditableFlag", strconv.FormatBool(*params.OverrideEditableFlag))
+	}
+	_, err := c.CallValuesContext(ctx, "DELETE", endpoint, nil, query)
+	return err
+}
+
+// GetCommentsParams holds the parameters of GetComments.
+type GetCommentsParams struct {
+	// The ID or key of the issue.
+	IssueIdOrKey string
+	// The index of the first item to return in a page of results (page offset).
+	StartAt int64
+	// The maximum number of items to return per page.
+	MaxResults int32
+	// Order the results by a field.
+	OrderBy string
+	// Use expand to include additional information about comments in the response.
+	Expand string
+}
+
+// GetComments calls GET /rest/api/3/issue/{issueIdOrKey}/comment.
+//
+// Returns all comments for an issue.
+func GetComments(ctx context.Context, c *jiraApiFunctions.Client, params GetCommentsParams) (*PageOfComments, error) {
+	endpoint := "/rest/api/3/issue/" + url.PathEscape(params.IssueIdOrKey) + "/comment"
+	query := url.Values{}
+	if params.StartAt != 0 {
+		query.Set("startAt", strconv.FormatInt(int64(params.StartAt), 10))
+	}
+	if params.MaxResults != 0 {
+		query.Set("maxResults", strconv.FormatInt(int64(params.MaxResults), 10))
+	}
+	if params.OrderBy != "" {
+		query.Set("orderBy", params.OrderBy)
+	}
+	if params.Expand != "" {
+		query.Set("expand", params.Expand)
+	}
+	response, err := c.CallValuesContext(ctx, "GET", endpoint, nil, query)
+	if err != nil {
+		return nil, err
+	}
+	var result PageOfComments
+	if len(response) > 0 {
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("decoding getComments response: %w", err)
+		}
+	}
+	return &result, nil
+}
+
+// AddCommentParams holds the parameters of AddComment.
+type AddCommentParams struct {
+	// The ID or key of the issue.
+	IssueIdOrKey string
+	// Use expand to include additional information about comments in the response.
+	Expand string
+	Body   *Comment
+}
+
+// AddComment calls POST /rest/api/3/issue/{issueIdOrKey}/comment.
+//
+// Adds a comment to an issue.
+func AddComment(ctx context.Context, c *jiraApiFunctions.Client, params AddCommentParams) (*Comment, error) {
+	endpoint := "/rest/api/3/issue/" + url.PathEscape(params.IssueIdOrKey) + "/comment"
+	query := url.Values{}
+	if params.Expand != "" {
+		query.Set("expand", params.Expand)
+	}
+	var body interface{}
+	if params.Body != nil {
+		body = params.Body
+	}
+	response, err := c.CallValuesContext(ctx, "POST", endpoint, body, query)
+	if err != nil {
+		return nil, err
+	}
+	var result Comment
+	if len(response) > 0 {
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("decoding addComment response: %w", err)
+		}
+	}
+	return &result, nil
+}
+
+// GetServerInfoParams holds the parameters of GetServerInfo.
+type GetServerInfoParams struct {
+}
+
+// GetServerInfo calls GET /rest/api/3/serverInfo.
+//
+// Returns information about the Jira instance.
+func GetServerInfo(ctx context.Context, c *jiraApiFunctions.Client, params GetServerInfoParams) (*ServerInformation, error) {
+	endpoint := "/rest/api/3/serverInfo"
+	query := url.Values{}
+	response, err := c.CallValuesContext(ctx, "GET", endpoint, nil, query)
+	if err != nil {
+		return nil, err
+	}
+	var result ServerInformation
+	if len(response) > 0 {
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("decoding getServerInfo response: %w", err)
+		}
+	}
+	return &result, nil
+}
+
+// ApplicationRole is the ApplicationRole schema.
+//
+// Details of an application role.
+type ApplicationRole struct {
+	// The groups that are granted default access for this application role.
+	DefaultGroups []string `json:"defaultGroups,omitempty"`
+	// The groups that are granted default access for this application role.
+	DefaultGroupsDetails []GroupName `json:"defaultGroupsDetails,omitempty"`
+	// Deprecated.
+	Defined *bool `json:"defined,omitempty"`
+	// The groups associated with the application role.
+	GroupDetails []GroupName `json:"groupDetails,omitempty"`
+	// The groups associated with the application role.
+	Groups            []string `json:"groups,omitempty"`
+	HasUnlimitedSeats *bool    `json:"hasUnlimitedSeats,omitempty"`
+	// The key of the application role.
+	Key string `json:"key,omitempty"`
+	// The display name of the application role.
+	Name string `json:"name,omitempty"`
+	// The maximum count of users on your license.
+	NumberOfSeats int32 `json:"numberOfSeats,omitempty"`
+	// Indicates if the application role belongs to Jira platform (`jira-core`).
+	Platform *bool `json:"platform,omitempty"`
+	// The count of users remaining on your license.
+	RemainingSeats int32 `json:"remainingSeats,omitempty"`
+	// Determines whether this application role should be selected by default on user creation.
+	SelectedByDefault *bool `json:"selectedByDefault,omitempty"`
+	// The number of users counting against your license.
+	UserCount int32 `json:"userCount,omitempty"`
+	// The type of users being counted against your license.
+	UserCountDescription string `json:"userCountDescription,omitempty"`
+}
+
+// AvatarUrlsBean is the AvatarUrlsBean schema.
+type AvatarUrlsBean struct {
+	// The URL of the item's 16x16 pixel avatar.
+	X16x16 string `json:"16x16,omitempty"`
+	// The URL of the item's 24x24 pixel avatar.
+	X24x24 string `json:"24x24,omitempty"`
+	// The URL of the item's 32x32 pixel avatar.
+	X32x32 string `json:"32x32,omitempty"`
+	// The URL of the item's 48x48 pixel avatar.
+	X48x48 string `json:"48x48,omitempty"`
+}
+
+// ChangeDetails is the ChangeDetails schema.
+//
+// A change item.
+type ChangeDetails struct {
+	// The name of the field changed.
+	Field string `json:"field,omitempty"`
+	// The ID of the field changed.
+	FieldId string `json:"fieldId,omitempty"`
+	// The type of the field changed.
+	Fieldtype string `json:"fieldtype,omitempty"`
+	// The details of the original value.
+	From string `json:"from,omitempty"`
+	// The details of the original value as a string.
+	FromString string `json:"fromString,omitempty"`
+	// The details of the new value.
+	To string `json:"to,omitempty"`
+	// The details of the new value as a string.
+	ToString string `json:"toString,omitempty"`
+}
+
+// Changelog is the Changelog schema.
+//
+// A log of changes made to issue fields.
+type Changelog struct {
+	// The user who made the change.
+	Author *UserDetails `json:"author,omitempty"`
+	// The date on which the change took place.
+	Created string `json:"created,omitempty"`
+	// The history metadata associated with the changed.
+	HistoryMetadata *HistoryMetadata `json:"historyMetadata,omitempty"`
+	// The ID of the changelog.
+	Id string `json:"id,omitempty"`
+	// The list of items changed.
+	Items []ChangeDetails `json:"items,omitempty"`
+}
+
+// Comment is the Comment schema.
+//
+// A comment.
+type Comment struct {
+	// The ID of the user who created the comment.
+	Author *UserDetails `json:"author,omitempty"`
+	// The comment text in Atlassian Document Format.
+	Body json.RawMessage `json:"body,omitempty"`
+	// The date and time at which the comment was created.
+	Created string `json:"created,omitempty"`
+	// The ID of the comment.
+	Id string `json:"id,omitempty"`
+	// Whether the comment was added from an email sent by a person who is not part of the issue.
+	JsdAuthorCanSeeRequest *bool `json:"jsdAuthorCanSeeRequest,omitempty"`
+	// Whether the comment is visible in Jira Service Desk.
+	JsdPublic *bool `json:"jsdPublic,omitempty"`
+	// A list of comment properties.
+	Properties []EntityProperty `json:"properties,omitempty"`
+	// The rendered version of the comment.
+	RenderedBody string `json:"renderedBody,omitempty"`
+	// The URL of the comment.
+	Self string `json:"self,omitempty"`
+	// The ID of the user who updated the comment last.
+	UpdateAuthor *UserDetails `json:"updateAuthor,omitempty"`
+	// The date and time at which the comment was updated last.
+	Updated string `json:"updated,omitempty"`
+	// The group or role to which this comment is visible.
+	Visibility *Visibility `json:"visibility,omitempty"`
+}
+
+// EntityProperty is the EntityProperty schema.
+//
+// An entity property, for more information see Entity properties.
+type EntityProperty struct {
+	// The key of the property.
+	Key string `json:"key,omitempty"`
+	// The value of the property.
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// FieldMetadata is the FieldMetadata schema.
+//
+// The metadata describing an issue field.
+type FieldMetadata struct {
+	// The list of values allowed in the field.
+	AllowedValues []json.RawMessage `json:"allowedValues,omitempty"`
+	// The URL that can be used to automatically complete the field.
+	AutoCompleteUrl string `json:"autoCompleteUrl,omitempty"`
+	// The configuration properties.
+	Configuration map[string]interface{} `json:"configuration,omitempty"`
+	// The default value of the field.
+	DefaultValue json.RawMessage `json:"defaultValue,omitempty"`
+	// Whether the field has a default value.
+	HasDefaultValue *bool `json:"hasDefaultValue,omitempty"`
+	// The key of the field.
+	Key string `json:"key"`
+	// The name of the field.
+	Name string `json:"name"`
+	// The list of operations that can be performed on the field.
+	Operations []string `json:"operations"`
+	// Whether the field is required.
+	Required bool `json:"required"`
+	// The data type of the field.
+	Schema *JsonTypeBean `json:"schema"`
+}
+
+// FieldUpdateOperation is the FieldUpdateOperation schema.
+//
+// Details of an operation to perform on a field.
+type FieldUpdateOperation struct {
+	// The value to add to the field.
+	Add json.RawMessage `json:"add,omitempty"`
+	// The field value to copy from another issue.
+	Copy json.RawMessage `json:"copy,omitempty"`
+	// The value to edit in the field.
+	Edit json.RawMessage `json:"edit,omitempty"`
+	// The value to removed from the field.
+	Remove json.RawMessage `json:"remove,omitempty"`
+	// The value to set in the field.
+	Set json.RawMessage `json:"set,omitempty"`
+}
+
+// GroupName is the GroupName schema.
+//
+// Details about a group.
+type GroupName struct {
+	// The ID of the group, which uniquely identifies the group across all Atlassian products.
+	GroupId string `json:"groupId,omitempty"`
+	// The name of group.
+	Name string `json:"name,omitempty"`
+	// The URL for these group details.
+	Self string `json:"self,omitempty"`
+}
+
+// HealthCheckResult is the HealthCheckResult schema.
+//
+// Jira instance health check results.
+type HealthCheckResult struct {
+	// The description of the Jira health check item.
+	Description string `json:"description,omitempty"`
+	// The name of the Jira health check item.
+	Name string `json:"name,omitempty"`
+	// Whether the Jira health check item passed or failed.
+	Passed *bool `json:"passed,omitempty"`
+}
+
+// HistoryMetadata is the HistoryMetadata schema.
+//
+// Details of issue history metadata.
+type HistoryMetadata struct {
+	// The activity described in the history record.
+	ActivityDescription string `json:"activityDescription,omitempty"`
+	// The key of the activity described in the history record.
+	ActivityDescriptionKey string `json:"activityDescriptionKey,omitempty"`
+	// Details of the user whose action created the history record.
+	Actor *HistoryMetadataParticipant `json:"actor,omitempty"`
+	// Details of the cause that triggered the creation the history record.
+	Cause *HistoryMetadataParticipant `json:"cause,omitempty"`
+	// The description of the history record.
+	Description string `json:"description,omitempty"`
+	// The description key of the history record.
+	DescriptionKey string `json:"descriptionKey,omitempty"`
+	// The description of the email address associated the history record.
+	EmailDescription string `json:"emailDescription,omitempty"`
+	// The description key of the email address associated the history record.
+	EmailDescriptionKey string `json:"emailDescriptionKey,omitempty"`
+	// Additional arbitrary information about the history record.
+	ExtraData map[string]string `json:"extraData,omitempty"`
+	// Details of the system that generated the history record.
+	Generator *HistoryMetadataParticipant `json:"generator,omitempty"`
+	// The type of the history record.
+	Type string `json:"type,omitempty"`
+}
+
+// HistoryMetadataParticipant is the HistoryMetadataParticipant schema.
+//
+// Details of user or system associated with a issue history metadata item.
+type HistoryMetadataParticipant struct {
+	// The URL to an avatar for the user or system associated with a history record.
+	AvatarUrl string `json:"avatarUrl,omitempty"`
+	// The display name of the user or system associated with a history record.
+	DisplayName string `json:"displayName,omitempty"`
+	// The key of the display name of the user or system associated with a history record.
+	DisplayNameKey string `json:"displayNameKey,omitempty"`
+	// The ID of the user or system associated with a history record.
+	Id string `json:"id,omitempty"`
+	// The type of the user or system associated with a history record.
+	Type string `json:"type,omitempty"`
+	// The URL of the user or system associated with a history record.
+	Url string `json:"url,omitempty"`
+}
+
+// IncludedFields is the IncludedFields schema.
+type IncludedFields struct {
+	ActuallyIncluded []string `json:"actuallyIncluded,omitempty"`
+	Excluded         []string `json:"excluded,omitempty"`
+	Included         []string `json:"included,omitempty"`
+}
+
+// IssueBean is the IssueBean schema.
+//
+// Details about an issue.
+type IssueBean struct {
+	// Details of changelogs associated with the issue.
+	Changelog *PageOfChangelogs `json:"changelog,omitempty"`
+	// The metadata for the fields on the issue that can be amended.
+	Editmeta *IssueUpdateMetadata `json:"editmeta,omitempty"`
+	// Expand options that include additional issue details in the response.
+	Expand          string                 `json:"expand,omitempty"`
+	Fields          map[string]interface{} `json:"fields,omitempty"`
+	FieldsToInclude *IncludedFields        `json:"fieldsToInclude,omitempty"`
+	// The ID of the issue.
+	Id string `json:"id,omitempty"`
+	// The key of the issue.
+	Key string `json:"key,omitempty"`
+	// The ID and name of each field present on the issue.
+	Names map[string]string `json:"names,omitempty"`
+	// The operations that can be performed on the issue.
+	Operations *Operations `json:"operations,omitempty"`
+	// Details of the issue properties identified in the request.
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	// The rendered value of each field present on the issue.
+	RenderedFields map[string]interface{} `json:"renderedFields,omitempty"`
+	// The schema describing each field present on the issue.
+	Schema map[string]JsonTypeBean `json:"schema,omitempty"`
+	// The URL of the issue details.
+	Self string `json:"self,omitempty"`
+	// The transitions that can be performed on the issue.
+	Transitions []IssueTransition `json:"transitions,omitempty"`
+	// The versions of each field on the issue.
+	VersionedRepresentations map[string]map[string]interface{} `json:"versionedRepresentations,omitempty"`
+}
+
+// IssueTransition is the IssueTransition schema.
+//
+// Details of an issue transition.
+type IssueTransition struct {
+	// Expand options that include additional transition details in the response.
+	Expand string `json:"expand,omitempty"`
+	// Details of the fields associated with the issue transition screen.
+	Fields map[string]FieldMetadata `json:"fields,omitempty"`
+	// Whether there is a screen associated with the issue transition.
+	HasScreen *bool `json:"hasScreen,omitempty"`
+	// The ID of the issue transition.
+	Id string `json:"id,omitempty"`
+	// Whether the transition is available to be performed.
+	IsAvailable *bool `json:"isAvailable,omitempty"`
+	// Whether the issue has to meet criteria before the issue transition is applied.
+	IsConditional *bool `json:"isConditional,omitempty"`
+	// Whether the issue transition is global, that is, the transition is applied to issues regardless of their status.
+	IsGlobal *bool `json:"isGlobal,omitempty"`
+	// Whether this is the initial issue transition for the workflow.
+	IsInitial *bool `json:"isInitial,omitempty"`
+	Looped    *bool `json:"looped,omitempty"`
+	// The name of the issue transition.
+	Name string `json:"name,omitempty"`
+	// Details of the issue status after the transition.
+	To *StatusDetails `json:"to,omitempty"`
+}
+
+// IssueUpdateDetails is the IssueUpdateDetails schema.
+//
+// Details of an issue update request.
+type IssueUpdateDetails struct {
+	// List of issue screen fields to update, specifying the sub-field to update and its value for each field.
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// Additional issue history details.
+	HistoryMetadata *HistoryMetadata `json:"historyMetadata,omitempty"`
+	// Details of issue properties to be add or update.
+	Properties []EntityProperty `json:"properties,omitempty"`
+	// Details of a transition.
+	Transition *IssueTransition `json:"transition,omitempty"`
+	// A Map containing the field field name and a list of operations to perform on the issue screen field.
+	Update map[string][]FieldUpdateOperation `json:"update,omitempty"`
+}
+
+// IssueUpdateMetadata is the IssueUpdateMetadata schema.
+//
+// A list of editable field details.
+type IssueUpdateMetadata struct {
+	Fields map[string]FieldMetadata `json:"fields,omitempty"`
+}
+
+// JsonTypeBean is the JsonTypeBean schema.
+//
+// The schema of a field.
+type JsonTypeBean struct {
+	// If the field is a custom field, the configuration of the field.
+	Configuration map[string]interface{} `json:"configuration,omitempty"`
+	// If the field is a custom field, the URI of the field.
+	Custom string `json:"custom,omitempty"`
+	// If the field is a custom field, the custom ID of the field.
+	CustomId int64 `json:"customId,omitempty"`
+	// When the data type is an array, the name of the field items within the array.
+	Items string `json:"items,omitempty"`
+	// If the field is a system field, the name of the field.
+	System string `json:"system,omitempty"`
+	// The data type of the field.
+	Type string `json:"type"`
+}
+
+// LinkGroup is the LinkGroup schema.
+//
+// Details a link group, which defines issue operations.
+type LinkGroup struct {
+	Groups     []LinkGroup  `json:"groups,omitempty"`
+	Header     *SimpleLink  `json:"header,omitempty"`
+	Id         string       `json:"id,omitempty"`
+	Links      []SimpleLink `json:"links,omitempty"`
+	StyleClass string       `json:"styleClass,omitempty"`
+	Weight     int32        `json:"weight,omitempty"`
+}
+
+// ListWrapperCallbackApplicationRole is the ListWrapperCallbackApplicationRole schema.
+type ListWrapperCallbackApplicationRole map[string]interface{}
+
+// ListWrapperCallbackGroupName is the ListWrapperCallbackGroupName schema.
+type ListWrapperCallbackGroupName map[string]interface{}
+
+// Operations is the Operations schema.
+//
+// Details of the operations that can be performed on the issue.
+type Operations struct {
+	// Details of the link groups defining issue operations.
+	LinkGroups []LinkGroup `json:"linkGroups,omitempty"`
+}
+
+// PageOfChangelogs is the PageOfChangelogs schema.
+//
+// A page of changelogs.
+type PageOfChangelogs struct {
+	// The list of changelogs.
+	Histories []Changelog `json:"histories,omitempty"`
+	// The maximum number of results that could be on the page.
+	MaxResults int32 `json:"maxResults,omitempty"`
+	// The index of the first item returned on the page.
+	StartAt int32 `json:"startAt,omitempty"`
+	// The number of results on the page.
+	Total int32 `json:"total,omitempty"`
+}
+
+// PageOfComments is the PageOfComments schema.
+//
+// A page of comments.
+type PageOfComments struct {
+	// The list of comments.
+	Comments []Comment `json:"comments,omitempty"`
+	// The maximum number of items that could be returned.
+	MaxResults int32 `json:"maxResults,omitempty"`
+	// The index of the first item returned.
+	StartAt int64 `json:"startAt,omitempty"`
+	// The number of items returned.
+	Total int64 `json:"total,omitempty"`
+}
+
+// PageOfWorklogs is the PageOfWorklogs schema.
+//
+// Paginated list of worklog details
+type PageOfWorklogs struct {
+	// The maximum number of results that could be on the page.
+	MaxResults int32 `json:"maxResults,omitempty"`
+	// The index of the first item returned on the page.
+	StartAt int32 `json:"startAt,omitempty"`
+	// The number of results on the page.
+	Total int32 `json:"total,omitempty"`
+	// List of worklogs.
+	Worklogs []Worklog `json:"worklogs,omitempty"`
+}
+
+// ProjectDetails is the ProjectDetails schema.
+//
+// Details about a project.
+type ProjectDetails struct {
+	// The URLs of the project's avatars.
+	AvatarUrls *AvatarUrlsBean `json:"avatarUrls,omitempty"`
+	// The ID of the project.
+	Id string `json:"id,omitempty"`
+	// The key of the project.
+	Key string `json:"key,omitempty"`
+	// The name of the project.
+	Name string `json:"name,omitempty"`
+	// The category the project belongs to.
+	ProjectCategory *UpdatedProjectCategory `json:"projectCategory,omitempty"`
+	// The project type of the project.
+	ProjectTypeKey string `json:"projectTypeKey,omitempty"`
+	// The URL of the project details.
+	Self string `json:"self,omitempty"`
+	// Whether or not the project is simplified.
+	Simplified *bool `json:"simplified,omitempty"`
+}
+
+// Scope is the Scope schema.
+//
+// The projects the item is associated with.
+type Scope struct {
+	// The project the item has scope in.
+	Project *ProjectDetails `json:"project,omitempty"`
+	// The type of scope.
+	Type string `json:"type,omitempty"`
+}
+
+// SearchAndReconcileResults is the SearchAndReconcileResults schema.
+//
+// The result of a JQL search with issues reconsilation.
+type SearchAndReconcileResults struct {
+	// Indicates whether this is the last page of the paginated response.
+	IsLast *bool `json:"isLast,omitempty"`
+	// The list of issues found by the search or reconsiliation.
+	Issues []IssueBean `json:"issues,omitempty"`
+	// The ID and name of each field in the search results.
+	Names map[string]string `json:"names,omitempty"`
+	// Continuation token to fetch the next page.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+	// The schema describing the field types in the search results.
+	Schema map[string]JsonTypeBean `json:"schema,omitempty"`
+}
+
+// ServerInformation is the ServerInformation schema.
+//
+// Details about the Jira instance.
+type ServerInformation struct {
+	// The base URL of the Jira instance.
+	BaseUrl string `json:"baseUrl,omitempty"`
+	// The timestamp when the Jira version was built.
+	BuildDate string `json:"buildDate,omitempty"`
+	// The build number of the Jira version.
+	BuildNumber int32 `json:"buildNumber,omitempty"`
+	// The type of server deployment.
+	DeploymentType string `json:"deploymentType,omitempty"`
+	// The display URL of the Jira instance.
+	DisplayUrl string `json:"displayUrl,omitempty"`
+	// The display URL of Confluence.
+	DisplayUrlConfluence string `json:"displayUrlConfluence,omitempty"`
+	// The display URL of the Servicedesk Help Center.
+	DisplayUrlServicedeskHelpCenter string `json:"displayUrlServicedeskHelpCenter,omitempty"`
+	// Jira instance health check results.
+	HealthChecks []HealthCheckResult `json:"healthChecks,omitempty"`
+	// The unique identifier of the Jira version.
+	ScmInfo string `json:"scmInfo,omitempty"`
+	// The time in Jira when this request was responded to.
+	ServerTime string `json:"serverTime,omitempty"`
+	// The default timezone of the Jira server.
+	ServerTimeZone string `json:"serverTimeZone,omitempty"`
+	// The name of the Jira instance.
+	ServerTitle string `json:"serverTitle,omitempty"`
+	// The version of Jira.
+	Version string `json:"version,omitempty"`
+	// The major, minor, and revision version numbers of the Jira version.
+	VersionNumbers []int32 `json:"versionNumbers,omitempty"`
+}
+
+// SimpleLink is the SimpleLink schema.
+//
+// Details about the operations available in this version.
+type SimpleLink struct {
+	Href       string `json:"href,omitempty"`
+	IconClass  string `json:"iconClass,omitempty"`
+	Id         string `json:"id,omitempty"`
+	Label      string `json:"label,omitempty"`
+	StyleClass string `json:"styleClass,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Weight     int32  `json:"weight,omitempty"`
+}
+
+// SimpleListWrapperApplicationRole is the SimpleListWrapperApplicationRole schema.
+type SimpleListWrapperApplicationRole struct {
+	Callback       *ListWrapperCallbackApplicationRole `json:"callback,omitempty"`
+	Items          []ApplicationRole                   `json:"items,omitempty"`
+	MaxResults     int32                               `json:"max-results,omitempty"`
+	PagingCallback *ListWrapperCallbackApplicationRole `json:"pagingCallback,omitempty"`
+	Size           int32                               `json:"size,omitempty"`
+}
+
+// SimpleListWrapperGroupName is the SimpleListWrapperGroupName schema.
+type SimpleListWrapperGroupName struct {
+	Callback       *ListWrapperCallbackGroupName `json:"callback,omitempty"`
+	Items          []GroupName                   `json:"items,omitempty"`
+	MaxResults     int32                         `json:"max-results,omitempty"`
+	PagingCallback *ListWrapperCallbackGroupName `json:"pagingCallback,omitempty"`
+	Size           int32                         `json:"size,omitempty"`
+}
+
+// StatusCategory is the StatusCategory schema.
+//
+// A status category.
+type StatusCategory struct {
+	// The name of the color used to represent the status category.
+	ColorName string `json:"colorName,omitempty"`
+	// The ID of the status category.
+	Id int64 `json:"id,omitempty"`
+	// The key of the status category.
+	Key string `json:"key,omitempty"`
+	// The name of the status category.
+	Name string `json:"name,omitempty"`
+	// The URL of the status category.
+	Self string `json:"self,omitempty"`
+}
+
+// StatusDetails is the StatusDetails schema.
+//
+// A status.
+type StatusDetails struct {
+	// The description of the status.
+	Description string `json:"description,omitempty"`
+	// The URL of the icon used to represent the status.
+	IconUrl string `json:"iconUrl,omitempty"`
+	// The ID of the status.
+	Id string `json:"id,omitempty"`
+	// The name of the status.
+	Name string `json:"name,omitempty"`
+	// The scope of the field.
+	Scope *Scope `json:"scope,omitempty"`
+	// The URL of the status.
+	Self string `json:"self,omitempty"`
+	// The category assigned to the status.
+	StatusCategory *StatusCategory `json:"statusCategory,omitempty"`
+}
+
+// Transitions is the Transitions schema.
+//
+// List of issue transitions.
+type Transitions struct {
+	// Expand options that include additional transitions details in the response.
+	Expand string `json:"expand,omitempty"`
+	// List of issue transitions.
+	Transitions []IssueTransition `json:"transitions,omitempty"`
+}
+
+// UpdatedProjectCategory is the UpdatedProjectCategory schema.
+//
+// A project category.
+type UpdatedProjectCategory struct {
+	// The name of the project category.
+	Description string `json:"description,omitempty"`
+	// The ID of the project category.
+	Id string `json:"id,omitempty"`
+	// The description of the project category.
+	Name string `json:"name,omitempty"`
+	// The URL of the project category.
+	Self string `json:"self,omitempty"`
+}
+
+// User is the User schema.
+//
+// A user with details as permitted by the user's Atlassian Account privacy settings.
+type User struct {
+	// The account ID of the user, which uniquely identifies the user across all Atlassian products.
+	AccountId string `json:"accountId,omitempty"`
+	// The user account type.
+	AccountType string `json:"accountType,omitempty"`
+	// Whether the user is active.
+	Active *bool `json:"active,omitempty"`
+	// The application roles the user is assigned to.
+	ApplicationRoles *SimpleListWrapperApplicationRole `json:"applicationRoles,omitempty"`
+	// The avatars of the user.
+	AvatarUrls *AvatarUrlsBean `json:"avatarUrls,omitempty"`
+	// The display name of the user.
+	DisplayName string `json:"displayName,omitempty"`
+	// The email address of the user.
+	EmailAddress string `json:"emailAddress,omitempty"`
+	// Expand options that include additional user details in the response.
+	Expand string `json:"expand,omitempty"`
+	// The groups that the user belongs to.
+	Groups *SimpleListWrapperGroupName `json:"groups,omitempty"`
+	// This property is no longer available and will be removed from the documentation soon.
+	Key string `json:"key,omitempty"`
+	// The locale of the user.
+	Locale string `json:"locale,omitempty"`
+	// This property is no longer available and will be removed from the documentation soon.
+	Name string `json:"name,omitempty"`
+	// The URL of the user.
+	Self string `json:"self,omitempty"`
+	// The time zone specified in the user's profile.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// UserDetails is the UserDetails schema.
+//
+// User details permitted by the user's Atlassian Account privacy settings.
+type UserDetails struct {
+	// The account ID of the user, which uniquely identifies the user across all Atlassian products.
+	AccountId string `json:"accountId,omitempty"`
+	// The type of account represented by this user.
+	AccountType string `json:"accountType,omitempty"`
+	// Whether the user is active.
+	Active *bool `json:"active,omitempty"`
+	// The avatars of the user.
+	AvatarUrls *AvatarUrlsBean `json:"avatarUrls,omitempty"`
+	// The display name of the user.
+	DisplayName string `json:"displayName,omitempty"`
+	// The email address of the user.
+	EmailAddress string `json:"emailAddress,omitempty"`
+	// This property is no longer available and will be removed from the documentation soon.
+	Key string `json:"key,omitempty"`
+	// This property is no longer available and will be removed from the documentation soon.
+	Name string `json:"name,omitempty"`
+	// The URL of the user.
+	Self string `json:"self,omitempty"`
+	// The time zone specified in the user's profile.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// Visibility is the Visibility schema.
+//
+// The group or role to which this item is visible.
+type Visibility struct {
+	// The ID of the group or the name of the role that visibility of this item is restricted to.
+	Identifier string `json:"identifier,omitempty"`
+	// Whether visibility of this item is restricted to a group or role.
+	Type string `json:"type,omitempty"`
+	// The name of the group or role that visibility of this item is restricted to.
+	Value string `json:"value,omitempty"`
+}
+
+// Worklog is the Worklog schema.
+//
+// Details of a worklog.
+type Worklog struct {
+	// Details of the user who created the worklog.
+	Author *UserDetails `json:"author,omitempty"`
+	// A comment about the worklog in Atlassian Document Format.
+	Comment json.RawMessage `json:"comment,omitempty"`
+	// The datetime on which the worklog was created.
+	Created string `json:"created,omitempty"`
+	// The ID of the worklog record.
+	Id string `json:"id,omitempty"`
+	// The ID of the issue this worklog is for.
+	IssueId string `json:"issueId,omitempty"`
+	// Details of properties for the worklog.
+	Properties []EntityProperty `json:"properties,omitempty"`
+	// The URL of the worklog item.
+	Self string `json:"self,omitempty"`
+	// The datetime on which the worklog effort was started.
+	Started string `json:"started,omitempty"`
+	// The time spent working on the issue as days (\#d), hours (\#h), or minutes (\#m or \#).
+	TimeSpent string `json:"timeSpent,omitempty"`
+	// The time in seconds spent working on the issue.
+	TimeSpentSeconds int64 `json:"timeSpentSeconds,omitempty"`
+	// Details of the user who last updated the worklog.
+	UpdateAuthor *UserDetails `json:"updateAuthor,omitempty"`
+	// The datetime on which the worklog was last updated.
+	Updated string `json:"updated,omitempty"`
+	// Details about any restrictions in the visibility of the worklog.
+	Visibility *Visibility `json:"visibility,omitempty"`
+}
@@ -0,0 +1,98 @@
+package jiraRestApi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"jiraTimeWidget/internal/openapi"
+	"jiraTimeWidget/jiraApiFunctions"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+// TestGeneratedCodeIsCurrent fails when jiraRestApiGenerated.go no longer
+// matches what the generator makes of the spec and operations.txt.
+func TestGeneratedCodeIsCurrent(t *testing.T) {
+	spec, err := openapi.Load("../jiraRestApiDoc/OpenApi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids, err := openapi.ReadAllowList("operations.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := openapi.Generate(spec, "jiraRestApi", ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("jiraRestApiGenerated.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("jiraRestApiGenerated.go is out of date, run go generate ./jiraRestApi")
+	}
+}
+
+func TestAddWorklog(t *testing.T) {
+	var query map[string][]string
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/rest/api/3/issue/PROJ-1/worklog" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		query = r.URL.Query()
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &sent)
+		w.Write([]byte(`{"id":"100","timeSpentSeconds":3600,"author":{"accountId":"abc"}}`))
+	}))
+	defer server.Close()
+	client := jiraApiFunctions.NewClient(server.URL, "", "key")
+
+	notify := false
+	worklog, err := AddWorklog(context.Background(), client, AddWorklogParams{
+		IssueIdOrKey: "PROJ-1",
+		NotifyUsers:  &notify,
+		Body:         &Worklog{TimeSpent: "1h", Started: "2024-01-02T09:00:00.000+0000"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if worklog.Id != "100" || worklog.TimeSpentSeconds != 3600 || worklog.Author == nil || worklog.Author.AccountId != "abc" {
+		t.Errorf("Unexpected worklog %+v", worklog)
+	}
+	if got := query["notifyUsers"]; len(got) != 1 || got[0] != "false" {
+		t.Errorf("Expected notifyUsers=false, got %v", query)
+	}
+	if _, ok := query["expand"]; ok {
+		t.Errorf("Unset parameters should not be sent, got %v", query)
+	}
+	if sent["timeSpent"] != "1h" || len(sent) != 2 {
+		t.Errorf("Unexpected body %v", sent)
+	}
+}
+
+func TestSearchRepeatsArrayParameters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query()["fields"]; len(got) != 2 || got[0] != "summary" || got[1] != "status" {
+			t.Errorf("Expected repeated fields, got %v", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"issues":[{"key":"PROJ-1"}],"isLast":true}`))
+	}))
+	defer server.Close()
+	client := jiraApiFunctions.NewClient(server.URL, "", "key")
+
+	results, err := SearchAndReconsileIssuesUsingJql(context.Background(), client, SearchAndReconsileIssuesUsingJqlParams{
+		Jql:    "project = PROJ",
+		Fields: []string{"summary", "status"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Issues) != 1 || results.Issues[0].Key != "PROJ-1" {
+		t.Errorf("Unexpected results %+v", results)
+	}
+}
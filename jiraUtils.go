@@ -1,142 +1,129 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 	"jiraTimeWidget/jiraApiFunctions"
+	"jiraTimeWidget/jiraModels"
 )
 
 func getJiraItem(jiraId string, token string) string {
-	jiraItem, err := jiraApiFunctions.GetIssue(jiraId, "", "names,renderedFields")
+	jiraItem, err := fetchJiraItem(context.Background(), jiraId)
 	if err != nil {
-		log.Println("Error getting jira item:", err)
 		return ""
 	}
 	return string(jiraItem)
 }
 
-func logWorkToJira(jiraId string, timeSpent string, comment string, startTime time.Time) error {
+// fetchJiraItem retrieves the full issue document for jiraId.
+func fetchJiraItem(ctx context.Context, jiraId string) ([]byte, error) {
+	jiraItem, err := currentSite().client.GetIssueContext(ctx, jiraId, "", "names,renderedFields")
+	if err != nil {
+		log.Println("Error getting jira item:", err)
+		return nil, err
+	}
+	return jiraItem, nil
+}
+
+// describeJiraError turns an API failure into a short message for the UI.
+func describeJiraError(err error) string {
+	var apiErr *jiraApiFunctions.APIError
+	switch {
+	case jiraApiFunctions.IsNotFound(err):
+		return "not found, or you don't have permission to see it"
+	case jiraApiFunctions.IsInvalidGrant(err):
+		return `your Jira login has expired, run "jira-time auth login"`
+	case jiraApiFunctions.IsUnauthorized(err):
+		return "Jira rejected your credentials, check ~/.jirarc"
+	case jiraApiFunctions.IsForbidden(err):
+		return "you don't have permission to do that"
+	case jiraApiFunctions.IsRateLimited(err):
+		return "Jira is rate limiting requests, try again shortly"
+	case jiraApiFunctions.IsServerError(err):
+		return fmt.Sprintf("Jira is having problems (HTTP %d)", jiraApiFunctions.StatusCode(err))
+	case errors.As(err, &apiErr) && apiErr.Message() != "":
+		return apiErr.Message()
+	default:
+		return err.Error()
+	}
+}
+
+// logWorkToJira posts a worklog through client and returns the ID Jira
+// assigned to it. The ID is empty when Jira took the worklog but its answer
+// couldn't be read; the worklog counts as logged so it is never posted
+// twice, and `jira-time sync` can match it up later.
+func logWorkToJira(ctx context.Context, client *jiraApiFunctions.Client, jiraId string, timeSpent string, comment string, startTime time.Time) (string, error) {
 	worklogData := map[string]interface{}{
 		"timeSpent": timeSpent,
-		"comment": map[string]interface{}{
-			"type": "doc",
-			"version": 1,
-			"content": []map[string]interface{}{
-				{
-					"type": "paragraph",
-					"content": []map[string]interface{}{
-						{
-							"text": comment,
-							"type": "text",
-						},
-					},
-				},
-			},
-		},
-		"started": startTime.Format("2006-01-02T15:04:05.000-0700"),
+		"comment": client.TextBody(comment),
+		"started": startTime.Format(jiraModels.TimeFormat),
 	}
 	
-	_, err := jiraApiFunctions.AddWorklog(jiraId, worklogData)
-	return err
+	worklog, err := client.CreateWorklog(ctx, jiraId, worklogData)
+	if errors.Is(err, jiraApiFunctions.ErrCreatedUnconfirmed) {
+		log.Printf("Warning: worklog on %s unconfirmed: %v", jiraId, err)
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return worklog.ID, nil
 }
 
 // GetIssueStatus retrieves the current status of a Jira issue
-func GetIssueStatus(issueKey string) (*StatusInfo, error) {
-	// Call GetIssue API with fields parameter set to "status"
-	response, err := jiraApiFunctions.GetIssue(issueKey, "status", "")
+func GetIssueStatus(ctx context.Context, issueKey string) (*StatusInfo, error) {
+	issue, err := currentSite().client.Issue(ctx, issueKey, []string{"status"}, "")
 	if err != nil {
 		log.Printf("Error fetching issue status for %s: %v", issueKey, err)
 		return nil, err
 	}
 
-	// Parse JSON response to extract status information
-	var issueData struct {
-		Fields struct {
-			Status struct {
-				ID          string `json:"id"`
-				Name        string `json:"name"`
-				Description string `json:"description"`
-				StatusCategory struct {
-					Key string `json:"key"`
-				} `json:"statusCategory"`
-			} `json:"status"`
-		} `json:"fields"`
-	}
-
-	if err := json.Unmarshal(response, &issueData); err != nil {
-		log.Printf("Error parsing status response for %s: %v", issueKey, err)
-		return nil, err
-	}
-
 	// Check if status data is present
-	if issueData.Fields.Status.ID == "" {
+	if issue.Fields.Status == nil || issue.Fields.Status.ID == "" {
 		log.Printf("No status found in response for %s", issueKey)
 		return nil, fmt.Errorf("no status found for issue %s", issueKey)
 	}
 
-	// Create and return StatusInfo struct
-	statusInfo := &StatusInfo{
-		ID:          issueData.Fields.Status.ID,
-		Name:        issueData.Fields.Status.Name,
-		Description: issueData.Fields.Status.Description,
-		Category:    issueData.Fields.Status.StatusCategory.Key,
-	}
+	status := newStatusInfo(*issue.Fields.Status)
+	return &status, nil
+}
 
-	return statusInfo, nil
+// newStatusInfo converts a Jira status into the form the UI shows.
+func newStatusInfo(status jiraModels.Status) StatusInfo {
+	return StatusInfo{
+		ID:          status.ID,
+		Name:        status.Name,
+		Description: status.Description,
+		Category:    status.StatusCategory.Key,
+	}
 }
 
 // GetAvailableTransitions retrieves all available status transitions for a Jira issue
-func GetAvailableTransitions(issueKey string) ([]Transition, error) {
+func GetAvailableTransitions(ctx context.Context, issueKey string) ([]Transition, error) {
 	// Get current status to determine direction
-	currentStatus, err := GetIssueStatus(issueKey)
+	currentStatus, err := GetIssueStatus(ctx, issueKey)
 	if err != nil {
 		log.Printf("Error fetching current status for %s: %v", issueKey, err)
 		return nil, err
 	}
 
-	// Call GetIssueTransitions API
-	response, err := jiraApiFunctions.GetIssueTransitions(issueKey, "")
+	available, err := currentSite().client.Transitions(ctx, issueKey)
 	if err != nil {
 		log.Printf("Error fetching transitions for %s: %v", issueKey, err)
 		return nil, err
 	}
 
-	// Parse JSON response to extract available transitions
-	var transitionsData struct {
-		Transitions []struct {
-			ID   string `json:"id"`
-			Name string `json:"name"`
-			To   struct {
-				ID          string `json:"id"`
-				Name        string `json:"name"`
-				Description string `json:"description"`
-				StatusCategory struct {
-					Key string `json:"key"`
-				} `json:"statusCategory"`
-			} `json:"to"`
-		} `json:"transitions"`
-	}
-
-	if err := json.Unmarshal(response, &transitionsData); err != nil {
-		log.Printf("Error parsing transitions response for %s: %v", issueKey, err)
-		return nil, err
-	}
-
 	// Convert to Transition structs with IsForward field populated
-	transitions := make([]Transition, 0, len(transitionsData.Transitions))
-	for _, t := range transitionsData.Transitions {
+	transitions := make([]Transition, 0, len(available))
+	for _, t := range available {
 		transition := Transition{
-			ID:   t.ID,
-			Name: t.Name,
-			To: StatusInfo{
-				ID:          t.To.ID,
-				Name:        t.To.Name,
-				Description: t.To.Description,
-				Category:    t.To.StatusCategory.Key,
-			},
+			ID:        t.ID,
+			Name:      t.Name,
+			To:        newStatusInfo(t.To),
 			IsForward: determineTransitionDirection(currentStatus.Category, t.To.StatusCategory.Key, t.To.Name),
 		}
 		transitions = append(transitions, transition)
@@ -199,7 +186,7 @@ func determineTransitionDirection(currentCategory, targetCategory, targetStatusN
 }
 
 // ExecuteStatusTransition executes a status transition for a Jira issue
-func ExecuteStatusTransition(issueKey string, transitionID string) error {
+func ExecuteStatusTransition(ctx context.Context, issueKey string, transitionID string) error {
 	// Format request body as {"transition": {"id": "transitionID"}}
 	transitionData := map[string]interface{}{
 		"transition": map[string]interface{}{
@@ -208,33 +195,11 @@ func ExecuteStatusTransition(issueKey string, transitionID string) error {
 	}
 
 	// Call TransitionIssue API with transition data
-	response, err := jiraApiFunctions.TransitionIssue(issueKey, transitionData)
-	if err != nil {
+	if _, err := currentSite().client.TransitionIssueContext(ctx, issueKey, transitionData); err != nil {
 		log.Printf("Error executing transition %s for issue %s: %v", transitionID, issueKey, err)
 		return fmt.Errorf("failed to execute status transition: %w", err)
 	}
 
-	// Check if response indicates an error (non-empty response body might contain error details)
-	if len(response) > 0 {
-		var errorResponse struct {
-			ErrorMessages []string          `json:"errorMessages"`
-			Errors        map[string]string `json:"errors"`
-		}
-		
-		if err := json.Unmarshal(response, &errorResponse); err == nil {
-			if len(errorResponse.ErrorMessages) > 0 {
-				log.Printf("Transition failed for %s: %v", issueKey, errorResponse.ErrorMessages)
-				return fmt.Errorf("transition failed: %s", errorResponse.ErrorMessages[0])
-			}
-			if len(errorResponse.Errors) > 0 {
-				log.Printf("Transition failed for %s: %v", issueKey, errorResponse.Errors)
-				for field, msg := range errorResponse.Errors {
-					return fmt.Errorf("transition failed: %s - %s", field, msg)
-				}
-			}
-		}
-	}
-
 	log.Printf("Successfully executed transition %s for issue %s", transitionID, issueKey)
 	return nil
 }
\ No newline at end of file
@@ -25,20 +25,9 @@ func TestGetJiraItem_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Override global variables for test - need to import jiraApiFunctions
-	originalUri := jiraGraphQlBaseUri
-	jiraGraphQlBaseUri = server.URL + "/gateway/api/graphql"
-	defer func() { jiraGraphQlBaseUri = originalUri }()
-	
-	// Also set the package variable
-	originalPackageUri := jiraApiFunctions.JiraGraphQlBaseUri
-	jiraApiFunctions.JiraGraphQlBaseUri = server.URL + "/gateway/api/graphql"
-	defer func() { jiraApiFunctions.JiraGraphQlBaseUri = originalPackageUri }()
-	
-	// Set API key
-	originalKey := jiraApiFunctions.JiraApiKey
-	jiraApiFunctions.JiraApiKey = "test-token"
-	defer func() { jiraApiFunctions.JiraApiKey = originalKey }()
+	originalClient := jiraApiFunctions.DefaultClient
+	jiraApiFunctions.DefaultClient = jiraApiFunctions.NewClient(server.URL, "", "test-token")
+	defer func() { jiraApiFunctions.DefaultClient = originalClient }()
 
 	result := getJiraItem("TEST-123", "test-token")
 	
@@ -54,17 +43,9 @@ func TestGetJiraItem_NotFound(t *testing.T) {
 	}))
 	defer server.Close()
 
-	originalUri := jiraGraphQlBaseUri
-	jiraGraphQlBaseUri = server.URL + "/gateway/api/graphql"
-	defer func() { jiraGraphQlBaseUri = originalUri }()
-	
-	originalPackageUri := jiraApiFunctions.JiraGraphQlBaseUri
-	jiraApiFunctions.JiraGraphQlBaseUri = server.URL + "/gateway/api/graphql"
-	defer func() { jiraApiFunctions.JiraGraphQlBaseUri = originalPackageUri }()
-	
-	originalKey := jiraApiFunctions.JiraApiKey
-	jiraApiFunctions.JiraApiKey = "test-token"
-	defer func() { jiraApiFunctions.JiraApiKey = originalKey }()
+	originalClient := jiraApiFunctions.DefaultClient
+	jiraApiFunctions.DefaultClient = jiraApiFunctions.NewClient(server.URL, "", "test-token")
+	defer func() { jiraApiFunctions.DefaultClient = originalClient }()
 
 	result := getJiraItem("INVALID-123", "test-token")
 	
@@ -84,17 +65,9 @@ func TestGetJiraItem_EmptyToken(t *testing.T) {
 	}))
 	defer server.Close()
 
-	originalUri := jiraGraphQlBaseUri
-	jiraGraphQlBaseUri = server.URL + "/gateway/api/graphql"
-	defer func() { jiraGraphQlBaseUri = originalUri }()
-	
-	originalPackageUri := jiraApiFunctions.JiraGraphQlBaseUri
-	jiraApiFunctions.JiraGraphQlBaseUri = server.URL + "/gateway/api/graphql"
-	defer func() { jiraApiFunctions.JiraGraphQlBaseUri = originalPackageUri }()
-	
-	originalKey := jiraApiFunctions.JiraApiKey
-	jiraApiFunctions.JiraApiKey = ""
-	defer func() { jiraApiFunctions.JiraApiKey = originalKey }()
+	originalClient := jiraApiFunctions.DefaultClient
+	jiraApiFunctions.DefaultClient = jiraApiFunctions.NewClient(server.URL, "", "")
+	defer func() { jiraApiFunctions.DefaultClient = originalClient }()
 
 	result := getJiraItem("TEST-123", "")
 	
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// journal is an append-only file holding one JSON record per line.
+//
+// Appends are fsync'd before they return and compaction goes through a temp
+// file and an atomic rename, so a crash can at worst leave a torn final line,
+// which readers skip. Callers serialise access with lock, which takes an
+// advisory lock on a sibling ".lock" file so the GUI and the CLI can share
+// a journal safely.
+type journal struct {
+	path string
+}
+
+func (j *journal) lockPath() string {
+	return j.path + ".lock"
+}
+
+// lock acquires the journal lock and returns the function that releases it.
+func (j *journal) lock(exclusive bool) (func(), error) {
+	return acquireFileLock(j.lockPath(), exclusive)
+}
+
+// acquireFileLock blocks until it holds an advisory lock on path, creating
+// the file if needed, and returns the function that releases it.
+func acquireFileLock(path string, exclusive bool) (func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f, exclusive); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("locking %s: %w", path, err)
+	}
+	return func() {
+		unlockFile(f)
+		f.Close()
+	}, nil
+}
+
+// appendRecords writes records to the end of the journal and syncs them to
+// disk. The caller must hold the exclusive lock.
+func (j *journal) appendRecords(records ...interface{}) error {
+	var buf bytes.Buffer
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// A crash mid-append can leave a final line without its newline; start
+	// on a fresh line so the torn record doesn't swallow this one.
+	if info, err := f.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			if _, err := f.Write([]byte{'\n'}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// readRecords calls fn for every well-formed record in the journal and
+// returns the number of records seen. Lines that are not valid JSON are
+// logged and skipped rather than failing the whole read. The caller must
+// hold a lock.
+func (j *journal) readRecords(fn func(record json.RawMessage) error) (int, error) {
+	f, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	count := 0
+	for lineNo := 1; ; lineNo++ {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			if !json.Valid(line) {
+				log.Printf("Skipping corrupt record on line %d of %s", lineNo, j.path)
+			} else {
+				count++
+				if err := fn(json.RawMessage(line)); err != nil {
+					return count, err
+				}
+			}
+		}
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+	}
+}
+
+// rewrite atomically replaces the journal contents with records. The caller
+// must hold the exclusive lock.
+func (j *journal) rewrite(records []interface{}) error {
+	tmpPath := j.path + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(f)
+	encoder := json.NewEncoder(writer)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			f.Close()
+			os.Remove(tmpPath)
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	syncDir(filepath.Dir(j.path))
+	return nil
+}
+
+// syncDir flushes a directory entry so a completed rename survives a crash.
+// Not every platform supports syncing directories, so failures are ignored.
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
+
+// writeFileAtomic replaces path with data, so readers see either the old
+// file or the new one in full.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmpPath := path + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	syncDir(filepath.Dir(path))
+	return nil
+}
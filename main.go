@@ -1,37 +1,87 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"os"
 	"strings"
 	"time"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/widget"
 )
 
-var jiraGraphQlBaseUri = "https://dish-enterprise.atlassian.net/gateway/api/graphql"
-var jiraCloudId ="c724e043-0c6e-49f7-96a0-ba63500910c4"
+// jiraSiteUrl is the site root from "site" in .jirarc. The GraphQL gateway
+// is derived from it, and the cloud ID is discovered from the site unless
+// .jirarc sets "cloudId". These and jiraApiFunctions.DefaultClient change
+// with the profile under activeProfileMu; goroutines read them through
+// currentSite.
+var jiraSiteUrl string
+var jiraGraphQlBaseUri string
+var jiraCloudId string
 
 var jiraApiKey string
 
 
 
 func main() {
+	// --profile may appear anywhere, for the GUI and every command
+	args, profile, err := extractProfileFlag(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	selectedProfileName = profile
+	
 	// Check if running in CLI mode
-	if len(os.Args) > 1 && os.Args[1] == "logs" {
-		viewLogs()
-		return
+	if len(args) > 0 {
+		os.Exit(runCommand(args))
 	}
 	
-	loadJiraConfig()
 	a := app.New()
-	w := a.NewWindow("JiraWidgetLite")
-	w.SetTitle("JiraWidgetLite")
+	w := a.NewWindow(windowTitle)
+	w.SetTitle(windowTitle)
+
+	err = setupJiraConfig(context.Background())
+	switch {
+	case errors.Is(err, errPassphraseRequired):
+		// Tokens are in the encrypted credential file, which needs unlocking
+		askCredentialPassphrase(a, w, func() { startWidget(w) })
+	case err != nil:
+		log.Printf("Configuration error: %v", err)
+		showConfigError(a, w, err)
+	default:
+		startWidget(w)
+	}
+
+	w.ShowAndRun()
+}
+
+// startWidget opens local storage and fills w with the time tracking form.
+func startWidget(w fyne.Window) {
+	timeLog, err := openTimeLogStore()
+	if err != nil {
+		log.Fatalf("Error opening time log: %v", err)
+	}
+	outbox, err := openOutbox()
+	if err != nil {
+		log.Fatalf("Error opening outbox: %v", err)
+	}
+	timer, err := openTimerStore()
+	if err != nil {
+		log.Fatalf("Error opening timer: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
 
-	startTime := time.Now()
-	stopTime := time.Now()
+	// No timer until Start is pressed or a running one is restored
+	startTime := time.Time{}
+	stopTime := time.Time{}
 	duration := time.Duration(0)
+	elapsed := binding.NewString()
 
 	ui := &UIComponents{
 		StartTime:     &startTime,
@@ -39,11 +89,25 @@ func main() {
 		Duration:      &duration,
 		StartText:     widget.NewLabel(""),
 		StopText:      widget.NewLabel(""),
-		DurationText:  widget.NewLabel(""),
+		DurationText:  widget.NewLabelWithData(elapsed),
+		ElapsedText:   elapsed,
+		WindowTitle:   binding.NewString(),
+		Asleep:        binding.NewUntyped(),
 		StatusLabel:   widget.NewLabel("Select an issue to start tracking time"),
 		SelectedIssue: "",
 		MainWindow:    w, // Pass window reference for dynamic resizing
+		TimeLog:       timeLog,
+		Outbox:        outbox,
+		Timer:         timer,
+		ctx:           ctx,
 	}
+	
+	// Closing the window abandons any Jira requests still in flight, and
+	// notes when a running timer was last seen
+	w.SetOnClosed(func() {
+		touchTimer(ui)
+		cancel()
+	})
 
 	content := createMainForm(ui)
 	w.SetContent(content)
@@ -52,16 +116,53 @@ func main() {
 	w.Resize(fyne.NewSize(550, 200)) // Wider to fit full dropdown text
 	w.SetFixedSize(false) // Allow resizing
 	
-	w.ShowAndRun()
+	// Offer to carry on with a timer left running, e.g. after a crash
+	restoreTimer(ui)
+	startTimerHeartbeat(ui)
+	startTimerTicker(ui)
+	
+	// Retry any worklogs that failed to reach Jira last time
+	startOutboxWorker(ui)
+}
+
+// mustLoadJiraConfig loads .jirarc for CLI commands, exiting if it doesn't
+// describe a usable site.
+func mustLoadJiraConfig() {
+	getPassphrase = promptPassphrase
+	if err := setupJiraConfig(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// mustOpenTimeLogStore opens the time log for CLI commands, exiting on failure.
+func mustOpenTimeLogStore() TimeLogStore {
+	store, err := openTimeLogStore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening time log: %v\n", err)
+		os.Exit(1)
+	}
+	return store
 }
 
-func viewLogs() {
-	entries, err := getTodaysTimeLog()
+func viewLogs(store TimeLogStore) {
+	entries, err := getTodaysTimeLog(store)
 	if err != nil {
 		fmt.Printf("Error reading time logs: %v\n", err)
 		return
 	}
 	
+	// With --profile, only show that profile's entries
+	if selectedProfileName != "" {
+		var own []TimeLogEntry
+		for _, entry := range entries {
+			if activeProfile.owns(entry.Profile) {
+				own = append(own, entry)
+			}
+		}
+		entries = own
+	}
+	
 	if len(entries) == 0 {
 		fmt.Println("No time entries logged today.")
 		return
@@ -73,18 +174,39 @@ func viewLogs() {
 	totalDuration := time.Duration(0)
 	
 	for i, entry := range entries {
-		duration := entry.EndTime.Sub(entry.StartTime)
-		totalDuration += duration
+		totalDuration += entry.spent()
 		
 		fmt.Printf("%d. %s - %s\n", i+1, entry.JiraID, entry.Summary)
+		if entry.Profile != "" {
+			fmt.Printf("   Profile: %s\n", entry.Profile)
+		}
 		fmt.Printf("   Time: %s (%s - %s)\n", 
 			entry.Duration,
 			entry.StartTime.Format("15:04"),
 			entry.EndTime.Format("15:04"))
+		if len(entry.Segments) > 1 {
+			var segments []string
+			for _, segment := range entry.Segments {
+				segments = append(segments, segment.Start.Format("15:04")+"-"+segment.End.Format("15:04"))
+			}
+			fmt.Printf("   Segments: %s\n", strings.Join(segments, ", "))
+		}
 		if entry.Comment != "" && entry.Comment != "Time tracked via JiraTimeWidget" {
 			fmt.Printf("   Comment: %s\n", entry.Comment)
 		}
 		fmt.Printf("   Logged: %s\n", entry.LoggedAt.Format("15:04:05"))
+		switch entry.SyncStatus {
+		case SyncSynced:
+			if entry.WorklogID == "" {
+				fmt.Printf("   Jira: ✅ synced (worklog ID unconfirmed)\n")
+			} else {
+				fmt.Printf("   Jira: ✅ synced (worklog %s)\n", entry.WorklogID)
+			}
+		case SyncFailed:
+			fmt.Printf("   Jira: ❌ failed: %s\n", entry.LastError)
+		case SyncPending:
+			fmt.Printf("   Jira: ⏳ pending\n")
+		}
 		fmt.Println()
 	}
 	
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"jiraTimeWidget/jiraApiFunctions"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultOAuthRedirectPort is used for the loopback redirect when a
+	// profile doesn't set "redirectPort". Register
+	// http://127.0.0.1:47123/callback as the app's callback URL.
+	defaultOAuthRedirectPort = 47123
+	oauthCallbackPath        = "/callback"
+
+	// oauthLoginTimeout bounds how long auth login waits for the browser.
+	oauthLoginTimeout = 5 * time.Minute
+
+	// oauthLockFileName is held while an OAuth token is refreshed.
+	oauthLockFileName = ".jira_time_oauth.lock"
+)
+
+// defaultOAuthScopes covers reading issues, logging work and refreshing the
+// token without asking again.
+var defaultOAuthScopes = []string{"read:jira-work", "write:jira-work", "read:jira-user", "read:me", "offline_access"}
+
+// OAuthSettings describes the OAuth 2.0 (3LO) app a profile logs in with.
+type OAuthSettings struct {
+	ClientID string `json:"clientId"`
+	// ClientSecret is only needed by apps that aren't public clients.
+	ClientSecret string   `json:"clientSecret"`
+	Scopes       []string `json:"scopes"`
+	RedirectPort int      `json:"redirectPort"`
+	// AuthURL, TokenURL and APIURL default to Atlassian's and only need
+	// changing to use another authorization server.
+	AuthURL  string `json:"authUrl"`
+	TokenURL string `json:"tokenUrl"`
+	APIURL   string `json:"apiUrl"`
+}
+
+func (s *OAuthSettings) apiURL() string {
+	if s.APIURL != "" {
+		return strings.TrimSuffix(s.APIURL, "/")
+	}
+	return jiraApiFunctions.AtlassianAPIURL
+}
+
+func (s *OAuthSettings) redirectPort() int {
+	if s.RedirectPort != 0 {
+		return s.RedirectPort
+	}
+	return defaultOAuthRedirectPort
+}
+
+// config returns the OAuth app configuration for the settings.
+func (s *OAuthSettings) config() *jiraApiFunctions.OAuthConfig {
+	config := &jiraApiFunctions.OAuthConfig{
+		ClientID:     s.ClientID,
+		ClientSecret: s.ClientSecret,
+		AuthURL:      s.AuthURL,
+		TokenURL:     s.TokenURL,
+		RedirectURL:  fmt.Sprintf("http://127.0.0.1:%d%s", s.redirectPort(), oauthCallbackPath),
+		Scopes:       s.Scopes,
+		Audience:     jiraApiFunctions.AtlassianAudience,
+	}
+	if config.AuthURL == "" {
+		config.AuthURL = jiraApiFunctions.AtlassianAuthURL
+	}
+	if config.TokenURL == "" {
+		config.TokenURL = jiraApiFunctions.AtlassianTokenURL
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = defaultOAuthScopes
+	}
+	return config
+}
+
+// loadOAuthTokens sets up the profile's token source from the token saved
+// by auth login. Refreshed tokens are written back, since the old refresh
+// token stops working once it has been rotated.
+func loadOAuthTokens(store CredentialStore, storeKind string, profile *JiraProfile) error {
+	secret, err := store.Get(profile.TokenRef)
+	if err != nil {
+		return err
+	}
+	var token jiraApiFunctions.OAuthToken
+	if err := json.Unmarshal([]byte(secret), &token); err != nil {
+		return fmt.Errorf("stored OAuth token is unreadable, run auth login again: %w", err)
+	}
+
+	stored := &storedOAuthToken{storeKind: storeKind, name: profile.TokenRef, profile: profile.Name}
+	profile.oauthTokens = jiraApiFunctions.NewOAuthTokenSource(profile.OAuth.config(), &token, stored)
+	return nil
+}
+
+// storedOAuthToken is a profile's OAuth token in the credential store,
+// which the GUI and the CLI refresh in turn, holding a lock file in the
+// home directory while they do.
+type storedOAuthToken struct {
+	storeKind string
+	name      string
+	profile   string
+}
+
+func (s *storedOAuthToken) Lock() (func(), error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return acquireFileLock(filepath.Join(homeDir, oauthLockFileName), true)
+}
+
+func (s *storedOAuthToken) Load() (*jiraApiFunctions.OAuthToken, error) {
+	store, err := openCredentialStore(s.storeKind)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+	secret, err := store.Get(s.name)
+	if err != nil {
+		return nil, err
+	}
+	var token jiraApiFunctions.OAuthToken
+	if err := json.Unmarshal([]byte(secret), &token); err != nil {
+		return nil, fmt.Errorf("stored OAuth token for profile %s is unreadable: %w", s.profile, err)
+	}
+	return &token, nil
+}
+
+func (s *storedOAuthToken) Save(token *jiraApiFunctions.OAuthToken) error {
+	if err := saveOAuthToken(s.storeKind, s.name, token); err != nil {
+		return fmt.Errorf("profile %s: %w", s.profile, err)
+	}
+	return nil
+}
+
+// saveOAuthToken stores token in the credential store as name.
+func saveOAuthToken(storeKind, name string, token *jiraApiFunctions.OAuthToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	store, err := openCredentialStore(storeKind)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	return store.Set(name, string(data))
+}
+
+// runOAuthLogin runs the authorization code flow with PKCE: it opens the
+// browser at the consent page, waits for the redirect on a loopback
+// listener, then saves the token and the site's cloud ID.
+func runOAuthLogin(ctx context.Context, config *jiraConfig, profile *JiraProfile, siteURL, secretName string) int {
+	oauthConfig := profile.OAuth.config()
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", profile.OAuth.redirectPort()))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listening for the OAuth redirect: %v\n", err)
+		return 1
+	}
+
+	verifier, challenge, err := jiraApiFunctions.NewPKCE()
+	if err != nil {
+		listener.Close()
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	state, err := jiraApiFunctions.NewOAuthState()
+	if err != nil {
+		listener.Close()
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	authURL := oauthConfig.AuthCodeURL(state, challenge)
+	fmt.Fprintf(os.Stderr, "Opening your browser to authorise jira-time. If it doesn't open, visit:\n%s\n", authURL)
+	if err := openAuthorizationURL(authURL); err != nil {
+		log.Printf("Could not open a browser: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, oauthLoginTimeout)
+	defer cancel()
+	code, err := waitForOAuthCode(ctx, listener, state)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: authorisation failed: %v\n", err)
+		return 1
+	}
+	token, err := oauthConfig.Exchange(ctx, code, verifier)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	tokens := jiraApiFunctions.NewOAuthTokenSource(oauthConfig, token, nil)
+	cloudID, err := oauthCloudID(ctx, profile.OAuth.apiURL(), tokens, siteURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	candidate := *profile
+	candidate.CloudID = cloudID
+	candidate.oauthTokens = tokens
+	user, err := candidate.newClient().Myself(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Jira didn't accept the login: %s\n", describeJiraError(err))
+		return 1
+	}
+
+	current := tokens.Current()
+	if err := saveOAuthToken(config.CredentialStore, secretName, &current); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving token: %v\n", err)
+		return 1
+	}
+	err = updateProfileConfig(profile.Name, func(fields map[string]interface{}) {
+		delete(fields, "jira")
+		fields["tokenRef"] = secretName
+		fields["cloudId"] = cloudID
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Token saved, but updating ~/.jirarc failed: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Set \"tokenRef\": %q and \"cloudId\": %q in profile %s by hand.\n", secretName, cloudID, profile.Name)
+		return 1
+	}
+
+	fmt.Printf("✅ Logged in to %s as %s.\n", siteURL, describeUser(user))
+	fmt.Printf("OAuth token saved as %q.\n", secretName)
+	return 0
+}
+
+// waitForOAuthCode serves the loopback redirect until the authorization
+// server sends the user back with a code for state.
+func waitForOAuthCode(ctx context.Context, listener net.Listener, state string) (string, error) {
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(oauthCallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var res result
+		switch {
+		case query.Get("state") != state:
+			res.err = errors.New("the redirect has the wrong state parameter")
+		case query.Get("error") != "":
+			res.err = fmt.Errorf("%s %s", query.Get("error"), query.Get("error_description"))
+		case query.Get("code") == "":
+			res.err = errors.New("the redirect has no code")
+		default:
+			res.code = query.Get("code")
+		}
+
+		message := "jira-time is logged in. You can close this window."
+		if res.err != nil {
+			message = "Login failed: " + res.err.Error()
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, "<!DOCTYPE html><html><body><p>%s</p></body></html>", html.EscapeString(message))
+
+		select {
+		case results <- res:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	select {
+	case res := <-results:
+		return res.code, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// oauthCloudID finds the cloud ID of siteURL among the sites the token was
+// granted for.
+func oauthCloudID(ctx context.Context, apiURL string, tokens jiraApiFunctions.TokenSource, siteURL string) (string, error) {
+	resources, err := jiraApiFunctions.AccessibleResources(ctx, apiURL, tokens)
+	if err != nil {
+		return "", fmt.Errorf("listing authorised sites: %s", describeJiraError(err))
+	}
+	var granted []string
+	for _, resource := range resources {
+		if resourceURL, err := normalizeSiteURL(resource.URL); err == nil && resourceURL == siteURL {
+			return resource.ID, nil
+		}
+		granted = append(granted, resource.URL)
+	}
+	if len(granted) == 0 {
+		return "", fmt.Errorf("the login wasn't granted access to any site")
+	}
+	return "", fmt.Errorf("the login was granted %s, not %s", strings.Join(granted, ", "), siteURL)
+}
+
+// openAuthorizationURL shows the consent page. Tests replace it to follow
+// the URL themselves.
+var openAuthorizationURL = openBrowser
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"jiraTimeWidget/jiraApiFunctions"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newFakeOAuthSite serves an authorization server, the accessible
+// resources list and Jira's /myself behind the API gateway. Refresh tokens
+// are rotated on every use.
+func newFakeOAuthSite(t *testing.T) *httptest.Server {
+	var mu sync.Mutex
+	issued := 0
+	validRefresh := map[string]bool{"refresh-0": true}
+	accessTokens := map[string]bool{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/authorize":
+			q := r.URL.Query()
+			http.Redirect(w, r, q.Get("redirect_uri")+"?code=the-code&state="+q.Get("state"), http.StatusFound)
+		case "/oauth/token":
+			r.ParseForm()
+			switch {
+			case r.Form.Get("grant_type") == "authorization_code" && r.Form.Get("code") == "the-code" && r.Form.Get("code_verifier") != "":
+			case r.Form.Get("grant_type") == "refresh_token" && validRefresh[r.Form.Get("refresh_token")]:
+				delete(validRefresh, r.Form.Get("refresh_token"))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			issued++
+			access, refresh := fmt.Sprintf("access-%d", issued), fmt.Sprintf("refresh-%d", issued)
+			accessTokens[access], validRefresh[refresh] = true, true
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": access, "refresh_token": refresh, "expires_in": 3600,
+			})
+		default:
+			if len(r.Header.Get("Authorization")) < 7 || !accessTokens[r.Header.Get("Authorization")[7:]] {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch r.URL.Path {
+			case "/oauth/token/accessible-resources":
+				fmt.Fprintf(w, `[{"id":"other-id","url":"https://other.atlassian.net"},{"id":"cloud-1","url":%q}]`, "https://work.atlassian.net/")
+			case "/ex/jira/cloud-1/rest/api/3/myself":
+				w.Write([]byte(`{"displayName":"OAuth User"}`))
+			default:
+				http.NotFound(w, r)
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func freePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func oauthProfileConfig(server *httptest.Server, port int) string {
+	return fmt.Sprintf(`{"credentialStore":"file","profiles":{"work":{
+		"site":"work.atlassian.net","auth":"oauth",
+		"oauth":{"clientId":"client-1","redirectPort":%d,"authUrl":"%s/authorize","tokenUrl":"%s/oauth/token","apiUrl":"%s"}}}}`,
+		port, server.URL, server.URL, server.URL)
+}
+
+func TestAuthLogin_OAuth(t *testing.T) {
+	withSiteGlobals(t)
+	server := newFakeOAuthSite(t)
+	writeJiraConfig(t, oauthProfileConfig(server, freePort(t)))
+	withPassphrase(t, "pass")
+
+	originalOpen := openAuthorizationURL
+	defer func() { openAuthorizationURL = originalOpen }()
+	openAuthorizationURL = func(url string) error {
+		// Stand in for the browser: follow the consent page's redirect
+		// back to the loopback listener.
+		go func() {
+			if resp, err := http.Get(url); err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	if code := runAuthCommand([]string{"login"}); code != 0 {
+		t.Fatalf("Expected OAuth login to succeed, got exit code %d", code)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(os.Getenv("HOME"), ".jirarc"))
+	var config struct {
+		Profiles map[string]map[string]interface{} `json:"profiles"`
+	}
+	json.Unmarshal(data, &config)
+	if work := config.Profiles["work"]; work["tokenRef"] != "work" || work["cloudId"] != "cloud-1" {
+		t.Errorf("Expected tokenRef and cloudId to be saved, got %v", work)
+	}
+
+	selectedProfileName = ""
+	if err := loadJiraConfig(); err != nil {
+		t.Fatalf("loadJiraConfig failed: %v", err)
+	}
+	if err := validateJiraConfig(context.Background()); err != nil {
+		t.Fatalf("Expected the OAuth profile to be valid, got %v", err)
+	}
+	if jiraApiFunctions.DefaultClient.BaseURL != server.URL+"/ex/jira/cloud-1" {
+		t.Errorf("Expected requests to go through the gateway, got %s", jiraApiFunctions.DefaultClient.BaseURL)
+	}
+	if _, err := jiraApiFunctions.GetCurrentUser(""); err != nil {
+		t.Errorf("Expected the saved token to work, got %v", err)
+	}
+}
+
+func TestOAuthProfile_RefreshIsSaved(t *testing.T) {
+	withSiteGlobals(t)
+	server := newFakeOAuthSite(t)
+	writeJiraConfig(t, oauthProfileConfig(server, freePort(t)))
+	updateProfileConfig("work", func(fields map[string]interface{}) {
+		fields["tokenRef"] = "work"
+		fields["cloudId"] = "cloud-1"
+	})
+	withPassphrase(t, "pass")
+
+	expired := &jiraApiFunctions.OAuthToken{AccessToken: "stale", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Hour)}
+	if err := saveOAuthToken(credentialStoreFile, "work", expired); err != nil {
+		t.Fatal(err)
+	}
+
+	selectedProfileName = ""
+	if err := loadJiraConfig(); err != nil {
+		t.Fatalf("loadJiraConfig failed: %v", err)
+	}
+	if _, err := jiraApiFunctions.GetCurrentUser(""); err != nil {
+		t.Fatalf("Expected the token to be refreshed, got %v", err)
+	}
+
+	// The rotated refresh token must survive a restart
+	store, _ := openEncryptedFileStore()
+	secret, _ := store.Get("work")
+	var saved jiraApiFunctions.OAuthToken
+	json.Unmarshal([]byte(secret), &saved)
+	if saved.RefreshToken != "refresh-1" || saved.AccessToken != "access-1" {
+		t.Errorf("Expected the rotated token to be saved, got %+v", saved)
+	}
+
+	// A revoked login points the user back at auth login
+	loadJiraConfig()
+	activeProfile.oauthTokens = jiraApiFunctions.NewOAuthTokenSource(activeProfile.OAuth.config(), expired, nil)
+	jiraApiFunctions.DefaultClient = activeProfile.newClient()
+	_, err := jiraApiFunctions.GetCurrentUser("")
+	if got := describeJiraError(err); got != `your Jira login has expired, run "jira-time auth login"` {
+		t.Errorf("Unexpected error description %q", got)
+	}
+}
+
+func TestValidateJiraConfig_OAuthNeedsLogin(t *testing.T) {
+	withSiteGlobals(t)
+	writeJiraConfig(t, `{"profiles":{"work":{"site":"work.atlassian.net","auth":"oauth","oauth":{"clientId":"c"}}}}`)
+	selectedProfileName = ""
+	loadJiraConfig()
+	if err := validateJiraConfig(context.Background()); err == nil {
+		t.Error("Expected an OAuth profile without a login to be rejected")
+	}
+
+	writeJiraConfig(t, `{"profiles":{"work":{"site":"work.atlassian.net","auth":"oauth"}}}`)
+	if _, err := readJiraConfig(); err == nil {
+		t.Error("Expected an OAuth profile without a client ID to be rejected")
+	}
+}
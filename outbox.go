@@ -0,0 +1,516 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"jiraTimeWidget/jiraApiFunctions"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	outboxFileName = ".jira_time_outbox.jsonl"
+
+	outboxBaseDelay = 30 * time.Second
+	outboxMaxDelay  = time.Hour
+
+	// How often the GUI checks for queued worklogs that are due a retry.
+	outboxPollInterval = 30 * time.Second
+)
+
+// errProfileUnavailable wraps failures to set up the client for a queued
+// worklog's profile, e.g. after its credentials were removed.
+var errProfileUnavailable = errors.New("the worklog's profile can't be used")
+
+// OutboxItem is a worklog that failed to reach Jira and is waiting to be
+// retried. Items are keyed by the ID of the time log entry they belong to.
+type OutboxItem struct {
+	EntryID     string    `json:"entryId"`
+	JiraID      string    `json:"jiraId"`
+	TimeSpent   string    `json:"timeSpent"`
+	Comment     string    `json:"comment"`
+	Started     time.Time `json:"started"`
+	QueuedAt    time.Time `json:"queuedAt"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"nextAttempt"`
+	LastError   string    `json:"lastError,omitempty"`
+	Profile     string    `json:"profile,omitempty"`
+}
+
+type outboxRecord struct {
+	Op   string     `json:"op"` // "put" or "drop"
+	Item OutboxItem `json:"item"`
+}
+
+// Outbox is the persistent queue of worklogs waiting to be posted to Jira.
+// Like the time log it is a journal, so it is shared between the GUI and
+// the CLI.
+type Outbox struct {
+	journal journal
+}
+
+// openOutbox opens the outbox in the user's home directory.
+func openOutbox() (*Outbox, error) {
+	homeDir, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+	return newOutbox(filepath.Join(homeDir, outboxFileName)), nil
+}
+
+func newOutbox(path string) *Outbox {
+	return &Outbox{journal: journal{path: path}}
+}
+
+// Enqueue queues a failed worklog for entry, due for retry after the first
+// backoff interval.
+func (o *Outbox) Enqueue(entry TimeLogEntry, cause error, now time.Time) error {
+	item := OutboxItem{
+		EntryID:     entry.ID,
+		JiraID:      entry.JiraID,
+		TimeSpent:   entry.Duration,
+		Comment:     entry.Comment,
+		Started:     entry.StartTime,
+		QueuedAt:    now,
+		Attempts:    1,
+		NextAttempt: now.Add(outboxBackoff(1)),
+		Profile:     entry.Profile,
+	}
+	if cause != nil {
+		item.LastError = cause.Error()
+	}
+	return o.put(item)
+}
+
+// Items returns the queued worklogs, oldest first.
+func (o *Outbox) Items() ([]OutboxItem, error) {
+	unlock, err := o.journal.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	items, _, err := o.load()
+	return items, err
+}
+
+// Len returns the number of queued worklogs.
+func (o *Outbox) Len() (int, error) {
+	items, err := o.Items()
+	return len(items), err
+}
+
+// Drop removes a queued worklog without posting it.
+func (o *Outbox) Drop(entryID string) error {
+	found, err := o.remove(entryID)
+	if err == nil && !found {
+		err = fmt.Errorf("no queued worklog with ID %s", entryID)
+	}
+	return err
+}
+
+// remove deletes an item from the queue, reporting whether it was there.
+func (o *Outbox) remove(entryID string) (bool, error) {
+	unlock, err := o.journal.lock(true)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	items, count, err := o.load()
+	if err != nil {
+		return false, err
+	}
+	found := false
+	for _, item := range items {
+		if item.EntryID == entryID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false, nil
+	}
+
+	// Once the queue drains, start the journal afresh instead of letting
+	// put/drop pairs pile up forever.
+	if len(items) == 1 && count > 1 {
+		return true, o.journal.rewrite(nil)
+	}
+	return true, o.journal.appendRecords(outboxRecord{Op: "drop", Item: OutboxItem{EntryID: entryID}})
+}
+
+func (o *Outbox) put(item OutboxItem) error {
+	unlock, err := o.journal.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return o.journal.appendRecords(outboxRecord{Op: "put", Item: item})
+}
+
+// load folds the journal into the live queue. The caller must hold a lock.
+func (o *Outbox) load() ([]OutboxItem, int, error) {
+	var items []OutboxItem
+	index := make(map[string]int)
+
+	count, err := o.journal.readRecords(func(raw json.RawMessage) error {
+		var record outboxRecord
+		if err := json.Unmarshal(raw, &record); err != nil {
+			log.Printf("Skipping unreadable outbox record: %v", err)
+			return nil
+		}
+		i, exists := index[record.Item.EntryID]
+		switch record.Op {
+		case "put":
+			if exists {
+				items[i] = record.Item
+			} else {
+				index[record.Item.EntryID] = len(items)
+				items = append(items, record.Item)
+			}
+		case "drop":
+			if exists {
+				items = append(items[:i], items[i+1:]...)
+				delete(index, record.Item.EntryID)
+				for id, j := range index {
+					if j > i {
+						index[id] = j - 1
+					}
+				}
+			}
+		}
+		return nil
+	})
+	return items, count, err
+}
+
+// OutboxFlushResult summarises one pass over the outbox.
+type OutboxFlushResult struct {
+	Sent    int
+	Failed  int
+	Pending int
+}
+
+// Flush posts every queued worklog accepted by selected using post, which
+// returns the Jira worklog ID on success. Sent items leave the queue and
+// their time log entries are marked synced; temporary failures are
+// rescheduled with exponential backoff and worklogs Jira rejects outright
+// are dropped. A cancelled post stops the flush, leaving it and the rest
+// queued. Only one process flushes at a time, so a worklog is never posted
+// twice concurrently.
+func (o *Outbox) Flush(store TimeLogStore, now time.Time, selected func(OutboxItem) bool, post func(OutboxItem) (string, error)) (OutboxFlushResult, error) {
+	var result OutboxFlushResult
+
+	unlock, err := acquireFileLock(o.journal.path+".flush.lock", true)
+	if err != nil {
+		return result, err
+	}
+	defer unlock()
+
+	items, err := o.Items()
+	if err != nil {
+		return result, err
+	}
+
+	for i, item := range items {
+		if !selected(item) {
+			result.Pending++
+			continue
+		}
+
+		worklogID, postErr := post(item)
+		if errors.Is(postErr, context.Canceled) {
+			// The window closed or the user interrupted retry; the
+			// worklog wasn't rejected, so it waits for the next flush
+			result.Pending += len(items) - i
+			return result, postErr
+		}
+		if postErr != nil && !keepQueued(postErr) {
+			// Jira rejected the worklog itself, so retrying can't help. The
+			// local entry keeps the error for `logs` and `sync` to report.
+			if _, err := o.remove(item.EntryID); err != nil {
+				return result, err
+			}
+			updateTimeLogEntry(store, item.EntryID, func(entry *TimeLogEntry) { entry.markFailed(postErr) })
+			log.Printf("Giving up on queued worklog for %s: %v", item.JiraID, postErr)
+			result.Failed++
+			continue
+		}
+		if postErr != nil {
+			item.Attempts++
+			item.LastError = postErr.Error()
+			item.NextAttempt = now.Add(outboxBackoff(item.Attempts))
+			var apiErr *jiraApiFunctions.APIError
+			if errors.As(postErr, &apiErr) && now.Add(apiErr.RetryAfter).After(item.NextAttempt) {
+				// Jira asked us to hold off for longer than our own backoff
+				item.NextAttempt = now.Add(apiErr.RetryAfter)
+			}
+			if err := o.put(item); err != nil {
+				return result, err
+			}
+			updateTimeLogEntry(store, item.EntryID, func(entry *TimeLogEntry) { entry.markFailed(postErr) })
+			log.Printf("Retry %d of queued worklog for %s failed: %v", item.Attempts, item.JiraID, postErr)
+			result.Failed++
+			result.Pending++
+			continue
+		}
+
+		if _, err := o.remove(item.EntryID); err != nil {
+			return result, err
+		}
+		updateTimeLogEntry(store, item.EntryID, func(entry *TimeLogEntry) { entry.markSynced(worklogID) })
+		log.Printf("Posted queued worklog of %s to %s", item.TimeSpent, item.JiraID)
+		result.Sent++
+	}
+
+	return result, nil
+}
+
+// keepQueued reports whether a queued worklog that failed with err should
+// stay queued: besides the failures worth retrying, a login that has
+// expired or a credential that can't be found only need the user to log in
+// again, which doesn't make the worklog itself wrong.
+func keepQueued(err error) bool {
+	return jiraApiFunctions.IsRetryable(err) || jiraApiFunctions.IsUnauthorized(err) ||
+		jiraApiFunctions.IsForbidden(err) || jiraApiFunctions.IsInvalidGrant(err) || errors.Is(err, errProfileUnavailable)
+}
+
+// outboxDue selects the items whose backoff has expired at now.
+func outboxDue(now time.Time) func(OutboxItem) bool {
+	return func(item OutboxItem) bool {
+		return !now.Before(item.NextAttempt)
+	}
+}
+
+// outboxBackoff returns how long to wait before the next attempt after the
+// given number of attempts.
+func outboxBackoff(attempts int) time.Duration {
+	delay := outboxBaseDelay
+	for i := 1; i < attempts && delay < outboxMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > outboxMaxDelay {
+		delay = outboxMaxDelay
+	}
+	return delay
+}
+
+// updateTimeLogEntry applies update to the stored entry with the given ID.
+// Failures are logged; the outbox stays authoritative for what is pending.
+func updateTimeLogEntry(store TimeLogStore, id string, update func(*TimeLogEntry)) {
+	if store == nil {
+		return
+	}
+	entries, err := store.Entries()
+	if err != nil {
+		log.Printf("Warning: Failed to read local log: %v", err)
+		return
+	}
+	for _, entry := range entries {
+		if entry.ID == id {
+			update(&entry)
+			if err := store.Put(entry); err != nil {
+				log.Printf("Warning: Failed to update local log: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// outboxPoster returns a function that sends queued worklogs to the Jira
+// site of the profile they were logged under, giving up when ctx is
+// cancelled.
+func outboxPoster(ctx context.Context) func(OutboxItem) (string, error) {
+	return func(item OutboxItem) (string, error) {
+		client, err := profileClient(item.Profile)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", errProfileUnavailable, err)
+		}
+		return logWorkToJira(ctx, client, item.JiraID, item.TimeSpent, item.Comment, item.Started)
+	}
+}
+
+// submitWorklog saves entry to the local log first, so the time isn't lost
+// if the Jira call fails, then posts it and records the outcome. When Jira
+// can't be reached the worklog is queued in outbox and queued is true. The
+// error is only returned when Jira rejected the worklog, since retrying
+// would fail the same way, or when it couldn't be queued. A post cancelled
+// by ctx is queued too, and returns the context's error with queued true.
+func submitWorklog(ctx context.Context, store TimeLogStore, outbox *Outbox, entry TimeLogEntry) (saved TimeLogEntry, queued bool, err error) {
+	if err := store.Put(entry); err != nil {
+		log.Printf("Warning: Failed to save local log: %v", err)
+	}
+
+	worklogID, err := logWorkToJira(ctx, currentSite().client, entry.JiraID, entry.Duration, entry.Comment, entry.StartTime)
+	if err != nil {
+		entry.markFailed(err)
+	} else {
+		entry.markSynced(worklogID)
+	}
+	if err := store.Put(entry); err != nil {
+		log.Printf("Warning: Failed to update local log: %v", err)
+	}
+	if err == nil {
+		return entry, false, nil
+	}
+
+	log.Printf("Error logging work: %v", err)
+	cancelled := errors.Is(err, context.Canceled)
+	if !cancelled && !jiraApiFunctions.IsRetryable(err) {
+		return entry, false, err
+	}
+	if qerr := outbox.Enqueue(entry, err, time.Now()); qerr != nil {
+		log.Printf("Error queueing worklog: %v", qerr)
+		return entry, false, err
+	}
+	if cancelled {
+		return entry, true, err
+	}
+	return entry, true, nil
+}
+
+// startOutboxWorker retries queued worklogs in the background, once at
+// startup and then periodically, and keeps the pending count visible in the
+// status label.
+func startOutboxWorker(ui *UIComponents) {
+	go func() {
+		ticker := time.NewTicker(outboxPollInterval)
+		defer ticker.Stop()
+		post := outboxPoster(ui.ctx)
+		lastPending := -1
+		for {
+			now := time.Now()
+			result, err := ui.Outbox.Flush(ui.TimeLog, now, outboxDue(now), post)
+			if err != nil {
+				if !isCancelled(err) {
+					log.Printf("Error flushing outbox: %v", err)
+				}
+			} else if result.Sent > 0 || result.Pending != lastPending {
+				showOutboxStatus(ui, result)
+				lastPending = result.Pending
+			}
+			select {
+			case <-ticker.C:
+			case <-ui.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// showOutboxStatus reports a flush of the outbox. Once the queue has
+// emptied without sending, e.g. after "jira-time outbox drop", a pending
+// count it showed earlier is cleared, unless something else has been
+// reported since.
+func showOutboxStatus(ui *UIComponents, result OutboxFlushResult) {
+	var text string
+	switch {
+	case result.Pending > 0 && result.Sent > 0:
+		text = fmt.Sprintf("📤 Sent %d queued worklog(s), %d still pending", result.Sent, result.Pending)
+	case result.Pending > 0:
+		text = fmt.Sprintf("📤 %d worklog(s) waiting to sync with Jira", result.Pending)
+	case result.Sent > 0:
+		text = fmt.Sprintf("✅ Sent %d queued worklog(s) to Jira", result.Sent)
+	}
+
+	ui.mu.Lock()
+	shown := ui.outboxNotice
+	ui.outboxNotice = ""
+	if result.Pending > 0 {
+		ui.outboxNotice = text
+	}
+	ui.mu.Unlock()
+	switch {
+	case text != "":
+		ui.StatusLabel.SetText(text)
+	case shown != "" && ui.StatusLabel.Text == shown:
+		ui.StatusLabel.SetText("")
+	}
+}
+
+// runOutboxCommand implements `jira-time outbox list|retry|drop`.
+func runOutboxCommand(store TimeLogStore, args []string) int {
+	outbox, err := openOutbox()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening outbox: %v\n", err)
+		return 1
+	}
+
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch args[0] {
+	case "list":
+		items, err := outbox.Items()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading outbox: %v\n", err)
+			return 1
+		}
+		if len(items) == 0 {
+			fmt.Println("Outbox is empty.")
+			return 0
+		}
+		fmt.Printf("%d worklog(s) waiting to be sent:\n", len(items))
+		fmt.Println(strings.Repeat("=", 60))
+		for _, item := range items {
+			fmt.Printf("%s  %s  %s (started %s)\n", item.EntryID, item.JiraID, item.TimeSpent, item.Started.Format("2006-01-02 15:04"))
+			if item.Profile != "" {
+				fmt.Printf("   Profile: %s\n", item.Profile)
+			}
+			fmt.Printf("   Attempts: %d, next retry %s\n", item.Attempts, item.NextAttempt.Format("2006-01-02 15:04:05"))
+			if item.LastError != "" {
+				fmt.Printf("   Last error: %s\n", item.LastError)
+			}
+		}
+		return 0
+
+	case "retry":
+		// Retry immediately, ignoring backoff
+		selected := func(OutboxItem) bool { return true }
+		if len(args) > 1 {
+			only := args[1]
+			selected = func(item OutboxItem) bool { return item.EntryID == only }
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		result, err := outbox.Flush(store, time.Now(), selected, outboxPoster(ctx))
+		if errors.Is(err, context.Canceled) {
+			fmt.Printf("Interrupted after sending %d, %d still pending.\n", result.Sent, result.Pending)
+			return 1
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error flushing outbox: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Sent %d, failed %d, %d still pending.\n", result.Sent, result.Failed, result.Pending)
+		if result.Failed > 0 {
+			return 1
+		}
+		return 0
+
+	case "drop":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Usage: jira-time outbox drop <id>")
+			return 2
+		}
+		if err := outbox.Drop(args[1]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Dropped %s. Its time remains in the local log marked as failed.\n", args[1])
+		return 0
+
+	default:
+		fmt.Fprintln(os.Stderr, "Usage: jira-time outbox list|retry [id]|drop <id>")
+		return 2
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jiraTimeWidget/jiraApiFunctions"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOutbox_FlushRetriesWithBackoff(t *testing.T) {
+	dir := t.TempDir()
+	store := newJournalTimeLogStore(filepath.Join(dir, "log.jsonl"))
+	outbox := newOutbox(filepath.Join(dir, "outbox.jsonl"))
+	now := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	entry := TimeLogEntry{ID: "a", JiraID: "TEST-1", Duration: "1h", StartTime: now.Add(-time.Hour), SyncStatus: SyncFailed}
+	store.Put(entry)
+	if err := outbox.Enqueue(entry, errors.New("offline"), now); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	posts := 0
+	failing := func(OutboxItem) (string, error) {
+		posts++
+		return "", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	}
+
+	// Not due yet: nothing is posted
+	result, err := outbox.Flush(store, now, outboxDue(now), failing)
+	if err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if posts != 0 || result.Pending != 1 {
+		t.Errorf("Expected item to wait for its backoff, got %d posts and %+v", posts, result)
+	}
+
+	// Due: the failed retry is rescheduled further out
+	later := now.Add(outboxBaseDelay)
+	result, err = outbox.Flush(store, later, outboxDue(later), failing)
+	if err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if posts != 1 || result.Failed != 1 || result.Pending != 1 {
+		t.Errorf("Expected one failed retry, got %d posts and %+v", posts, result)
+	}
+	items, _ := outbox.Items()
+	if len(items) != 1 || items[0].Attempts != 2 || !items[0].NextAttempt.Equal(later.Add(2*outboxBaseDelay)) {
+		t.Errorf("Expected item rescheduled after second attempt, got %+v", items)
+	}
+
+	// Success removes the item and marks the time log entry synced
+	succeeding := func(item OutboxItem) (string, error) {
+		if item.EntryID != "a" || item.JiraID != "TEST-1" || item.TimeSpent != "1h" {
+			t.Errorf("Unexpected item posted: %+v", item)
+		}
+		return "10001", nil
+	}
+	all := func(OutboxItem) bool { return true }
+	result, err = outbox.Flush(store, later, all, succeeding)
+	if err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if result.Sent != 1 || result.Pending != 0 {
+		t.Errorf("Expected item to be sent, got %+v", result)
+	}
+	if n, _ := outbox.Len(); n != 0 {
+		t.Errorf("Expected empty outbox, got %d items", n)
+	}
+
+	entries, _ := store.Entries()
+	if len(entries) != 1 || entries[0].SyncStatus != SyncSynced || entries[0].WorklogID != "10001" {
+		t.Errorf("Expected entry to be marked synced, got %+v", entries)
+	}
+}
+
+func TestSubmitWorklog_UnreadableResponseNotQueued(t *testing.T) {
+	withSiteGlobals(t)
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("<html>Created</html>"))
+	}))
+	defer server.Close()
+	jiraApiFunctions.DefaultClient = jiraApiFunctions.NewClient(server.URL, "", "key")
+
+	// Jira created the worklog, so posting it again would log the time twice
+	dir := t.TempDir()
+	store := newJournalTimeLogStore(filepath.Join(dir, "log.jsonl"))
+	outbox := newOutbox(filepath.Join(dir, "outbox.jsonl"))
+	entry := TimeLogEntry{ID: "a", JiraID: "TEST-1", Duration: "1h", StartTime: time.Now().Add(-time.Hour)}
+	saved, queued, err := submitWorklog(context.Background(), store, outbox, entry)
+	if err != nil || queued || posts != 1 {
+		t.Errorf("Expected one post and nothing queued, got %d posts, queued %v, %v", posts, queued, err)
+	}
+	if n, _ := outbox.Len(); n != 0 {
+		t.Errorf("Expected an empty outbox, got %d items", n)
+	}
+	if saved.SyncStatus != SyncSynced || saved.WorklogID != "" {
+		t.Errorf("Expected the entry to be synced without a worklog ID, got %+v", saved)
+	}
+}
+
+func TestOutbox_FlushKeepsItemsWhenCancelled(t *testing.T) {
+	withSiteGlobals(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected nothing to reach Jira once cancelled")
+	}))
+	defer server.Close()
+	jiraApiFunctions.DefaultClient = jiraApiFunctions.NewClient(server.URL, "", "key")
+
+	dir := t.TempDir()
+	store := newJournalTimeLogStore(filepath.Join(dir, "log.jsonl"))
+	outbox := newOutbox(filepath.Join(dir, "outbox.jsonl"))
+	now := time.Now()
+	outbox.Enqueue(TimeLogEntry{ID: "a", JiraID: "TEST-1", Duration: "1h"}, nil, now)
+	outbox.Enqueue(TimeLogEntry{ID: "b", JiraID: "TEST-2", Duration: "2h"}, nil, now)
+
+	// The window closed, or the user pressed Ctrl-C, before the flush
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	all := func(OutboxItem) bool { return true }
+	result, err := outbox.Flush(store, now, all, outboxPoster(ctx))
+	if !errors.Is(err, context.Canceled) || result.Failed != 0 || result.Pending != 2 {
+		t.Errorf("Expected the flush to stop with both items pending, got %+v, %v", result, err)
+	}
+	if n, _ := outbox.Len(); n != 2 {
+		t.Errorf("Expected both items to stay queued, got %d", n)
+	}
+
+	// A post cancelled on its way is queued rather than lost
+	saved, queued, err := submitWorklog(ctx, store, outbox, TimeLogEntry{ID: "c", JiraID: "TEST-3", Duration: "1h"})
+	if !queued || !errors.Is(err, context.Canceled) || saved.SyncStatus != SyncFailed {
+		t.Errorf("Expected the cancelled worklog to be queued, got %+v, %v, %v", saved, queued, err)
+	}
+}
+
+func TestOutbox_FlushKeepsItemsUntilLoggedIn(t *testing.T) {
+	dir := t.TempDir()
+	store := newJournalTimeLogStore(filepath.Join(dir, "log.jsonl"))
+	outbox := newOutbox(filepath.Join(dir, "outbox.jsonl"))
+	now := time.Now()
+	outbox.Enqueue(TimeLogEntry{ID: "a", JiraID: "TEST-1", Duration: "1h"}, nil, now)
+	outbox.Enqueue(TimeLogEntry{ID: "b", JiraID: "TEST-2", Duration: "1h"}, nil, now)
+
+	unauthorized := func(item OutboxItem) (string, error) {
+		if item.EntryID == "a" {
+			return "", &jiraApiFunctions.APIError{StatusCode: http.StatusUnauthorized}
+		}
+		return "", fmt.Errorf("%w: no profile \"gone\" in .jirarc", errProfileUnavailable)
+	}
+	all := func(OutboxItem) bool { return true }
+	result, err := outbox.Flush(store, now, all, unauthorized)
+	if err != nil || result.Failed != 2 || result.Pending != 2 {
+		t.Errorf("Expected both items to wait for a login, got %+v, %v", result, err)
+	}
+	if n, _ := outbox.Len(); n != 2 {
+		t.Errorf("Expected both items to stay queued, got %d", n)
+	}
+}
+
+func TestOutbox_Drop(t *testing.T) {
+	outbox := newOutbox(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	now := time.Now()
+
+	outbox.Enqueue(TimeLogEntry{ID: "a", JiraID: "TEST-1"}, nil, now)
+	outbox.Enqueue(TimeLogEntry{ID: "b", JiraID: "TEST-2"}, nil, now)
+
+	if err := outbox.Drop("a"); err != nil {
+		t.Fatalf("Drop failed: %v", err)
+	}
+	if err := outbox.Drop("missing"); err == nil {
+		t.Error("Expected error dropping unknown item")
+	}
+
+	items, err := outbox.Items()
+	if err != nil {
+		t.Fatalf("Items failed: %v", err)
+	}
+	if len(items) != 1 || items[0].EntryID != "b" {
+		t.Errorf("Expected only item b to remain, got %+v", items)
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	if d := outboxBackoff(1); d != outboxBaseDelay {
+		t.Errorf("Expected first delay %v, got %v", outboxBaseDelay, d)
+	}
+	if d := outboxBackoff(3); d != 4*outboxBaseDelay {
+		t.Errorf("Expected third delay %v, got %v", 4*outboxBaseDelay, d)
+	}
+	if d := outboxBackoff(50); d != outboxMaxDelay {
+		t.Errorf("Expected delay capped at %v, got %v", outboxMaxDelay, d)
+	}
+}
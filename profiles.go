@@ -0,0 +1,373 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"jiraTimeWidget/jiraApiFunctions"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// legacyProfileName names the profile built from a .jirarc that has no
+// "profiles" section.
+const legacyProfileName = "default"
+
+// Values for "auth" in a profile.
+const (
+	authModeToken = "token"
+	authModeOAuth = "oauth"
+)
+
+// JiraProfile is one Jira site the widget can log time against, as
+// configured in .jirarc.
+type JiraProfile struct {
+	Name   string `json:"-"`
+	Site   string `json:"site"`
+	Email  string `json:"email"`
+	APIKey string `json:"jira"`
+	// TokenRef names a token kept in the credential store instead of
+	// writing it here as "jira".
+	TokenRef string `json:"tokenRef"`
+	CloudID  string `json:"cloudId"`
+	// Timeout bounds each request, e.g. "45s"; "0s" waits indefinitely.
+	Timeout string `json:"timeout"`
+	// JQL replaces the query used to fill the recent issues list.
+	JQL string `json:"jql"`
+	// WorklogComment is used when a worklog is logged without a comment.
+	WorklogComment string `json:"worklogComment"`
+	// WorklogRoundTo rounds timed durations up, e.g. "15m".
+	WorklogRoundTo string `json:"worklogRoundTo"`
+	// Auth is "token" (the default) or "oauth".
+	Auth  string         `json:"auth"`
+	OAuth *OAuthSettings `json:"oauth"`
+	// Deployment is "cloud" (the default) or "datacenter" for Jira Data
+	// Center and Server, which log in with a Personal Access Token.
+	Deployment string `json:"deployment"`
+	// Cache keeps read responses on disk, so the widget starts without
+	// waiting for Jira and can show issues while offline.
+	Cache bool `json:"cache"`
+
+	isDefault bool
+	// oauthTokens is loaded from the credential store for OAuth profiles.
+	oauthTokens *jiraApiFunctions.OAuthTokenSource
+}
+
+// jiraConfig is the parsed .jirarc. Older files hold a single site's keys
+// at the top level; newer ones name several under "profiles".
+type jiraConfig struct {
+	JiraProfile
+	DefaultProfile string                  `json:"defaultProfile"`
+	Profiles       map[string]*JiraProfile `json:"profiles"`
+	// CredentialStore picks where tokenRef secrets live, see
+	// openCredentialStore.
+	CredentialStore string `json:"credentialStore"`
+}
+
+// selectedProfileName is the profile chosen with --profile, if any.
+var selectedProfileName string
+
+// jiraProfiles is the configuration loaded by loadJiraConfig.
+var jiraProfiles *jiraConfig
+
+// activeProfile is the profile the widget is currently talking to.
+var activeProfile = &JiraProfile{Name: legacyProfileName, isDefault: true}
+
+// activeProfileMu guards changes to activeProfile against the goroutines
+// that read it with currentProfile.
+var activeProfileMu sync.RWMutex
+
+// readJiraConfig reads ~/.jirarc.
+func readJiraConfig() (*jiraConfig, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(homeDir, ".jirarc"))
+	if err != nil {
+		return nil, err
+	}
+
+	var config jiraConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing .jirarc: %w", err)
+	}
+	if len(config.Profiles) == 0 {
+		legacy := config.JiraProfile
+		config.Profiles = map[string]*JiraProfile{legacyProfileName: &legacy}
+		config.DefaultProfile = legacyProfileName
+	}
+	for name, profile := range config.Profiles {
+		if profile == nil {
+			return nil, fmt.Errorf("profile %q in .jirarc is empty", name)
+		}
+		profile.Name = name
+		if profile.Auth != "" && profile.Auth != authModeToken && profile.Auth != authModeOAuth {
+			return nil, fmt.Errorf("profile %s: unknown auth %q in .jirarc (use %q or %q)", name, profile.Auth, authModeToken, authModeOAuth)
+		}
+		deployment, err := jiraApiFunctions.ParseDeployment(profile.Deployment)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", name, err)
+		}
+		if profile.usesOAuth() && deployment == jiraApiFunctions.DeploymentDataCenter {
+			return nil, fmt.Errorf("profile %s: OAuth is only available on Jira Cloud, use a Personal Access Token on Data Center", name)
+		}
+		if profile.usesOAuth() && (profile.OAuth == nil || profile.OAuth.ClientID == "") {
+			return nil, fmt.Errorf(`profile %s: OAuth needs "oauth": {"clientId": ...} in .jirarc`, name)
+		}
+	}
+	if _, ok := config.Profiles[config.defaultProfileName()]; !ok {
+		return nil, fmt.Errorf("defaultProfile %q in .jirarc is not a profile", config.DefaultProfile)
+	}
+	config.Profiles[config.defaultProfileName()].isDefault = true
+	return &config, nil
+}
+
+// loadActiveProfile selects the profile chosen with --profile without
+// unlocking any tokens, for commands that only work with the local log.
+func loadActiveProfile() {
+	config, err := readJiraConfig()
+	if err != nil {
+		return
+	}
+	if profile, err := config.Profile(selectedProfileName); err == nil {
+		jiraProfiles = config
+		setActiveProfile(profile)
+	}
+}
+
+// ProfileNames returns the configured profile names in sorted order.
+func (c *jiraConfig) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns the named profile, or the default one when name is empty.
+func (c *jiraConfig) Profile(name string) (*JiraProfile, error) {
+	if name == "" {
+		name = c.defaultProfileName()
+	}
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("no profile %q in .jirarc (have %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+	return profile, nil
+}
+
+// defaultProfileName is the profile used without --profile: the one named
+// by "defaultProfile", or the first in sorted order.
+func (c *jiraConfig) defaultProfileName() string {
+	if c.DefaultProfile != "" {
+		return c.DefaultProfile
+	}
+	return c.ProfileNames()[0]
+}
+
+// applyProfile points the Jira globals and the default API client at the
+// profile's site. They change together under activeProfileMu, so
+// currentSite never sees half of one profile and half of another.
+func applyProfile(profile *JiraProfile) {
+	var siteURL string
+	if profile.Site != "" {
+		normalized, err := normalizeSiteURL(profile.Site)
+		if err != nil {
+			log.Printf("Ignoring site of profile %s: %v", profile.Name, err)
+		} else {
+			siteURL = normalized
+		}
+	}
+	client := profile.newClient()
+
+	activeProfileMu.Lock()
+	defer activeProfileMu.Unlock()
+	jiraSiteUrl = siteURL
+	jiraApiKey = profile.APIKey
+	jiraCloudId = profile.CloudID
+	jiraGraphQlBaseUri = client.GraphQLURL()
+	jiraApiFunctions.DefaultClient = client
+	activeProfile = profile
+}
+
+// setActiveProfile switches activeProfile under activeProfileMu.
+func setActiveProfile(profile *JiraProfile) {
+	activeProfileMu.Lock()
+	defer activeProfileMu.Unlock()
+	activeProfile = profile
+}
+
+// currentProfile returns activeProfile for goroutines other than the one
+// that switches profiles, such as the window's ticker.
+func currentProfile() *JiraProfile {
+	activeProfileMu.RLock()
+	defer activeProfileMu.RUnlock()
+	return activeProfile
+}
+
+// jiraSite is the active profile with the site globals and client
+// applyProfile set from it, as one copy.
+type jiraSite struct {
+	profile    *JiraProfile
+	url        string
+	cloudID    string
+	graphQLURL string
+	client     *jiraApiFunctions.Client
+}
+
+// currentSite returns the active profile's site for goroutines other than
+// the one that switches profiles, such as those loading an issue or
+// posting queued worklogs, which keep using it if the profile changes.
+func currentSite() jiraSite {
+	activeProfileMu.RLock()
+	defer activeProfileMu.RUnlock()
+	return jiraSite{
+		profile:    activeProfile,
+		url:        jiraSiteUrl,
+		cloudID:    jiraCloudId,
+		graphQLURL: jiraGraphQlBaseUri,
+		client:     jiraApiFunctions.DefaultClient,
+	}
+}
+
+// setCloudID records the cloud ID discovered for profile, unless another
+// profile has been applied since.
+func setCloudID(profile *JiraProfile, cloudID string) {
+	activeProfileMu.Lock()
+	defer activeProfileMu.Unlock()
+	if activeProfile == profile && jiraCloudId == "" {
+		jiraCloudId = cloudID
+	}
+}
+
+// usesOAuth reports whether the profile logs in with OAuth rather than an
+// API token.
+func (p *JiraProfile) usesOAuth() bool {
+	return p.Auth == authModeOAuth
+}
+
+// isDataCenter reports whether the profile's site runs Jira Data Center or
+// Server rather than Cloud.
+func (p *JiraProfile) isDataCenter() bool {
+	deployment, _ := jiraApiFunctions.ParseDeployment(p.Deployment)
+	return deployment == jiraApiFunctions.DeploymentDataCenter
+}
+
+// newClient returns an API client for the profile's site. OAuth profiles
+// reach it through the API gateway, which needs the cloud ID.
+func (p *JiraProfile) newClient() *jiraApiFunctions.Client {
+	var client *jiraApiFunctions.Client
+	if p.usesOAuth() {
+		client = jiraApiFunctions.NewOAuthClient(p.OAuth.apiURL(), p.CloudID, nil)
+		if p.oauthTokens != nil {
+			client.Tokens = p.oauthTokens
+		}
+	} else {
+		siteURL, _ := normalizeSiteURL(p.Site)
+		client = jiraApiFunctions.NewClient(siteURL, p.Email, p.APIKey)
+		client.Deployment, _ = jiraApiFunctions.ParseDeployment(p.Deployment)
+	}
+	if p.Timeout != "" {
+		if d, err := time.ParseDuration(p.Timeout); err == nil {
+			if d == 0 {
+				d = -1
+			}
+			client.Timeout = d
+		} else {
+			log.Printf("Ignoring invalid timeout %q in profile %s: %v", p.Timeout, p.Name, err)
+		}
+	}
+	if jiraTransport != nil {
+		client.HTTPClient = &http.Client{Transport: jiraTransport}
+	}
+	if p.Cache {
+		if dir, err := os.UserCacheDir(); err == nil {
+			client.Cache = jiraApiFunctions.NewResponseCache(filepath.Join(dir, "jira-time", p.Name))
+		} else {
+			log.Printf("Not caching responses for profile %s: %v", p.Name, err)
+		}
+	}
+	return client
+}
+
+// profileClient returns a client for the named profile, so work queued
+// under one profile still goes to its own site after switching to another.
+func profileClient(name string) (*jiraApiFunctions.Client, error) {
+	if jiraProfiles == nil {
+		return currentSite().client, nil
+	}
+	profile, err := jiraProfiles.Profile(name)
+	if err != nil {
+		return nil, err
+	}
+	return profile.newClient(), nil
+}
+
+// worklogComment returns comment, or the profile's default when it is empty.
+func (p *JiraProfile) worklogComment(comment string) string {
+	if comment != "" {
+		return comment
+	}
+	if p.WorklogComment != "" {
+		return p.WorklogComment
+	}
+	return "Time tracked via JiraTimeWidget"
+}
+
+// roundWorklog rounds a timed duration up to the profile's increment.
+func (p *JiraProfile) roundWorklog(d time.Duration) time.Duration {
+	if p.WorklogRoundTo == "" {
+		return d
+	}
+	step, err := time.ParseDuration(p.WorklogRoundTo)
+	if err != nil || step <= 0 {
+		return d
+	}
+	if rem := d % step; rem != 0 {
+		d += step - rem
+	}
+	return d
+}
+
+// owns reports whether something tagged with the profile name entryProfile
+// belongs to p. Entries from before profiles existed belong to the default
+// profile.
+func (p *JiraProfile) owns(entryProfile string) bool {
+	if entryProfile == "" {
+		return p.isDefault
+	}
+	return entryProfile == p.Name
+}
+
+// extractProfileFlag removes --profile NAME (or --profile=NAME, with one or
+// two dashes) from args, so it can be given before or after a subcommand.
+func extractProfileFlag(args []string) ([]string, string, error) {
+	var rest []string
+	profile := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name := strings.TrimLeft(arg, "-")
+		switch {
+		case arg == "--":
+			return append(rest, args[i:]...), profile, nil
+		case strings.HasPrefix(arg, "-") && name == "profile":
+			if i+1 >= len(args) {
+				return nil, "", fmt.Errorf("%s needs a profile name", arg)
+			}
+			profile = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "-") && strings.HasPrefix(name, "profile="):
+			profile = strings.TrimPrefix(name, "profile=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, profile, nil
+}
@@ -1,14 +0,0 @@
-package main
-
-const CurrentUserQuery = `query currentUser {
-  me {
-    user {
-      ... on AtlassianAccountUser {
-        accountId
-        accountStatus
-        name
-        picture
-      }
-    }
-  }
-}`
\ No newline at end of file
@@ -1,61 +1,55 @@
 package main
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"jiraTimeWidget/jiraApiFunctions"
 	"log"
 )
 
+// defaultRecentIssuesJQL picks the recently updated issues assigned to the
+// current user. Profiles can replace it with their own "jql".
+const defaultRecentIssuesJQL = "assignee = currentUser() AND (status != Closed OR updated >= -7d) AND status NOT IN (Deferred, \"On Hold\") ORDER BY updated DESC"
+
 type RecentIssue struct {
 	Key     string `json:"key"`
 	Summary string `json:"summary"`
 	Status  string `json:"status"`
 }
 
-func getRecentIssues(maxResults int) []RecentIssue {
+func getRecentIssues(ctx context.Context, maxResults int) []RecentIssue {
 	// Search for recently updated issues assigned to current user
 	// Filter criteria:
 	// - Assigned to current user
 	// - Either not closed, OR closed but updated within last 7 days
 	// - Exclude deferred and on hold issues (not actively worked on)
 	// - Ordered by most recently updated first
-	searchParams := map[string]string{
-		"jql":        "assignee = currentUser() AND (status != Closed OR updated >= -7d) AND status NOT IN (Deferred, \"On Hold\") ORDER BY updated DESC",
-		"maxResults": fmt.Sprintf("%d", maxResults),
-		"fields":     "key,summary,status",
-	}
-	
-	result, err := jiraApiFunctions.MakeJiraAPICall("GET", "/rest/api/3/search/jql", nil, searchParams)
-	if err != nil {
-		log.Printf("Error fetching recent issues: %v", err)
-		return nil
-	}
-	
-	var searchResult struct {
-		Issues []struct {
-			Key    string `json:"key"`
-			Fields struct {
-				Summary string `json:"summary"`
-				Status  struct {
-					Name string `json:"name"`
-				} `json:"status"`
-			} `json:"fields"`
-		} `json:"issues"`
+	site := currentSite()
+	jql := site.profile.JQL
+	if jql == "" {
+		jql = defaultRecentIssuesJQL
 	}
+	fields := []string{"key", "summary", "status"}
 	
-	if err := json.Unmarshal(result, &searchResult); err != nil {
-		log.Printf("Error parsing recent issues: %v", err)
-		return nil
-	}
+	// Jira may return fewer issues per page than asked for, so keep
+	// reading pages until maxResults issues have been collected
+	opts := jiraApiFunctions.PageOptions{MaxItems: maxResults}
+	it := site.client.Search(ctx, jql, fields, opts)
 	
 	var recentIssues []RecentIssue
-	for _, issue := range searchResult.Issues {
-		recentIssues = append(recentIssues, RecentIssue{
+	for it.Next() {
+		issue := it.Value()
+		recentIssue := RecentIssue{
 			Key:     issue.Key,
 			Summary: issue.Fields.Summary,
-			Status:  issue.Fields.Status.Name,
-		})
+		}
+		if issue.Fields.Status != nil {
+			recentIssue.Status = issue.Fields.Status.Name
+		}
+		recentIssues = append(recentIssues, recentIssue)
+	}
+	if err := it.Err(); err != nil {
+		log.Printf("Error fetching recent issues: %v", err)
+		return nil
 	}
 	
 	return recentIssues
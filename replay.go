@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"jiraTimeWidget/jiraApiFunctions"
+	"log"
+	"net/http"
+	"os"
+)
+
+// Environment variables that send Jira traffic through a cassette:
+// JIRA_TIME_RECORD captures it, redacted, while talking to the configured
+// site, and JIRA_TIME_REPLAY answers every request from a recording
+// instead of the network.
+const (
+	recordEnv = "JIRA_TIME_RECORD"
+	replayEnv = "JIRA_TIME_REPLAY"
+)
+
+// replayProfileName names the profile used in replay mode.
+const replayProfileName = "replay"
+
+// jiraTransport, when set, carries the requests of every client the
+// profiles create.
+var jiraTransport http.RoundTripper
+
+// replayDataDir holds the time log and outbox in replay mode, so a demo
+// never touches the real ones.
+var replayDataDir string
+
+// dataDir returns the directory for the time log and outbox: the user's
+// home, or a scratch directory in replay mode.
+func dataDir() (string, error) {
+	if replayDataDir != "" {
+		return replayDataDir, nil
+	}
+	return os.UserHomeDir()
+}
+
+// setupReplay points the widget at the cassette at path in place of
+// .jirarc. The cassette's tenant info supplies the cloud ID.
+func setupReplay(ctx context.Context, path string) error {
+	cassette, err := jiraApiFunctions.LoadCassette(path)
+	if err != nil {
+		return err
+	}
+	if replayDataDir == "" {
+		if replayDataDir, err = os.MkdirTemp("", "jira-time-replay"); err != nil {
+			return err
+		}
+	}
+	log.Printf("📼 Replaying Jira responses from %s, keeping the time log in %s", path, replayDataDir)
+	jiraTransport = jiraApiFunctions.NewReplayer(cassette)
+	jiraProfiles = nil
+	applyProfile(&JiraProfile{Name: replayProfileName, Site: cassette.Site, APIKey: replayProfileName, isDefault: true})
+	return validateJiraConfig(ctx)
+}
+
+// startRecording records Jira traffic to the JIRA_TIME_RECORD cassette,
+// if one is named.
+func startRecording() {
+	path := os.Getenv(recordEnv)
+	if path == "" || jiraTransport != nil {
+		return
+	}
+	log.Printf("📼 Recording Jira traffic to %s", path)
+	jiraTransport = jiraApiFunctions.NewRecorder(path, nil)
+}
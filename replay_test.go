@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"jiraTimeWidget/jiraApiFunctions"
+	"testing"
+	"time"
+)
+
+func TestReplayDemoCassette(t *testing.T) {
+	withSiteGlobals(t)
+	t.Setenv(replayEnv, "testdata/cassettes/demo.json")
+	t.Cleanup(func() { replayDataDir = "" })
+	replayDataDir = t.TempDir()
+	ctx := context.Background()
+
+	if err := setupJiraConfig(ctx); err != nil {
+		t.Fatalf("setupJiraConfig failed in replay mode: %v", err)
+	}
+	if activeProfile.Name != replayProfileName || jiraCloudId != jiraApiFunctions.CassetteCloudID {
+		t.Errorf("Expected the replay profile with the recorded cloud ID, got %s and %q", activeProfile.Name, jiraCloudId)
+	}
+
+	issues := getRecentIssues(ctx, 20)
+	if len(issues) != 2 || issues[0].Key != "DEMO-1" || issues[1].Status != "To Do" {
+		t.Errorf("Unexpected recent issues %+v", issues)
+	}
+
+	details, err := loadIssueDetails(ctx, "DEMO-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.Status.Name != "In Progress" || len(details.Transitions) != 2 || details.describeTimeTracking() != "2h 30m logged of 8h" {
+		t.Errorf("Unexpected details %+v", details)
+	}
+
+	// A worklog for another time still gets the recorded answer
+	id, err := logWorkToJira(ctx, jiraApiFunctions.DefaultClient, "DEMO-1", "45m", "Demo", time.Now())
+	if err != nil || id != "20001" {
+		t.Errorf("logWorkToJira = %q, %v", id, err)
+	}
+	if err := ExecuteStatusTransition(ctx, "DEMO-1", "31"); err != nil {
+		t.Fatal(err)
+	}
+	details, err = loadIssueDetails(ctx, "DEMO-1")
+	if err != nil || details.Status.Name != "Done" {
+		t.Errorf("Expected the recorded status after the transition, got %+v, %v", details, err)
+	}
+
+	if _, err := loadIssueDetailsREST(ctx, jiraApiFunctions.DefaultClient, "DEMO-9"); !jiraApiFunctions.IsNotFound(err) {
+		t.Errorf("Expected a 404 for an issue that wasn't recorded, got %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"io/fs"
+	"jiraTimeWidget/jiraApiFunctions"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// tenantInfoTimeout bounds cloud ID discovery at startup, so an offline
+// start isn't held up for long.
+const tenantInfoTimeout = 5 * time.Second
+
+// normalizeSiteURL turns what a user might paste into .jirarc, such as
+// "example.atlassian.net/" or a GraphQL gateway URL, into a site root like
+// https://example.atlassian.net.
+func normalizeSiteURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", errors.New("site URL is empty")
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid site URL %q: %w", raw, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("invalid site URL %q: scheme must be http or https", raw)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid site URL %q: missing host name", raw)
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+	path = strings.TrimSuffix(path, "/gateway/api/graphql")
+	path = strings.TrimSuffix(path, "/browse")
+	return u.Scheme + "://" + u.Host + path, nil
+}
+
+// setupJiraConfig loads .jirarc and checks that the selected profile is
+// usable. A missing file is reported as missing settings. In replay mode
+// the recorded cassette stands in for .jirarc.
+func setupJiraConfig(ctx context.Context) error {
+	if path := os.Getenv(replayEnv); path != "" {
+		return setupReplay(ctx, path)
+	}
+	startRecording()
+	if err := loadJiraConfig(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := validateJiraConfig(ctx); err != nil {
+		if jiraProfiles != nil && len(jiraProfiles.Profiles) > 1 {
+			return fmt.Errorf("profile %s: %w", activeProfile.Name, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// validateJiraConfig checks that .jirarc names a site and credentials, and
+// fills in the cloud ID from a Cloud site when it isn't configured. A site
+// that can't be reached is only logged, so the widget still starts offline.
+func validateJiraConfig(ctx context.Context) error {
+	if jiraSiteUrl == "" {
+		return errors.New(`no Jira site configured. Add your site to ~/.jirarc, e.g. "site": "https://your-domain.atlassian.net"`)
+	}
+	if activeProfile.usesOAuth() {
+		return validateOAuthProfile()
+	}
+	if jiraApiKey == "" && activeProfile.TokenRef != "" {
+		return fmt.Errorf(`no token is stored as %q. Run "jira-time auth login" to save one`, activeProfile.TokenRef)
+	}
+	if jiraApiKey == "" && activeProfile.isDataCenter() {
+		return errors.New(`no Personal Access Token configured. Create one under your Jira profile, then run "jira-time auth login"`)
+	}
+	if jiraApiKey == "" {
+		return errors.New(`no Jira API token configured. Run "jira-time auth login", or add it to ~/.jirarc as "jira": "<token>"`)
+	}
+
+	if err := resolveCloudID(ctx, currentSite()); err != nil {
+		if jiraApiFunctions.IsNotFound(err) {
+			return fmt.Errorf("%s doesn't look like a Jira Cloud site. Check \"site\" in ~/.jirarc", jiraSiteUrl)
+		}
+		log.Printf("Warning: could not discover the cloud ID for %s: %v", jiraSiteUrl, err)
+	}
+	return nil
+}
+
+// validateOAuthProfile checks that auth login has been run for an OAuth
+// profile. The cloud ID comes from the login, since OAuth clients can only
+// reach the site through it.
+func validateOAuthProfile() error {
+	if activeProfile.oauthTokens == nil {
+		return errors.New(`not logged in with OAuth. Run "jira-time auth login" to authorise this profile`)
+	}
+	if jiraCloudId == "" {
+		return errors.New(`the site's cloud ID is unknown. Run "jira-time auth login" again, or set "cloudId" in ~/.jirarc`)
+	}
+	return nil
+}
+
+// resolveCloudID sets jiraCloudId from site's tenant info unless .jirarc
+// already provided it. Data Center sites have no cloud ID. The cloud ID is
+// only kept if site's profile is still active once the site has answered.
+func resolveCloudID(ctx context.Context, site jiraSite) error {
+	if site.cloudID != "" || site.profile.isDataCenter() {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, tenantInfoTimeout)
+	defer cancel()
+
+	cloudID, err := discoverCloudID(ctx, site.client)
+	if err != nil {
+		return err
+	}
+	setCloudID(site.profile, cloudID)
+	return nil
+}
+
+// discoverCloudID asks the site which Atlassian cloud it belongs to.
+func discoverCloudID(ctx context.Context, client *jiraApiFunctions.Client) (string, error) {
+	response, err := client.GetTenantInfoContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	var tenant struct {
+		CloudID string `json:"cloudId"`
+	}
+	if err := json.Unmarshal(response, &tenant); err != nil {
+		return "", fmt.Errorf("unexpected tenant info response: %w", err)
+	}
+	if tenant.CloudID == "" {
+		return "", errors.New("tenant info response has no cloud ID")
+	}
+	return tenant.CloudID, nil
+}
+
+// showConfigError explains a configuration problem in a dialog and quits
+// the app once it is dismissed.
+func showConfigError(a fyne.App, w fyne.Window, err error) {
+	w.Resize(fyne.NewSize(450, 200))
+
+	errDialog := dialog.NewError(err, w)
+	errDialog.SetOnClosed(a.Quit)
+	errDialog.Show()
+}
+
+// askCredentialPassphrase asks for the passphrase of the encrypted
+// credential file, then loads the configuration and calls onUnlocked.
+func askCredentialPassphrase(a fyne.App, w fyne.Window, onUnlocked func()) {
+	w.Resize(fyne.NewSize(450, 200))
+
+	entry := widget.NewPasswordEntry()
+	items := []*widget.FormItem{widget.NewFormItem("Passphrase", entry)}
+	form := dialog.NewForm("Unlock Jira credentials", "Unlock", "Quit", items, func(unlock bool) {
+		if !unlock {
+			a.Quit()
+			return
+		}
+
+		credentialPassphrase = entry.Text
+		err := setupJiraConfig(context.Background())
+		switch {
+		case errors.Is(err, errWrongPassphrase):
+			credentialPassphrase = ""
+			errDialog := dialog.NewError(err, w)
+			errDialog.SetOnClosed(func() { askCredentialPassphrase(a, w, onUnlocked) })
+			errDialog.Show()
+		case err != nil:
+			log.Printf("Configuration error: %v", err)
+			showConfigError(a, w, err)
+		default:
+			onUnlocked()
+		}
+	}, w)
+	form.Resize(fyne.NewSize(400, 150))
+	form.Show()
+}
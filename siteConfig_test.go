@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"jiraTimeWidget/jiraApiFunctions"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withSiteGlobals restores the site and profile globals after a test.
+func withSiteGlobals(t *testing.T) {
+	site, graphQL, cloudID, key := jiraSiteUrl, jiraGraphQlBaseUri, jiraCloudId, jiraApiKey
+	client := jiraApiFunctions.DefaultClient
+	profiles, profile, selected := jiraProfiles, activeProfile, selectedProfileName
+	transport := jiraTransport
+	t.Cleanup(func() {
+		jiraSiteUrl, jiraGraphQlBaseUri, jiraCloudId, jiraApiKey = site, graphQL, cloudID, key
+		jiraApiFunctions.DefaultClient = client
+		jiraProfiles, activeProfile, selectedProfileName = profiles, profile, selected
+		jiraTransport = transport
+	})
+}
+
+func TestNormalizeSiteURL(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"https://example.atlassian.net", "https://example.atlassian.net"},
+		{"example.atlassian.net/", "https://example.atlassian.net"},
+		{" https://example.atlassian.net/gateway/api/graphql ", "https://example.atlassian.net"},
+		{"https://example.atlassian.net/browse/", "https://example.atlassian.net"},
+		{"http://jira.internal:8080/jira", "http://jira.internal:8080/jira"},
+	} {
+		got, err := normalizeSiteURL(tc.in)
+		if err != nil {
+			t.Errorf("normalizeSiteURL(%q) failed: %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("normalizeSiteURL(%q) = %q, expected %q", tc.in, got, tc.want)
+		}
+	}
+
+	for _, bad := range []string{"", "   ", "ftp://example.com", "https://"} {
+		if _, err := normalizeSiteURL(bad); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
+
+func TestLoadJiraConfig_Site(t *testing.T) {
+	withSiteGlobals(t)
+	tempDir := t.TempDir()
+	config := `{"jira":"key","site":"example.atlassian.net/","cloudId":"cloud-1"}`
+	os.WriteFile(filepath.Join(tempDir, ".jirarc"), []byte(config), 0644)
+
+	originalHomeDir := os.Getenv("HOME")
+	os.Setenv("HOME", tempDir)
+	defer os.Setenv("HOME", originalHomeDir)
+
+	loadJiraConfig()
+
+	if jiraSiteUrl != "https://example.atlassian.net" {
+		t.Errorf("Unexpected site %q", jiraSiteUrl)
+	}
+	if jiraGraphQlBaseUri != "https://example.atlassian.net/gateway/api/graphql" {
+		t.Errorf("Unexpected GraphQL URL %q", jiraGraphQlBaseUri)
+	}
+	if jiraCloudId != "cloud-1" {
+		t.Errorf("Unexpected cloud ID %q", jiraCloudId)
+	}
+	if jiraApiFunctions.DefaultClient.BaseURL != jiraSiteUrl {
+		t.Errorf("Expected the default client to use %s, got %s", jiraSiteUrl, jiraApiFunctions.DefaultClient.BaseURL)
+	}
+}
+
+func TestValidateJiraConfig_DiscoversCloudID(t *testing.T) {
+	withSiteGlobals(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_edge/tenant_info" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"cloudId":"discovered-id"}`))
+	}))
+	defer server.Close()
+
+	jiraSiteUrl, jiraApiKey, jiraCloudId = server.URL, "key", ""
+	jiraApiFunctions.DefaultClient = jiraApiFunctions.NewClient(server.URL, "", "key")
+
+	if err := validateJiraConfig(context.Background()); err != nil {
+		t.Fatalf("Expected valid config, got %v", err)
+	}
+	if jiraCloudId != "discovered-id" {
+		t.Errorf("Expected discovered cloud ID, got %q", jiraCloudId)
+	}
+}
+
+// A cloud ID discovered for a profile the user has since switched away from
+// is dropped rather than applied to the new profile.
+func TestResolveCloudID_AfterSwitch(t *testing.T) {
+	withSiteGlobals(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"cloudId":"work-id"}`))
+	}))
+	defer server.Close()
+	work := &JiraProfile{Name: "work", Site: server.URL, APIKey: "key"}
+	home := &JiraProfile{Name: "home", Site: "https://home.atlassian.net", APIKey: "key", CloudID: "home-id"}
+
+	applyProfile(work)
+	site := currentSite()
+	applyProfile(home)
+	if err := resolveCloudID(context.Background(), site); err != nil {
+		t.Fatal(err)
+	}
+	if got := currentSite(); got.cloudID != "home-id" || got.url != "https://home.atlassian.net" {
+		t.Errorf("Expected the home site to keep its cloud ID, got %q for %s", got.cloudID, got.url)
+	}
+
+	applyProfile(work)
+	if err := resolveCloudID(context.Background(), currentSite()); err != nil {
+		t.Fatal(err)
+	}
+	if jiraCloudId != "work-id" {
+		t.Errorf("Expected the work site's cloud ID, got %q", jiraCloudId)
+	}
+}
+
+func TestValidateJiraConfig_Errors(t *testing.T) {
+	withSiteGlobals(t)
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	jiraApiFunctions.DefaultClient = jiraApiFunctions.NewClient(server.URL, "", "key")
+
+	for _, tc := range []struct {
+		site, key, want string
+	}{
+		{"", "key", "no Jira site configured"},
+		{server.URL, "", "no Jira API token configured"},
+		{server.URL, "key", "doesn't look like a Jira Cloud site"},
+	} {
+		jiraSiteUrl, jiraApiKey, jiraCloudId = tc.site, tc.key, ""
+		err := validateJiraConfig(context.Background())
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Expected error containing %q, got %v", tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/exec"
+)
+
+// disableEcho stops the terminal on f from echoing typed characters and
+// returns a function that turns echo back on. It does nothing when f is not
+// a terminal.
+func disableEcho(f *os.File) func() {
+	if info, err := f.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return func() {}
+	}
+	stty := func(arg string) error {
+		cmd := exec.Command("stty", arg)
+		cmd.Stdin = f
+		return cmd.Run()
+	}
+	if stty("-echo") != nil {
+		return func() {}
+	}
+	return func() { stty("echo") }
+}
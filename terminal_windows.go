@@ -0,0 +1,24 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// disableEcho stops the console on f from echoing typed characters and
+// returns a function that turns echo back on. It does nothing when f is not
+// a console.
+func disableEcho(f *os.File) func() {
+	handle := windows.Handle(f.Fd())
+	var mode uint32
+	if windows.GetConsoleMode(handle, &mode) != nil {
+		return func() {}
+	}
+	if windows.SetConsoleMode(handle, mode&^windows.ENABLE_ECHO_INPUT) != nil {
+		return func() {}
+	}
+	return func() { windows.SetConsoleMode(handle, mode) }
+}
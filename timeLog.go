@@ -1,79 +1,300 @@
 package main
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"time"
 )
 
+// SyncStatus records whether a local entry made it to Jira.
+type SyncStatus string
+
+const (
+	SyncPending SyncStatus = "pending" // saved locally, Jira call not finished
+	SyncSynced  SyncStatus = "synced"  // Jira accepted the worklog
+	SyncFailed  SyncStatus = "failed"  // Jira call failed, see LastError
+)
+
 type TimeLogEntry struct {
-	JiraID    string    `json:"jiraId"`
-	Summary   string    `json:"summary"`
-	StartTime time.Time `json:"startTime"`
-	EndTime   time.Time `json:"endTime"`
-	Duration  string    `json:"duration"`
-	Comment   string    `json:"comment"`
-	LoggedAt  time.Time `json:"loggedAt"`
+	ID         string     `json:"id"`
+	JiraID     string     `json:"jiraId"`
+	Summary    string     `json:"summary"`
+	StartTime  time.Time  `json:"startTime"`
+	EndTime    time.Time  `json:"endTime"`
+	Duration   string     `json:"duration"`
+	Comment    string     `json:"comment"`
+	LoggedAt   time.Time  `json:"loggedAt"`
+	SyncStatus SyncStatus `json:"syncStatus,omitempty"`
+	WorklogID  string     `json:"worklogId,omitempty"` // ID Jira assigned to the worklog
+	LastError  string     `json:"lastError,omitempty"`
+	Profile    string     `json:"profile,omitempty"` // .jirarc profile the entry was logged under
+
+	// Segments are the stretches a paused and resumed timer ran for.
+	Segments []TimeSegment `json:"segments,omitempty"`
+}
+
+// TimeSegment is a stretch of uninterrupted work within an entry.
+type TimeSegment struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// spent returns the time an entry logged: its duration as entered, or the
+// timed interval when that can't be read.
+func (e TimeLogEntry) spent() time.Duration {
+	if d := parseDuration(e.Duration); d > 0 {
+		return d
+	}
+	return e.EndTime.Sub(e.StartTime)
+}
+
+// markSynced records a successful post of the entry to Jira.
+func (e *TimeLogEntry) markSynced(worklogID string) {
+	e.SyncStatus = SyncSynced
+	e.WorklogID = worklogID
+	e.LastError = ""
+}
+
+// markFailed records a failed attempt to post the entry to Jira.
+func (e *TimeLogEntry) markFailed(err error) {
+	e.SyncStatus = SyncFailed
+	e.LastError = err.Error()
 }
 
-func saveTimeLogEntry(entry TimeLogEntry) error {
-	homeDir, err := os.UserHomeDir()
+// TimeLogStore persists work logged locally. Implementations must be safe
+// to share between processes, since the GUI and the CLI use the same store.
+type TimeLogStore interface {
+	// Put records entry, replacing any earlier entry with the same ID.
+	Put(entry TimeLogEntry) error
+	// Entries returns every stored entry in the order it was first recorded.
+	Entries() ([]TimeLogEntry, error)
+	// Compact drops superseded records from the underlying storage.
+	Compact() error
+}
+
+const (
+	timeLogFileName       = ".jira_time_log.jsonl"
+	legacyTimeLogFileName = ".jira_time_log.json"
+
+	// Compaction kicks in once superseded records outnumber live ones and
+	// there are enough of them to be worth a rewrite.
+	timeLogCompactMinStale = 256
+)
+
+// journalTimeLogStore is a TimeLogStore backed by an append-only journal.
+// Every Put appends the full entry; the latest record for an ID wins.
+type journalTimeLogStore struct {
+	journal journal
+}
+
+// openTimeLogStore opens the time log in the user's home directory,
+// importing the old single-array log file on first use.
+func openTimeLogStore() (TimeLogStore, error) {
+	homeDir, err := dataDir()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	
-	logFile := filepath.Join(homeDir, ".jira_time_log.json")
-	
-	// Read existing entries
-	var entries []TimeLogEntry
-	if data, err := os.ReadFile(logFile); err == nil {
-		json.Unmarshal(data, &entries)
-	}
-	
-	// Add new entry
-	entries = append(entries, entry)
-	
-	// Write back to file
-	data, err := json.MarshalIndent(entries, "", "  ")
+
+	store := newJournalTimeLogStore(filepath.Join(homeDir, timeLogFileName))
+	if err := store.migrateLegacy(filepath.Join(homeDir, legacyTimeLogFileName)); err != nil {
+		return nil, fmt.Errorf("migrating %s: %w", legacyTimeLogFileName, err)
+	}
+	if err := store.compactIfNeeded(); err != nil {
+		log.Printf("Warning: failed to compact time log: %v", err)
+	}
+	return store, nil
+}
+
+func newJournalTimeLogStore(path string) *journalTimeLogStore {
+	return &journalTimeLogStore{journal: journal{path: path}}
+}
+
+func (s *journalTimeLogStore) Put(entry TimeLogEntry) error {
+	if entry.ID == "" {
+		return fmt.Errorf("time log entry for %s has no ID", entry.JiraID)
+	}
+
+	unlock, err := s.journal.lock(true)
 	if err != nil {
 		return err
 	}
-	
-	return os.WriteFile(logFile, data, 0644)
+	defer unlock()
+
+	return s.journal.appendRecords(entry)
 }
 
-func getTodaysTimeLog() ([]TimeLogEntry, error) {
-	homeDir, err := os.UserHomeDir()
+func (s *journalTimeLogStore) Entries() ([]TimeLogEntry, error) {
+	unlock, err := s.journal.lock(false)
 	if err != nil {
 		return nil, err
 	}
-	
-	logFile := filepath.Join(homeDir, ".jira_time_log.json")
-	
+	defer unlock()
+
+	entries, _, err := s.load()
+	return entries, err
+}
+
+func (s *journalTimeLogStore) Compact() error {
+	unlock, err := s.journal.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, _, err := s.load()
+	if err != nil {
+		return err
+	}
+	return s.rewrite(entries)
+}
+
+// load folds the journal into its live entries and reports how many records
+// it read. The caller must hold a lock.
+func (s *journalTimeLogStore) load() ([]TimeLogEntry, int, error) {
 	var entries []TimeLogEntry
-	if data, err := os.ReadFile(logFile); err == nil {
-		json.Unmarshal(data, &entries)
+	index := make(map[string]int)
+
+	count, err := s.journal.readRecords(func(record json.RawMessage) error {
+		var entry TimeLogEntry
+		if err := json.Unmarshal(record, &entry); err != nil {
+			log.Printf("Skipping unreadable time log record: %v", err)
+			return nil
+		}
+		if i, exists := index[entry.ID]; exists {
+			entries[i] = entry
+			return nil
+		}
+		index[entry.ID] = len(entries)
+		entries = append(entries, entry)
+		return nil
+	})
+	return entries, count, err
+}
+
+func (s *journalTimeLogStore) rewrite(entries []TimeLogEntry) error {
+	records := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		records[i] = entry
 	}
-	
+	return s.journal.rewrite(records)
+}
+
+// compactIfNeeded rewrites the journal when it has accumulated a lot of
+// superseded records.
+func (s *journalTimeLogStore) compactIfNeeded() error {
+	unlock, err := s.journal.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, count, err := s.load()
+	if err != nil {
+		return err
+	}
+	stale := count - len(entries)
+	if stale < timeLogCompactMinStale || stale < len(entries) {
+		return nil
+	}
+	log.Printf("Compacting time log: dropping %d superseded records", stale)
+	return s.rewrite(entries)
+}
+
+// migrateLegacy imports the pre-journal log, which stored every entry in a
+// single JSON array, and renames it out of the way. Imported entries get
+// IDs derived from their position so an interrupted migration can simply be
+// run again.
+func (s *journalTimeLogStore) migrateLegacy(legacyPath string) error {
+	if _, err := os.Stat(legacyPath); os.IsNotExist(err) {
+		return nil
+	}
+
+	unlock, err := s.journal.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Another process may have finished the migration while we waited.
+	data, err := os.ReadFile(legacyPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var legacy []TimeLogEntry
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			// Leave the file alone so nothing is lost; the user can fix it by hand.
+			return fmt.Errorf("%s is not a valid time log: %w", legacyPath, err)
+		}
+	}
+
+	existing, _, err := s.load()
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(existing))
+	for _, entry := range existing {
+		known[entry.ID] = true
+	}
+
+	var records []interface{}
+	for i, entry := range legacy {
+		entry.ID = fmt.Sprintf("legacy-%d", i)
+		if !known[entry.ID] {
+			records = append(records, entry)
+		}
+	}
+	if len(records) > 0 {
+		if err := s.journal.appendRecords(records...); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Imported %d entries from %s", len(legacy), legacyPath)
+	return os.Rename(legacyPath, legacyPath+".migrated")
+}
+
+func newTimeLogEntryID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand does not fail on supported platforms; fall back to the clock.
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+func getTodaysTimeLog(store TimeLogStore) ([]TimeLogEntry, error) {
+	entries, err := store.Entries()
+	if err != nil {
+		return nil, err
+	}
+
 	// Filter for today's entries
 	today := time.Now().Format("2006-01-02")
 	var todaysEntries []TimeLogEntry
-	
+
 	for _, entry := range entries {
 		if entry.LoggedAt.Format("2006-01-02") == today {
 			todaysEntries = append(todaysEntries, entry)
 		}
 	}
-	
+
 	return todaysEntries, nil
 }
 
 func formatDurationForJira(duration time.Duration) string {
 	hours := int(duration.Hours())
 	minutes := int(duration.Minutes()) % 60
-	
+
 	if hours > 0 && minutes > 0 {
 		return fmt.Sprintf("%dh %dm", hours, minutes)
 	} else if hours > 0 {
@@ -83,4 +304,4 @@ func formatDurationForJira(duration time.Duration) string {
 	} else {
 		return "1m" // Minimum 1 minute
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournalTimeLogStore_PutAndEntries(t *testing.T) {
+	store := newJournalTimeLogStore(filepath.Join(t.TempDir(), "log.jsonl"))
+
+	first := TimeLogEntry{ID: "a", JiraID: "TEST-1", Duration: "1h"}
+	second := TimeLogEntry{ID: "b", JiraID: "TEST-2", Duration: "30m"}
+	for _, entry := range []TimeLogEntry{first, second} {
+		if err := store.Put(entry); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	// A second Put for the same ID replaces the entry in place
+	first.Duration = "2h"
+	if err := store.Put(first); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	entries, err := store.Entries()
+	if err != nil {
+		t.Fatalf("Entries failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].ID != "a" || entries[0].Duration != "2h" {
+		t.Errorf("Expected first entry to be updated in place, got %+v", entries[0])
+	}
+	if entries[1].ID != "b" {
+		t.Errorf("Expected second entry 'b', got '%s'", entries[1].ID)
+	}
+}
+
+func TestJournalTimeLogStore_RejectsMissingID(t *testing.T) {
+	store := newJournalTimeLogStore(filepath.Join(t.TempDir(), "log.jsonl"))
+
+	if err := store.Put(TimeLogEntry{JiraID: "TEST-1"}); err == nil {
+		t.Error("Expected error for entry without ID")
+	}
+}
+
+func TestJournalTimeLogStore_SkipsTornWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.jsonl")
+	store := newJournalTimeLogStore(path)
+
+	if err := store.Put(TimeLogEntry{ID: "a", JiraID: "TEST-1"}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// Simulate a crash halfway through the next append
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"id":"b","jiraId":"TES`)
+	f.Close()
+
+	if err := store.Put(TimeLogEntry{ID: "c", JiraID: "TEST-3"}); err != nil {
+		t.Fatalf("Put after torn write failed: %v", err)
+	}
+
+	entries, err := store.Entries()
+	if err != nil {
+		t.Fatalf("Entries failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].ID != "a" || entries[1].ID != "c" {
+		t.Errorf("Expected entries a and c, got %+v", entries)
+	}
+}
+
+func TestJournalTimeLogStore_Compact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.jsonl")
+	store := newJournalTimeLogStore(path)
+
+	for i := 0; i < 5; i++ {
+		if err := store.Put(TimeLogEntry{ID: "a", Comment: strings.Repeat("x", i)}); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	if err := store.Put(TimeLogEntry{ID: "b"}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	if err := store.Compact(); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Errorf("Expected 2 records after compaction, got %d", len(lines))
+	}
+
+	entries, err := store.Entries()
+	if err != nil {
+		t.Fatalf("Entries failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Comment != "xxxx" {
+		t.Errorf("Expected latest entry to survive compaction, got %+v", entries)
+	}
+}
+
+func TestOpenTimeLogStore_MigratesLegacyFile(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHomeDir := os.Getenv("HOME")
+	os.Setenv("HOME", tempDir)
+	defer os.Setenv("HOME", originalHomeDir)
+
+	legacy := []TimeLogEntry{
+		{JiraID: "TEST-1", Duration: "1h", LoggedAt: time.Now()},
+		{JiraID: "TEST-2", Duration: "15m", LoggedAt: time.Now()},
+	}
+	data, _ := json.MarshalIndent(legacy, "", "  ")
+	legacyPath := filepath.Join(tempDir, legacyTimeLogFileName)
+	os.WriteFile(legacyPath, data, 0644)
+
+	store, err := openTimeLogStore()
+	if err != nil {
+		t.Fatalf("openTimeLogStore failed: %v", err)
+	}
+
+	entries, err := store.Entries()
+	if err != nil {
+		t.Fatalf("Entries failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].JiraID != "TEST-1" || entries[1].JiraID != "TEST-2" {
+		t.Fatalf("Expected migrated entries, got %+v", entries)
+	}
+	if entries[0].ID == "" {
+		t.Error("Expected migrated entries to be assigned IDs")
+	}
+
+	if _, err := os.Stat(legacyPath); !os.IsNotExist(err) {
+		t.Error("Expected legacy file to be moved aside")
+	}
+	if _, err := os.Stat(legacyPath + ".migrated"); err != nil {
+		t.Errorf("Expected migrated copy to exist: %v", err)
+	}
+
+	// Opening again must not duplicate anything
+	store, err = openTimeLogStore()
+	if err != nil {
+		t.Fatalf("second openTimeLogStore failed: %v", err)
+	}
+	entries, _ = store.Entries()
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 entries after reopening, got %d", len(entries))
+	}
+}
+
+func TestOpenTimeLogStore_KeepsCorruptLegacyFile(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHomeDir := os.Getenv("HOME")
+	os.Setenv("HOME", tempDir)
+	defer os.Setenv("HOME", originalHomeDir)
+
+	legacyPath := filepath.Join(tempDir, legacyTimeLogFileName)
+	os.WriteFile(legacyPath, []byte("[{invalid"), 0644)
+
+	if _, err := openTimeLogStore(); err == nil {
+		t.Error("Expected error for corrupt legacy file")
+	}
+	if _, err := os.Stat(legacyPath); err != nil {
+		t.Errorf("Expected corrupt legacy file to be left in place: %v", err)
+	}
+}
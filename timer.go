@@ -0,0 +1,439 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// timerFileName holds the timers, so a timer started in the window can
+	// be stopped from the command line and the other way round.
+	timerFileName = ".jira_timer.json"
+
+	// How often the window records that it saw the running timer.
+	timerHeartbeatInterval = time.Minute
+
+	// How often the elapsed time and window title are updated, and the gap
+	// between updates that means the computer was asleep.
+	timerTickInterval = time.Second
+	timerSleepGap     = time.Minute
+)
+
+var (
+	// errTimerRunning is returned when starting a timer on an issue that
+	// already has one.
+	errTimerRunning = errors.New("a timer is already running")
+
+	// errSeveralTimers is returned when no issue is named and there is more
+	// than one timer to choose from.
+	errSeveralTimers = errors.New("several timers are running")
+)
+
+// ActiveTimer is a timer running on an issue. It is saved on every change,
+// so neither closing the window nor a crash loses the tracked time.
+type ActiveTimer struct {
+	Issue   string       `json:"issue"`
+	Start   time.Time    `json:"start"`
+	Pauses  []TimerPause `json:"pauses,omitempty"`
+	Comment string       `json:"comment,omitempty"` // draft worklog comment
+	Profile string       `json:"profile,omitempty"` // .jirarc profile the issue belongs to
+
+	// LastSeen is when the window last saw the timer running. After a
+	// crash or a forgotten timer it is the best guess at when work stopped.
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// TimerPause is a break in a timer. End is zero while the timer is paused.
+type TimerPause struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Elapsed returns how long the timer has been running at now, leaving out
+// its pauses. It goes by the wall clock, since the monotonic clock stops
+// while the computer sleeps on some systems.
+func (t *ActiveTimer) Elapsed(now time.Time) time.Duration {
+	now = now.Round(0)
+	elapsed := now.Sub(t.Start.Round(0))
+	for _, pause := range t.Pauses {
+		end := pause.End
+		if end.IsZero() || end.After(now) {
+			end = now
+		}
+		if end.After(pause.Start) {
+			elapsed -= end.Round(0).Sub(pause.Start.Round(0))
+		}
+	}
+	if elapsed < 0 {
+		return 0
+	}
+	return elapsed
+}
+
+// Paused reports whether the timer is paused.
+func (t *ActiveTimer) Paused() bool {
+	n := len(t.Pauses)
+	return n > 0 && t.Pauses[n-1].End.IsZero()
+}
+
+// Pause pauses the timer at now. It returns false if it was already paused.
+func (t *ActiveTimer) Pause(now time.Time) bool {
+	if t.Paused() {
+		return false
+	}
+	t.Pauses = append(t.Pauses, TimerPause{Start: now})
+	return true
+}
+
+// AddPause records a break from start to end in the past, such as time
+// the computer spent asleep, keeping the pauses in order.
+func (t *ActiveTimer) AddPause(start, end time.Time) {
+	i := len(t.Pauses)
+	for i > 0 && t.Pauses[i-1].Start.After(start) {
+		i--
+	}
+	t.Pauses = append(t.Pauses, TimerPause{})
+	copy(t.Pauses[i+1:], t.Pauses[i:])
+	t.Pauses[i] = TimerPause{Start: start, End: end}
+}
+
+// Resume ends the pause at now. It returns false if it wasn't paused.
+func (t *ActiveTimer) Resume(now time.Time) bool {
+	if !t.Paused() {
+		return false
+	}
+	t.Pauses[len(t.Pauses)-1].End = now
+	return true
+}
+
+// Segments returns the stretches the timer ran for between its start and
+// end, split by its pauses.
+func (t *ActiveTimer) Segments(end time.Time) []TimeSegment {
+	var segments []TimeSegment
+	from := t.Start
+	for _, pause := range t.Pauses {
+		if pause.Start.After(from) {
+			segments = appendSegment(segments, from, pause.Start, end)
+		}
+		if pause.End.IsZero() {
+			return segments
+		}
+		if pause.End.After(from) {
+			from = pause.End
+		}
+	}
+	return appendSegment(segments, from, end, end)
+}
+
+// appendSegment adds the segment from start to stop, cut off at end, if
+// anything is left of it.
+func appendSegment(segments []TimeSegment, start, stop, end time.Time) []TimeSegment {
+	if stop.After(end) {
+		stop = end
+	}
+	if !stop.After(start) {
+		return segments
+	}
+	return append(segments, TimeSegment{Start: start, End: stop})
+}
+
+// lastSeen returns when the timer was last seen running, which is its
+// start if the window never saw it.
+func (t *ActiveTimer) lastSeen() time.Time {
+	if t.LastSeen.After(t.Start) {
+		return t.LastSeen
+	}
+	return t.Start
+}
+
+// logEntry returns the worklog for the timer stopped at end, in the active
+// profile. The timer's draft comment is used unless comment is given.
+func (t *ActiveTimer) logEntry(end time.Time, comment string) TimeLogEntry {
+	if comment == "" {
+		comment = t.Comment
+	}
+	entry := TimeLogEntry{
+		ID:         newTimeLogEntryID(),
+		JiraID:     t.Issue,
+		StartTime:  t.Start,
+		EndTime:    end,
+		Duration:   formatDurationForJira(activeProfile.roundWorklog(t.Elapsed(end))),
+		Comment:    activeProfile.worklogComment(comment),
+		LoggedAt:   time.Now(),
+		SyncStatus: SyncPending,
+		Profile:    activeProfile.Name,
+	}
+	if len(t.Pauses) > 0 {
+		entry.Segments = t.Segments(end)
+	}
+	return entry
+}
+
+// resumedAt returns when the timer last started running.
+func (t *ActiveTimer) resumedAt() time.Time {
+	if n := len(t.Pauses); n > 0 && t.Pauses[n-1].End.After(t.Start) {
+		return t.Pauses[n-1].End
+	}
+	return t.Start
+}
+
+// TimerStore keeps the timers in a small JSON file next to the time log.
+// Changes hold an advisory lock like the journals do, so the GUI and the
+// CLI never overwrite each other's timers.
+type TimerStore struct {
+	path string
+}
+
+// timerState is the content of the timer file: a timer per issue, in the
+// order they were started, and whether starting one pauses the others.
+type timerState struct {
+	AutoPause bool          `json:"autoPause,omitempty"`
+	Timers    []ActiveTimer `json:"timers"`
+}
+
+// find returns the index of the timer on issue, or -1 when there is none.
+// An empty issue means the only timer.
+func (st *timerState) find(issue string) (int, error) {
+	if issue == "" {
+		if len(st.Timers) > 1 {
+			return -1, fmt.Errorf("%w: %s", errSeveralTimers, strings.Join(st.issues(), ", "))
+		}
+		return len(st.Timers) - 1, nil
+	}
+	for i := range st.Timers {
+		if st.Timers[i].Issue == issue {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+func (st *timerState) issues() []string {
+	issues := make([]string, len(st.Timers))
+	for i, timer := range st.Timers {
+		issues[i] = timer.Issue
+	}
+	return issues
+}
+
+// openTimerStore opens the timer state in the user's home directory.
+func openTimerStore() (*TimerStore, error) {
+	homeDir, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+	return newTimerStore(filepath.Join(homeDir, timerFileName)), nil
+}
+
+func newTimerStore(path string) *TimerStore {
+	return &TimerStore{path: path}
+}
+
+// Timers returns every timer, in the order they were started.
+func (s *TimerStore) Timers() ([]ActiveTimer, error) {
+	state, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return state.Timers, nil
+}
+
+// Timer returns the timer on issue, or nil when there is none. An empty
+// issue means the only timer, and errSeveralTimers is returned when there
+// are more.
+func (s *TimerStore) Timer(issue string) (*ActiveTimer, error) {
+	state, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	i, err := state.find(issue)
+	if err != nil || i < 0 {
+		return nil, err
+	}
+	return &state.Timers[i], nil
+}
+
+// AutoPause reports whether starting a timer pauses the others.
+func (s *TimerStore) AutoPause() (bool, error) {
+	state, err := s.read()
+	if err != nil {
+		return false, err
+	}
+	return state.AutoPause, nil
+}
+
+// SetAutoPause sets whether starting a timer pauses the others.
+func (s *TimerStore) SetAutoPause(on bool) error {
+	return s.update(func(state *timerState) error {
+		state.AutoPause = on
+		return nil
+	})
+}
+
+// Start adds timer alongside the other timers. It returns errTimerRunning
+// if its issue already has a timer. When pauseOthers or the auto-pause
+// setting is on, the other running timers are paused as it starts, and
+// their issues are returned.
+func (s *TimerStore) Start(timer ActiveTimer, pauseOthers bool) ([]string, error) {
+	var paused []string
+	err := s.update(func(state *timerState) error {
+		if i, _ := state.find(timer.Issue); i >= 0 {
+			current := state.Timers[i]
+			return fmt.Errorf("%w on %s since %s", errTimerRunning, current.Issue, current.Start.Format("15:04"))
+		}
+		if pauseOthers || state.AutoPause {
+			for i := range state.Timers {
+				other := &state.Timers[i]
+				at := timer.Start
+				if resumed := other.resumedAt(); at.Before(resumed) {
+					at = resumed
+				}
+				if other.Pause(at) {
+					paused = append(paused, other.Issue)
+				}
+			}
+		}
+		state.Timers = append(state.Timers, timer)
+		return nil
+	})
+	return paused, err
+}
+
+// Stop removes the timer on issue and returns it, or nil when there was
+// none. An empty issue means the only timer.
+func (s *TimerStore) Stop(issue string) (*ActiveTimer, error) {
+	var stopped *ActiveTimer
+	err := s.update(func(state *timerState) error {
+		i, err := state.find(issue)
+		if err != nil || i < 0 {
+			return err
+		}
+		timer := state.Timers[i]
+		stopped = &timer
+		state.Timers = append(state.Timers[:i], state.Timers[i+1:]...)
+		return nil
+	})
+	return stopped, err
+}
+
+// Update applies change to the timer on issue, or to the only timer when
+// issue is empty, and returns the updated timer. It returns nil when there
+// was nothing to update.
+func (s *TimerStore) Update(issue string, change func(*ActiveTimer)) (*ActiveTimer, error) {
+	var updated *ActiveTimer
+	err := s.update(func(state *timerState) error {
+		i, err := state.find(issue)
+		if err != nil || i < 0 {
+			return err
+		}
+		change(&state.Timers[i])
+		timer := state.Timers[i]
+		updated = &timer
+		return nil
+	})
+	return updated, err
+}
+
+// UpdateAll applies change to every timer and returns them.
+func (s *TimerStore) UpdateAll(change func(*ActiveTimer)) ([]ActiveTimer, error) {
+	var timers []ActiveTimer
+	err := s.update(func(state *timerState) error {
+		for i := range state.Timers {
+			change(&state.Timers[i])
+		}
+		timers = state.Timers
+		return nil
+	})
+	return timers, err
+}
+
+// read loads the timer file under a shared lock.
+func (s *TimerStore) read() (*timerState, error) {
+	unlock, err := acquireFileLock(s.path+".lock", false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return s.load()
+}
+
+// update applies change to the timers while holding the lock, and saves
+// them unless change fails.
+func (s *TimerStore) update(change func(*timerState) error) error {
+	unlock, err := acquireFileLock(s.path+".lock", true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := s.load()
+	if err != nil {
+		return err
+	}
+	if err := change(state); err != nil {
+		return err
+	}
+	if len(state.Timers) == 0 && !state.AutoPause {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return s.save(state)
+}
+
+// load reads the timer file. A file holding a single timer, as written
+// before there could be several, is read as a list of one. The caller
+// must hold a lock.
+func (s *TimerStore) load() (*timerState, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return &timerState{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state timerState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("%s is not a valid timer: %w", s.path, err)
+	}
+	if state.Timers == nil {
+		var single ActiveTimer
+		if err := json.Unmarshal(data, &single); err == nil && single.Issue != "" {
+			state.Timers = []ActiveTimer{single}
+		}
+	}
+	return &state, nil
+}
+
+// save writes the timer file through a synced temp file and a rename, so a
+// crash leaves either the old timers or the new ones. The caller must hold
+// the exclusive lock.
+func (s *TimerStore) save(state *timerState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"log"
+	"strings"
+	"time"
+)
+
+// windowTitle is the title while no timer is running.
+const windowTitle = "JiraWidgetLite"
+
+// wallNow returns the time without its monotonic clock reading, so the
+// difference between two readings includes time the computer was asleep.
+func wallNow() time.Time {
+	return time.Now().Round(0)
+}
+
+// formatElapsed shows a running time with seconds, e.g. "1:05:09".
+func formatElapsed(d time.Duration) string {
+	seconds := int(d / time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
+
+// timerTitle is the window title while a timer runs, e.g. "▶ PROJ-123 0:42".
+func timerTitle(issueKey string, elapsed time.Duration, paused bool) string {
+	icon := "▶"
+	if paused {
+		icon = "⏸"
+	}
+	minutes := int(elapsed / time.Minute)
+	return fmt.Sprintf("%s %s %d:%02d", icon, issueKey, minutes/60, minutes%60)
+}
+
+// elapsedView is a copy of the form's timer, taken by showElapsed, that
+// the ticker keeps the elapsed time going from without reading the form.
+type elapsedView struct {
+	timer   ActiveTimer
+	stopped time.Time // zero while the timer runs
+}
+
+// text is the elapsed time shown next to the start time at now, e.g.
+// "0:42:10 active, paused", or nothing without a timer.
+func (v elapsedView) text(now time.Time) string {
+	if v.timer.Start.IsZero() {
+		return ""
+	}
+	end := now
+	if !v.stopped.IsZero() {
+		end = v.stopped
+	}
+	text := formatElapsed(v.timer.Elapsed(end)) + " active"
+	if v.stopped.IsZero() && v.timer.Paused() {
+		text += ", paused"
+	}
+	return text
+}
+
+func setWindowTitle(ui *UIComponents, title string) {
+	if ui.MainWindow != nil && ui.MainWindow.Title() != title {
+		ui.MainWindow.SetTitle(title)
+	}
+}
+
+// asleepBetween reports whether the gap between two ticks means the
+// computer was asleep rather than busy.
+func asleepBetween(lastTick, now time.Time) bool {
+	return now.Round(0).Sub(lastTick.Round(0)) > timerSleepGap
+}
+
+// startTimerTicker updates the elapsed times and the window title every
+// second until the window closes. The ticker runs on its own goroutine, so
+// it only sets bindings, which widgets and the title follow, see
+// tickTimers. The timer list is read again when the window comes to the
+// front, in case timers were changed from the command line. The elapsed
+// time is worked out from the wall clock rather than counted in ticks, so
+// it is right after the computer wakes up, and a long sleep with timers
+// running is offered to be left out.
+func startTimerTicker(ui *UIComponents) {
+	refreshTimerList(ui)
+	fyne.CurrentApp().Lifecycle().SetOnEnteredForeground(func() {
+		refreshTimerList(ui)
+	})
+	ui.WindowTitle.AddListener(binding.NewDataListener(func() {
+		if title, err := ui.WindowTitle.Get(); err == nil && title != "" {
+			setWindowTitle(ui, title)
+		}
+	}))
+	ui.Asleep.AddListener(binding.NewDataListener(func() {
+		if gap, err := ui.Asleep.Get(); err == nil && gap != nil {
+			askAboutSleep(ui, gap.(*sleepGap))
+		}
+	}))
+	go func() {
+		ticker := time.NewTicker(timerTickInterval)
+		defer ticker.Stop()
+		lastTick := wallNow()
+		for {
+			select {
+			case <-ticker.C:
+				now := wallNow()
+				if asleepBetween(lastTick, now) {
+					if running := runningTimers(ui); len(running) > 0 {
+						log.Printf("💤 Asleep from %s to %s with %s running", lastTick.Format(time.RFC3339), now.Format(time.RFC3339), strings.Join(running, ", "))
+						noteSleep(ui, &sleepGap{issues: running, from: lastTick, to: now})
+					}
+				}
+				lastTick = now
+				tickTimers(ui, now)
+			case <-ui.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// runningTimers returns the issues of the active profile's timers that are
+// running and not paused.
+func runningTimers(ui *UIComponents) []string {
+	timers, err := ui.Timer.Timers()
+	if err != nil {
+		log.Printf("Warning: Failed to read timers: %v", err)
+		return nil
+	}
+	profile := currentProfile()
+	var running []string
+	for _, timer := range timers {
+		if profile.owns(timer.Profile) && !timer.Paused() {
+			running = append(running, timer.Issue)
+		}
+	}
+	return running
+}
+
+// sleepGap is time the computer spent asleep with the timers on issues
+// running.
+type sleepGap struct {
+	issues   []string
+	from, to time.Time
+}
+
+// noteSleep hands gap from the ticker to the window to ask about, through
+// ui.Asleep. While the window is still asking about an earlier sleep, gap
+// is left uncounted rather than raising a second prompt.
+func noteSleep(ui *UIComponents, gap *sleepGap) {
+	ui.mu.Lock()
+	asking := ui.askingAboutSleep
+	ui.askingAboutSleep = true
+	ui.mu.Unlock()
+	if asking {
+		log.Printf("Not asking about the sleep until %s, still asking about an earlier one", gap.to.Format(time.RFC3339))
+		return
+	}
+	ui.Asleep.Set(gap)
+}
+
+// askAboutSleep asks whether gap counts as work, pausing its timers for it
+// if not. It runs from ui.Asleep's listener, as the title is set from
+// ui.WindowTitle's, not from the ticker, and the form is only changed once
+// the answer comes back.
+func askAboutSleep(ui *UIComponents, gap *sleepGap) {
+	issues, from, to := gap.issues, gap.from, gap.to
+	asleep := make(map[string]bool)
+	for _, issueKey := range issues {
+		asleep[issueKey] = true
+	}
+	running := "timer on " + issues[0] + " was"
+	if len(issues) > 1 {
+		running = "timers on " + strings.Join(issues, ", ") + " were"
+	}
+	message := fmt.Sprintf("The computer was asleep from %s to %s\nwhile the %s running.\nCount that time as work?",
+		formatTimerTime(from, to), to.Format("15:04"), running)
+	confirm := dialog.NewConfirm("Welcome back", message, func(count bool) {
+		ui.mu.Lock()
+		ui.askingAboutSleep = false
+		ui.mu.Unlock()
+		if count {
+			return
+		}
+		// Timers stopped or restarted meanwhile are left alone
+		timers, err := ui.Timer.UpdateAll(func(timer *ActiveTimer) {
+			if asleep[timer.Issue] && timer.Start.Before(from) && !timer.Paused() {
+				timer.AddPause(from, to)
+			}
+		})
+		if err != nil {
+			log.Printf("Warning: Failed to save timer: %v", err)
+			return
+		}
+		for i := range timers {
+			if showsTimer(ui, &timers[i]) {
+				ui.Pauses = timers[i].Pauses
+			}
+		}
+		showElapsed(ui)
+		ui.StatusLabel.SetText(fmt.Sprintf("⏸️ Left out %s asleep", formatDurationForJira(to.Sub(from))))
+	}, ui.MainWindow)
+	confirm.SetConfirmText("Count it")
+	confirm.SetDismissText("Leave it out")
+	confirm.Show()
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fyne.io/fyne/v2/data/binding"
+	"testing"
+	"time"
+)
+
+func TestTimerTitle(t *testing.T) {
+	if got := timerTitle("PROJ-123", 42*time.Minute+59*time.Second, false); got != "▶ PROJ-123 0:42" {
+		t.Errorf("timerTitle = %q", got)
+	}
+	if got := timerTitle("PROJ-123", 2*time.Hour+5*time.Minute, true); got != "⏸ PROJ-123 2:05" {
+		t.Errorf("timerTitle while paused = %q", got)
+	}
+	if got := formatElapsed(time.Hour + 5*time.Minute + 9*time.Second + 500*time.Millisecond); got != "1:05:09" {
+		t.Errorf("formatElapsed = %q", got)
+	}
+}
+
+func TestTimersTitle(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	now := start.Add(90 * time.Minute)
+	paused := ActiveTimer{Issue: "PROJ-1", Start: start, Pauses: []TimerPause{{Start: start.Add(time.Hour)}}}
+	running := ActiveTimer{Issue: "OPS-7", Start: start.Add(time.Hour)}
+
+	if got := timersTitle(nil, now); got != windowTitle {
+		t.Errorf("timersTitle without timers = %q", got)
+	}
+	if got := timersTitle([]ActiveTimer{paused}, now); got != "⏸ PROJ-1 1:00" {
+		t.Errorf("timersTitle with a paused timer = %q", got)
+	}
+	// The running timer is shown ahead of a paused one
+	if got := timersTitle([]ActiveTimer{paused, running}, now); got != "▶ OPS-7 0:30 +1" {
+		t.Errorf("timersTitle with two timers = %q", got)
+	}
+}
+
+func TestElapsedView_Text(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	now := start.Add(90 * time.Minute)
+
+	if got := (elapsedView{}).text(now); got != "" {
+		t.Errorf("text without a timer = %q", got)
+	}
+	running := elapsedView{timer: ActiveTimer{Issue: "PROJ-1", Start: start}}
+	if got := running.text(now); got != "1:30:00 active" {
+		t.Errorf("text while running = %q", got)
+	}
+	paused := elapsedView{timer: ActiveTimer{Issue: "PROJ-1", Start: start, Pauses: []TimerPause{{Start: start.Add(time.Hour)}}}}
+	if got := paused.text(now); got != "1:00:00 active, paused" {
+		t.Errorf("text while paused = %q", got)
+	}
+	stopped := elapsedView{timer: running.timer, stopped: start.Add(time.Hour)}
+	if got := stopped.text(now); got != "1:00:00 active" {
+		t.Errorf("text once stopped = %q", got)
+	}
+}
+
+// The ticker works from the rows' copies of the timers, without reading the
+// store, which is left nil here.
+func TestTickTimers(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	now := start.Add(90 * time.Minute)
+	ui := &UIComponents{ElapsedText: binding.NewString(), WindowTitle: binding.NewString()}
+	ui.shownTimer = elapsedView{timer: ActiveTimer{Issue: "PROJ-1", Start: start}}
+	ui.timerRows = []timerRow{
+		{issue: "PROJ-1", timer: ActiveTimer{Issue: "PROJ-1", Start: start}, elapsed: binding.NewString()},
+		{issue: "OPS-7", timer: ActiveTimer{Issue: "OPS-7", Start: start.Add(time.Hour)}, elapsed: binding.NewString()},
+	}
+
+	tickTimers(ui, now)
+	if got, _ := ui.ElapsedText.Get(); got != "1:30:00 active" {
+		t.Errorf("ElapsedText = %q", got)
+	}
+	if got, _ := ui.timerRows[1].elapsed.Get(); got != "0:30:00" {
+		t.Errorf("OPS-7 row = %q", got)
+	}
+	if got, _ := ui.WindowTitle.Get(); got != "▶ PROJ-1 1:30 +1" {
+		t.Errorf("WindowTitle = %q", got)
+	}
+}
+
+// A sleep during the prompt about an earlier one doesn't raise another.
+func TestNoteSleep(t *testing.T) {
+	ui := &UIComponents{Asleep: binding.NewUntyped()}
+	wake := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	first := &sleepGap{issues: []string{"PROJ-1"}, from: wake.Add(-time.Hour), to: wake}
+
+	noteSleep(ui, first)
+	noteSleep(ui, &sleepGap{issues: []string{"PROJ-1"}, from: wake.Add(time.Minute), to: wake.Add(time.Hour)})
+	if got, _ := ui.Asleep.Get(); got != first {
+		t.Errorf("Asleep = %+v, want the first sleep", got)
+	}
+	if !ui.askingAboutSleep {
+		t.Error("Expected the window to be asking about the sleep")
+	}
+}
+
+func TestAsleepBetween(t *testing.T) {
+	lastTick := time.Now()
+	if asleepBetween(lastTick, lastTick.Add(3*time.Second)) {
+		t.Error("Expected a few seconds between ticks to be a busy machine, not sleep")
+	}
+	if !asleepBetween(lastTick, lastTick.Add(40*time.Minute)) {
+		t.Error("Expected a 40 minute gap between ticks to be sleep")
+	}
+}
+
+func TestActiveTimer_AddPauseWhileAsleep(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+	timer := ActiveTimer{Start: start}
+	timer.Pause(at(120))
+
+	// Sleep noticed after the user paused is still put in order
+	timer.AddPause(at(30), at(60))
+	if len(timer.Pauses) != 2 || !timer.Pauses[0].Start.Equal(at(30)) || !timer.Paused() {
+		t.Fatalf("Unexpected pauses %+v", timer.Pauses)
+	}
+	if got := timer.Elapsed(at(150)); got != 90*time.Minute {
+		t.Errorf("Elapsed = %v, want 1h30m", got)
+	}
+
+	// Times with a monotonic reading are compared on the wall clock
+	now := time.Now()
+	if got := (&ActiveTimer{Start: now.Add(-time.Hour)}).Elapsed(now); got != time.Hour {
+		t.Errorf("Elapsed = %v, want 1h", got)
+	}
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"log"
+	"time"
+)
+
+// timerRow is a timer's row in the timer list, with the timer as last read
+// from the store, which the ticker keeps the elapsed time going from.
+type timerRow struct {
+	issue   string
+	paused  bool
+	timer   ActiveTimer
+	elapsed binding.String
+}
+
+// createTimerList builds the list of timers, one row per issue with a timer,
+// and the setting that makes starting a timer pause the others. The form
+// above it works on the selected issue's timer; the list keeps the others
+// in view and lets each be paused or logged on its own.
+func createTimerList(ui *UIComponents) *fyne.Container {
+	autoPause, err := ui.Timer.AutoPause()
+	if err != nil {
+		log.Printf("Warning: Failed to read timers: %v", err)
+	}
+	ui.AutoPauseCheck = widget.NewCheck("Pause the other timers when one starts", func(on bool) {
+		if err := ui.Timer.SetAutoPause(on); err != nil {
+			log.Printf("Warning: Failed to save timer setting: %v", err)
+		}
+	})
+	ui.AutoPauseCheck.SetChecked(autoPause)
+
+	ui.TimerRows = container.NewVBox()
+	ui.TimerList = container.NewVBox(widget.NewLabel("Timers"), ui.TimerRows, ui.AutoPauseCheck)
+	ui.TimerList.Hide() // Shown once a timer is running
+	return ui.TimerList
+}
+
+// refreshTimerList reads the active profile's timers and shows them,
+// rebuilding the rows when a timer has started, stopped, paused or resumed.
+// The callbacks that change timers call it, as does the window coming to
+// the front, which picks up changes made from the command line. It builds
+// widgets, so it is only called from callbacks; the ticker moves the
+// elapsed times on with tickTimers.
+func refreshTimerList(ui *UIComponents) {
+	all, err := ui.Timer.Timers()
+	if err != nil {
+		log.Printf("Warning: Failed to read timers: %v", err)
+		return
+	}
+	profile := currentProfile()
+	var timers []ActiveTimer
+	for _, timer := range all {
+		if profile.owns(timer.Profile) {
+			timers = append(timers, timer)
+		}
+	}
+
+	ui.mu.Lock()
+	rebuilt := !sameTimerRows(ui.timerRows, timers)
+	var objects []fyne.CanvasObject
+	if rebuilt {
+		ui.timerRows = nil
+		for _, timer := range timers {
+			row, object := newTimerRow(ui, timer)
+			ui.timerRows = append(ui.timerRows, row)
+			objects = append(objects, object)
+		}
+	}
+	for i := range ui.timerRows {
+		ui.timerRows[i].timer = timers[i]
+	}
+	ui.mu.Unlock()
+	tickTimers(ui, wallNow())
+
+	if !rebuilt || ui.TimerList == nil {
+		return
+	}
+	ui.TimerRows.Objects = objects
+	ui.TimerRows.Refresh()
+	if len(timers) > 0 {
+		ui.TimerList.Show()
+	} else {
+		ui.TimerList.Hide()
+	}
+	resizeWindowToContent(ui)
+}
+
+// tickTimers moves the elapsed times in the form, the timer list and the
+// window title on to now. It only sets bindings, working from the copies
+// of the timers that showElapsed and refreshTimerList leave under ui.mu,
+// so the ticker can call it.
+func tickTimers(ui *UIComponents, now time.Time) {
+	ui.mu.Lock()
+	view := ui.shownTimer
+	rows := append([]timerRow(nil), ui.timerRows...)
+	ui.mu.Unlock()
+
+	ui.ElapsedText.Set(view.text(now))
+	var timers []ActiveTimer
+	for _, row := range rows {
+		row.elapsed.Set(formatElapsed(row.timer.Elapsed(now)))
+		timers = append(timers, row.timer)
+	}
+	ui.WindowTitle.Set(timersTitle(timers, now))
+}
+
+// sameTimerRows reports whether rows still show timers, in the same order
+// and state.
+func sameTimerRows(rows []timerRow, timers []ActiveTimer) bool {
+	if len(rows) != len(timers) {
+		return false
+	}
+	for i, row := range rows {
+		if row.issue != timers[i].Issue || row.paused != timers[i].Paused() {
+			return false
+		}
+	}
+	return true
+}
+
+// newTimerRow returns the row for timer: its issue, which selects it, the
+// active time, and buttons to pause or resume it and to stop and log it.
+func newTimerRow(ui *UIComponents, timer ActiveTimer) (timerRow, fyne.CanvasObject) {
+	issueKey := timer.Issue
+	row := timerRow{issue: issueKey, paused: timer.Paused(), timer: timer, elapsed: binding.NewString()}
+	icon, pauseText := "▶", "Pause"
+	if row.paused {
+		icon, pauseText = "⏸", "Resume"
+	}
+
+	issueButton := widget.NewButton(icon+" "+issueKey, func() {
+		if !selectIssueOption(ui, issueKey) {
+			ui.StatusLabel.SetText(fmt.Sprintf("ℹ️ %s isn't in the issue list", issueKey))
+		}
+	})
+	issueButton.Importance = widget.LowImportance
+	pauseButton := widget.NewButton(pauseText, func() {
+		toggleTimerPause(ui, issueKey)
+	})
+	logButton := widget.NewButton("Stop & Log", func() {
+		stopAndLogTimer(ui, issueKey, wallNow())
+	})
+	return row, container.NewHBox(issueButton, widget.NewLabelWithData(row.elapsed), layout.NewSpacer(), pauseButton, logButton)
+}
+
+// timersTitle is the window title for timers: the first one running, or
+// the first one if all are paused, with a count of the others.
+func timersTitle(timers []ActiveTimer, now time.Time) string {
+	if len(timers) == 0 {
+		return windowTitle
+	}
+	shown := timers[0]
+	for _, timer := range timers {
+		if !timer.Paused() {
+			shown = timer
+			break
+		}
+	}
+	title := timerTitle(shown.Issue, shown.Elapsed(now), shown.Paused())
+	if len(timers) > 1 {
+		title += fmt.Sprintf(" +%d", len(timers)-1)
+	}
+	return title
+}
+
+// toggleTimerPause pauses the timer on issueKey, or resumes it if paused,
+// keeping the form in step when it shows that timer.
+func toggleTimerPause(ui *UIComponents, issueKey string) {
+	now := wallNow()
+	timer, err := ui.Timer.Update(issueKey, func(timer *ActiveTimer) {
+		if !timer.Resume(now) {
+			timer.Pause(now)
+		}
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to save timer: %v", err)
+		ui.StatusLabel.SetText(fmt.Sprintf("❌ Failed to save the timer on %s", issueKey))
+		return
+	}
+	defer refreshTimerList(ui)
+	if timer == nil {
+		ui.StatusLabel.SetText(fmt.Sprintf("ℹ️ The timer on %s was stopped elsewhere", issueKey))
+		return
+	}
+
+	if showsTimer(ui, timer) {
+		ui.Pauses = timer.Pauses
+		if timer.Paused() {
+			ui.PauseButton.SetText("Resume")
+		} else {
+			ui.PauseButton.SetText("Pause")
+		}
+		showElapsed(ui)
+	}
+	if timer.Paused() {
+		ui.StatusLabel.SetText(fmt.Sprintf("⏸️ Paused %s after %s", issueKey, formatDurationForJira(timer.Elapsed(now))))
+	} else {
+		ui.StatusLabel.SetText(fmt.Sprintf("▶️ Resumed %s, %s so far", issueKey, formatDurationForJira(timer.Elapsed(now))))
+	}
+}
+
+// stopAndLogTimer stops the timer on issueKey at end and logs its time to
+// Jira as a worklog of its own, with its draft comment.
+func stopAndLogTimer(ui *UIComponents, issueKey string, end time.Time) {
+	stopped, err := ui.Timer.Stop(issueKey)
+	if err != nil {
+		log.Printf("Warning: Failed to clear timer: %v", err)
+		ui.StatusLabel.SetText(fmt.Sprintf("❌ Failed to stop the timer on %s", issueKey))
+		return
+	}
+	defer refreshTimerList(ui)
+	if stopped == nil {
+		ui.StatusLabel.SetText(fmt.Sprintf("ℹ️ The timer on %s was stopped elsewhere", issueKey))
+		return
+	}
+	if showsTimer(ui, stopped) {
+		resetTimerDisplay(ui)
+		ui.CommentEntry.SetText("")
+		updateLogButtonState(ui)
+	}
+
+	entry := stopped.logEntry(end, "")
+	ui.StatusLabel.SetText(fmt.Sprintf("⏳ Logging %s to %s...", entry.Duration, issueKey))
+	go func() {
+		_, queued, err := submitWorklog(ui.ctx, ui.TimeLog, ui.Outbox, entry)
+		if isCancelled(err) {
+			return
+		}
+		showWorklogResult(ui, entry, queued, err)
+	}()
+}
+
+// showsTimer reports whether the form is running timer.
+func showsTimer(ui *UIComponents, timer *ActiveTimer) bool {
+	return timer.Issue == ui.SelectedIssue && timerRunning(ui) && ui.StartTime.Equal(timer.Start)
+}
+
+// showWorklogResult reports how logging entry went.
+func showWorklogResult(ui *UIComponents, entry TimeLogEntry, queued bool, err error) {
+	switch {
+	case err != nil:
+		ui.StatusLabel.SetText(fmt.Sprintf("❌ Failed to log work: %s", describeJiraError(err)))
+	case queued:
+		pending, _ := ui.Outbox.Len()
+		ui.StatusLabel.SetText(fmt.Sprintf("📤 Jira unavailable, %s queued for retry (%d pending)", entry.Duration, pending))
+	default:
+		ui.StatusLabel.SetText(fmt.Sprintf("✅ Logged %s to %s", entry.Duration, entry.JiraID))
+		log.Printf("Successfully logged %s to %s", entry.Duration, entry.JiraID)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"log"
+	"time"
+)
+
+// restoreTimer offers to carry on with the timers that were running when
+// the window last closed or crashed, or that were started from the command
+// line: resume each, stop it when it was last seen running, or discard it.
+func restoreTimer(ui *UIComponents) {
+	all, err := ui.Timer.Timers()
+	if err != nil {
+		log.Printf("Warning: Failed to read timers: %v", err)
+		return
+	}
+	var timers []ActiveTimer
+	for _, timer := range all {
+		if activeProfile.owns(timer.Profile) {
+			timers = append(timers, timer)
+		}
+	}
+	askAboutTimers(ui, timers)
+}
+
+// askAboutTimers asks about the first of timers, then the rest in turn.
+func askAboutTimers(ui *UIComponents, timers []ActiveTimer) {
+	if len(timers) == 0 {
+		return
+	}
+	timer, next := timers[0], func() {
+		refreshTimerList(ui)
+		askAboutTimers(ui, timers[1:])
+	}
+
+	now := wallNow()
+	message := fmt.Sprintf("A timer on %s has been running since %s.", timer.Issue, formatTimerTime(timer.Start, now))
+	stopAt, stopLabel := now, "Stop now"
+	if seen := timer.lastSeen(); seen.After(timer.Start) {
+		stopAt, stopLabel = seen, "Stop at "+seen.Format("15:04")
+		message += fmt.Sprintf("\nIt was last seen running at %s, after %s of work.",
+			formatTimerTime(seen, now), formatDurationForJira(timer.Elapsed(seen)))
+	}
+
+	var prompt *dialog.CustomDialog
+	resume := widget.NewButton("Resume", func() {
+		prompt.Hide()
+		// The timer list shows it either way; select it if nothing else is
+		if ui.RecentSelect.Selected == "" {
+			selectIssueOption(ui, timer.Issue)
+		}
+		next()
+	})
+	stop := widget.NewButton(stopLabel, func() {
+		prompt.Hide()
+		stopRestoredTimer(ui, &timer, stopAt)
+		next()
+	})
+	discard := widget.NewButton("Discard", func() {
+		prompt.Hide()
+		if _, err := ui.Timer.Stop(timer.Issue); err != nil {
+			log.Printf("Warning: Failed to clear timer: %v", err)
+		}
+		ui.StatusLabel.SetText(fmt.Sprintf("🗑️ Discarded the timer on %s", timer.Issue))
+		next()
+	})
+	resume.Importance = widget.HighImportance
+
+	prompt = dialog.NewCustomWithoutButtons("Timer still running", widget.NewLabel(message), ui.MainWindow)
+	prompt.SetButtons([]fyne.CanvasObject{discard, stop, resume})
+	prompt.Show()
+}
+
+// stopRestoredTimer stops timer at end and fills in the form, ready to log.
+// When the form is already in use, or the issue isn't listed, the time is
+// logged straight away instead.
+func stopRestoredTimer(ui *UIComponents, timer *ActiveTimer, end time.Time) {
+	if !ui.StartTime.IsZero() || !selectIssueOption(ui, timer.Issue) {
+		stopAndLogTimer(ui, timer.Issue, end)
+		return
+	}
+	if _, err := ui.Timer.Stop(timer.Issue); err != nil {
+		log.Printf("Warning: Failed to clear timer: %v", err)
+	}
+
+	*ui.StartTime = timer.Start
+	*ui.StopTime = end
+	ui.Pauses = timer.Pauses
+	*ui.Duration = timer.Elapsed(end)
+	ui.StartText.SetText(timer.Start.Format("15:04:05"))
+	ui.StopText.SetText(end.Format("15:04:05"))
+	ui.StartContainer.Show()
+	ui.EndContainer.Show()
+	showElapsed(ui)
+	ui.DurationEntry.SetText(formatDurationForJira(activeProfile.roundWorklog(*ui.Duration)))
+	ui.CommentEntry.SetText(timer.Comment)
+	log.Printf("Stopped the restored timer on %s at %s", timer.Issue, end.Format(time.RFC3339))
+}
+
+// formatTimerTime shows a time of day, with the date if it isn't today.
+func formatTimerTime(t, now time.Time) string {
+	if t.Format("2006-01-02") == now.Format("2006-01-02") {
+		return t.Format("15:04")
+	}
+	return t.Format("Mon 2 Jan 15:04")
+}
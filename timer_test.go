@@ -0,0 +1,203 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimerStore_StartStop(t *testing.T) {
+	store := newTimerStore(filepath.Join(t.TempDir(), "timer.json"))
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	if running, err := store.Timer(""); err != nil || running != nil {
+		t.Fatalf("Expected no timer, got %+v, %v", running, err)
+	}
+	if _, err := store.Start(ActiveTimer{Issue: "PROJ-1", Start: start, Comment: "Pairing"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another store on the same file sees the timer, as the CLI would
+	other := newTimerStore(store.path)
+	running, err := other.Timer("")
+	if err != nil || running == nil || running.Issue != "PROJ-1" || !running.Start.Equal(start) || running.Comment != "Pairing" {
+		t.Fatalf("Unexpected timer %+v, %v", running, err)
+	}
+	if got := running.Elapsed(start.Add(90 * time.Minute)); got != 90*time.Minute {
+		t.Errorf("Elapsed = %v", got)
+	}
+
+	if _, err := other.Start(ActiveTimer{Issue: "PROJ-1", Start: start}, false); !errors.Is(err, errTimerRunning) {
+		t.Errorf("Expected errTimerRunning, got %v", err)
+	}
+	if stopped, err := other.Stop("OPS-7"); err != nil || stopped != nil {
+		t.Errorf("Expected only a timer on OPS-7 to be stopped, got %+v, %v", stopped, err)
+	}
+
+	// A second issue's timer runs alongside the first
+	paused, err := other.Start(ActiveTimer{Issue: "OPS-7", Start: start.Add(time.Hour)}, false)
+	if err != nil || len(paused) != 0 {
+		t.Errorf("Expected OPS-7 to start alongside PROJ-1, got %v, %v", paused, err)
+	}
+	if timers, _ := store.Timers(); len(timers) != 2 || timers[0].Issue != "PROJ-1" || timers[1].Issue != "OPS-7" || timers[0].Paused() {
+		t.Errorf("Unexpected timers %+v", timers)
+	}
+	if _, err := store.Stop(""); !errors.Is(err, errSeveralTimers) {
+		t.Errorf("Expected errSeveralTimers without an issue, got %v", err)
+	}
+
+	stopped, err := store.Stop("PROJ-1")
+	if err != nil || stopped == nil || stopped.Issue != "PROJ-1" || stopped.Comment != "Pairing" {
+		t.Errorf("Expected PROJ-1 to be stopped, got %+v, %v", stopped, err)
+	}
+	stopped, err = store.Stop("")
+	if err != nil || stopped == nil || stopped.Issue != "OPS-7" {
+		t.Errorf("Expected OPS-7 to be stopped, got %+v, %v", stopped, err)
+	}
+	if timers, _ := other.Timers(); len(timers) != 0 {
+		t.Errorf("Expected no timers after stopping, got %+v", timers)
+	}
+}
+
+func TestTimerStore_AutoPause(t *testing.T) {
+	store := newTimerStore(filepath.Join(t.TempDir(), "timer.json"))
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	store.Start(ActiveTimer{Issue: "PROJ-1", Start: start}, false)
+	store.Start(ActiveTimer{Issue: "OPS-7", Start: at(10)}, false)
+	if err := store.SetAutoPause(true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Starting a third timer pauses the running ones as it starts
+	paused, err := store.Start(ActiveTimer{Issue: "PROJ-2", Start: at(60)}, false)
+	if err != nil || !reflect.DeepEqual(paused, []string{"PROJ-1", "OPS-7"}) {
+		t.Fatalf("Expected PROJ-1 and OPS-7 to be paused, got %v, %v", paused, err)
+	}
+	timers, _ := newTimerStore(store.path).Timers()
+	if len(timers) != 3 || !timers[0].Paused() || !timers[1].Paused() || timers[2].Paused() {
+		t.Fatalf("Unexpected timers %+v", timers)
+	}
+	if got := timers[1].Elapsed(at(120)); got != 50*time.Minute {
+		t.Errorf("Expected OPS-7 to stop counting when PROJ-2 started, got %v", got)
+	}
+
+	// Each timer logs its own time
+	entry := timers[0].logEntry(at(120), "Pairing")
+	if entry.JiraID != "PROJ-1" || entry.Duration != "1h" || entry.Comment != "Pairing" || len(entry.Segments) != 1 {
+		t.Errorf("Unexpected worklog for PROJ-1 %+v", entry)
+	}
+
+	// The setting outlives the timers, and a paused timer isn't paused again
+	store.Update("PROJ-1", func(timer *ActiveTimer) { timer.Resume(at(90)) })
+	if err := store.SetAutoPause(false); err != nil {
+		t.Fatal(err)
+	}
+	if paused, _ := store.Start(ActiveTimer{Issue: "PROJ-3", Start: at(100)}, true); !reflect.DeepEqual(paused, []string{"PROJ-1", "PROJ-2"}) {
+		t.Errorf("Expected -pause-others to pause PROJ-1 and PROJ-2, got %v", paused)
+	}
+	if on, err := store.AutoPause(); err != nil || on {
+		t.Errorf("AutoPause = %v, %v", on, err)
+	}
+}
+
+func TestTimerStore_ReadsSingleTimerFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timer.json")
+	// The file as it was written when there was only ever one timer
+	legacy := `{"issue": "PROJ-1", "start": "2024-03-04T09:00:00Z", "comment": "Pairing", "lastSeen": "0001-01-01T00:00:00Z"}`
+	if err := os.WriteFile(path, []byte(legacy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	store := newTimerStore(path)
+	timers, err := store.Timers()
+	if err != nil || len(timers) != 1 || timers[0].Issue != "PROJ-1" || timers[0].Comment != "Pairing" {
+		t.Fatalf("Unexpected timers %+v, %v", timers, err)
+	}
+	if _, err := store.Start(ActiveTimer{Issue: "OPS-7", Start: time.Now()}, false); err != nil {
+		t.Fatal(err)
+	}
+	if timers, _ := store.Timers(); len(timers) != 2 {
+		t.Errorf("Expected the old timer to be kept alongside the new one, got %+v", timers)
+	}
+}
+
+func TestTimerStore_UpdateSurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timer.json")
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	store := newTimerStore(path)
+	if updated, err := store.Update("", func(*ActiveTimer) { t.Error("Expected no timer to update") }); err != nil || updated != nil {
+		t.Errorf("Update without a timer = %+v, %v", updated, err)
+	}
+	store.Start(ActiveTimer{Issue: "PROJ-1", Start: start}, false)
+
+	if updated, _ := store.Update("OPS-7", func(timer *ActiveTimer) { timer.Comment = "wrong issue" }); updated != nil {
+		t.Errorf("Expected only a timer on OPS-7 to be updated, got %+v", updated)
+	}
+	store.Update("PROJ-1", func(timer *ActiveTimer) { timer.Comment = "Draft" })
+	store.UpdateAll(func(timer *ActiveTimer) { timer.LastSeen = start.Add(2 * time.Hour) })
+
+	// A restarted window reads back everything it saved
+	restored, err := newTimerStore(path).Timer("PROJ-1")
+	if err != nil || restored.Comment != "Draft" || !restored.lastSeen().Equal(start.Add(2*time.Hour)) {
+		t.Errorf("Unexpected restored timer %+v, %v", restored, err)
+	}
+	restored.LastSeen = time.Time{}
+	if !restored.lastSeen().Equal(start) {
+		t.Errorf("Expected a timer never seen to be last seen at its start, got %v", restored.lastSeen())
+	}
+}
+
+func TestActiveTimer_ElapsedSkipsPauses(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	timer := ActiveTimer{Start: start, Pauses: []TimerPause{
+		{Start: start.Add(time.Hour), End: start.Add(90 * time.Minute)},
+		{Start: start.Add(2 * time.Hour)}, // still paused
+	}}
+	for now, want := range map[time.Time]time.Duration{
+		start.Add(30 * time.Minute):  30 * time.Minute,
+		start.Add(75 * time.Minute):  time.Hour,
+		start.Add(2 * time.Hour):     90 * time.Minute,
+		start.Add(5 * time.Hour):     90 * time.Minute,
+		start.Add(-10 * time.Minute): 0,
+	} {
+		if got := timer.Elapsed(now); got != want {
+			t.Errorf("Elapsed(%s) = %v, want %v", now.Format("15:04"), got, want)
+		}
+	}
+}
+
+func TestActiveTimer_PauseResumeSegments(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+	timer := ActiveTimer{Start: start}
+
+	if timer.Resume(at(10)) || !timer.Pause(at(60)) || timer.Pause(at(70)) || !timer.Paused() {
+		t.Fatalf("Unexpected pause state %+v", timer)
+	}
+	if !timer.Resume(at(90)) || timer.Paused() {
+		t.Fatalf("Expected the timer to resume, got %+v", timer)
+	}
+	timer.Pause(at(120))
+
+	// Stopping while paused ends the last segment at the pause
+	want := []TimeSegment{{start, at(60)}, {at(90), at(120)}}
+	if got := timer.Segments(at(150)); !reflect.DeepEqual(got, want) {
+		t.Errorf("Segments = %+v, want %+v", got, want)
+	}
+	timer.Resume(at(130))
+	want = append(want, TimeSegment{at(130), at(150)})
+	if got := timer.Segments(at(150)); !reflect.DeepEqual(got, want) {
+		t.Errorf("Segments = %+v, want %+v", got, want)
+	}
+	if got := timer.Elapsed(at(150)); got != 110*time.Minute {
+		t.Errorf("Elapsed = %v, want 1h50m", got)
+	}
+	// A segment cut off by an earlier end
+	if got := timer.Segments(at(30)); !reflect.DeepEqual(got, []TimeSegment{{start, at(30)}}) {
+		t.Errorf("Segments before the first pause = %+v", got)
+	}
+}
@@ -1,8 +1,13 @@
 package main
 
+import (
+	"encoding/json"
+	"jiraTimeWidget/jiraApiFunctions"
+)
+
 type JiraResponse struct {
-	Data       Data       `json:"data"`
-	Extensions Extensions `json:"extensions"`
+	Data       Data                               `json:"data"`
+	Extensions jiraApiFunctions.GraphQLExtensions `json:"extensions"`
 }
 
 type Data struct {
@@ -12,6 +17,7 @@ type Data struct {
 
 type Jira struct {
 	IssueSearchStable IssueSearchStable `json:"issueSearchStable"`
+	IssueByKey        *IssueNode        `json:"issueByKey"`
 }
 
 type IssueSearchStable struct {
@@ -50,37 +56,56 @@ type FieldNode struct {
 	Type         string  `json:"type"`
 	User         *User   `json:"user,omitempty"`
 	DateTime     *string `json:"dateTime,omitempty"`
+	Text         *string `json:"text,omitempty"`
+	
+	// Set on the status field
+	Status      *IssueStatusNode      `json:"status,omitempty"`
+	Transitions *TransitionConnection `json:"transitions,omitempty"`
+	
+	// Set on the time tracking field
+	OriginalEstimate  *TimeEstimate `json:"originalEstimate,omitempty"`
+	RemainingEstimate *TimeEstimate `json:"remainingEstimate,omitempty"`
+	TimeSpent         *TimeEstimate `json:"timeSpent,omitempty"`
 }
 
-type Me struct {
-	User User `json:"user"`
+type IssueStatusNode struct {
+	StatusId       string `json:"statusId"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	StatusCategory struct {
+		Key string `json:"key"`
+	} `json:"statusCategory"`
 }
 
-type User struct {
-	AccountId     string `json:"accountId"`
-	AccountStatus string `json:"accountStatus"`
-	Name          string `json:"name"`
-	Picture       string `json:"picture"`
+type TransitionConnection struct {
+	Edges []TransitionEdge `json:"edges"`
 }
 
-type Extensions struct {
-	Gateway Gateway `json:"gateway"`
+type TransitionEdge struct {
+	Node TransitionNode `json:"node"`
 }
 
-type Gateway struct {
-	RequestId       string `json:"request_id"`
-	TraceId         string `json:"trace_id"`
-	CrossRegion     bool   `json:"crossRegion"`
-	EdgeCrossRegion bool   `json:"edgeCrossRegion"`
+type TransitionNode struct {
+	// TransitionId is a number in the gateway schema; json.Number takes it
+	// either way.
+	TransitionId json.Number     `json:"transitionId"`
+	Name         string          `json:"name"`
+	To           IssueStatusNode `json:"to"`
 }
 
-type Variable struct {
-	KeyOrId string `json:"keyOrId"`
+type TimeEstimate struct {
+	TimeInSeconds *int `json:"timeInSeconds"`
 }
 
-type GraphQlQuery struct {
-	Query     string   `json:"query"`
-	Variables Variable `json:"variables"`
+type Me struct {
+	User User `json:"user"`
+}
+
+type User struct {
+	AccountId     string `json:"accountId"`
+	AccountStatus string `json:"accountStatus"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
 }
 
 type TextNode struct {
@@ -1,25 +1,33 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/widget"
+	"jiraTimeWidget/jiraApiFunctions"
 	"log"
 	"os/exec"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 )
 
 type UIComponents struct {
 	StartTime            *time.Time
 	StopTime             *time.Time
+	Pauses               []TimerPause // Breaks in the timer since StartTime
 	Duration             *time.Duration
 	StartText            *widget.Label
 	StopText             *widget.Label
-	DurationText         *widget.Label
+	DurationText         *widget.Label  // Shows ElapsedText
+	ElapsedText          binding.String // The form timer's active time
+	WindowTitle          binding.String // Follows every timer, see tickTimers
+	Asleep               binding.Untyped // A *sleepGap to ask about, see noteSleep
 	DurationEntry        *widget.Entry
 	CommentEntry         *widget.Entry
 	StatusLabel          *widget.Label
@@ -32,19 +40,172 @@ type UIComponents struct {
 	StatusContainer      *fyne.Container
 	CommentContainer     *fyne.Container
 	LogButton            *widget.Button
+	PauseButton          *widget.Button
 	BrowserButton        *widget.Button
 	MainWindow           fyne.Window
-	CurrentStatus        *StatusInfo
+	CurrentStatus        *StatusInfo   // Guarded by mu, see loadedIssue
+	CurrentIssue         *IssueDetails // Details of SelectedIssue, with its transitions
 	StatusDisplayLabel   *widget.Label
 	StatusChangeButton   *widget.Button
+	TimeLog              TimeLogStore
+	Outbox               *Outbox
+	Timer                *TimerStore // Timers, shared with the CLI
+	TimerList            *fyne.Container
+	TimerRows            *fyne.Container
+	AutoPauseCheck       *widget.Check
+	
+	// mu guards what goroutines share with the window's callbacks: the
+	// copy of the form's timer the ticker shows the elapsed time of, the
+	// rows of the timer list, the selected issue's details as loaded from
+	// Jira and the context of the requests loading them, the outbox
+	// count last shown in the status label, and whether the window is
+	// asking about a sleep.
+	mu               sync.Mutex
+	shownTimer       elapsedView
+	timerRows        []timerRow
+	outboxNotice     string
+	askingAboutSleep bool
+
+	// ctx is cancelled when the window closes. issueCtx covers requests for
+	// the selected issue and is cancelled when another issue is selected.
+	ctx         context.Context
+	issueCtx    context.Context
+	cancelIssue context.CancelFunc
+}
+
+// selectIssueContext cancels requests still running for the previously
+// selected issue and returns a context for the newly selected one.
+func selectIssueContext(ui *UIComponents) context.Context {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	if ui.cancelIssue != nil {
+		ui.cancelIssue()
+	}
+	ui.CurrentStatus = nil
+	ui.CurrentIssue = nil
+	ui.issueCtx, ui.cancelIssue = context.WithCancel(ui.ctx)
+	return ui.issueCtx
+}
+
+// currentIssueContext returns the context for the selected issue.
+func currentIssueContext(ui *UIComponents) context.Context {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	if ui.issueCtx != nil {
+		return ui.issueCtx
+	}
+	return ui.ctx
+}
+
+// setLoadedIssue records details, loaded from Jira under ctx, as the
+// selected issue's. It reports false, changing nothing, when ctx was
+// cancelled because another issue was selected meanwhile; the check and
+// the change are made under ui.mu so a selection can't come in between.
+// Nil details forget the transitions but keep the status shown.
+func setLoadedIssue(ui *UIComponents, ctx context.Context, details *IssueDetails) bool {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	if ctx.Err() != nil {
+		return false
+	}
+	ui.CurrentIssue = details
+	if details != nil {
+		ui.CurrentStatus = &details.Status
+	}
+	return true
+}
+
+// loadedIssue returns the selected issue's details and status as last
+// loaded from Jira, or nil while they load.
+func loadedIssue(ui *UIComponents) (*IssueDetails, *StatusInfo) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	return ui.CurrentIssue, ui.CurrentStatus
+}
+
+// clearSelectedIssue forgets the selected issue and hides the controls that
+// act on it, cancelling anything still loading for it.
+func clearSelectedIssue(ui *UIComponents) {
+	selectIssueContext(ui)
+	ui.SelectedIssue = ""
+	ui.RecentSelect.ClearSelected()
+	ui.StatusDisplayLabel.SetText("")
+	ui.StatusChangeButton.Disable()
+	
+	ui.TimeButtonsContainer.Hide()
+	ui.StatusContainer.Hide()
+	ui.DurationContainer.Hide()
+	ui.CommentContainer.Hide()
+	if ui.BrowserButton != nil {
+		ui.BrowserButton.Hide()
+	}
+	
+	resizeWindowToContent(ui)
+	updateLogButtonState(ui)
+}
+
+// switchProfile points the widget at another .jirarc profile.
+func switchProfile(ui *UIComponents, name string) error {
+	profile, err := jiraProfiles.Profile(name)
+	if err != nil {
+		return err
+	}
+	applyProfile(profile)
+	
+	// Issues from the old site can't be logged against the new one
+	clearSelectedIssue(ui)
+	refreshTimerList(ui)
+	
+	site := currentSite()
+	go func() {
+		if err := resolveCloudID(ui.ctx, site); err != nil {
+			log.Printf("Warning: could not discover the cloud ID for %s: %v", site.url, err)
+		}
+	}()
+	return nil
+}
+
+// isCancelled reports whether err came from a request the UI abandoned,
+// which needs no error message.
+func isCancelled(err error) bool {
+	return errors.Is(err, context.Canceled)
 }
 
 func createTimeButtons(ui *UIComponents) *fyne.Container {
 	startButton := widget.NewButton("Start", func() {
-		*ui.StartTime = time.Now()
+		if timerRunning(ui) {
+			ui.StatusLabel.SetText("⏱️ The timer is already running, pause or stop it first")
+			return
+		}
+		*ui.StartTime = wallNow()
+		*ui.StopTime = time.Time{}
+		ui.Pauses = nil
+		ui.PauseButton.SetText("Pause")
 		ui.StartText.SetText(ui.StartTime.Format("15:04:05"))
 		log.Println(ui.StartTime.Format(time.RFC3339))
-		ui.StatusLabel.SetText("⏱️ Time tracking started")
+		
+		// Share the timer so it can be stopped from the command line. The
+		// other issues' timers keep running unless set to pause.
+		paused, err := ui.Timer.Start(ActiveTimer{
+			Issue:    ui.SelectedIssue,
+			Start:    *ui.StartTime,
+			Comment:  ui.CommentEntry.Text,
+			Profile:  activeProfile.Name,
+			LastSeen: *ui.StartTime,
+		}, false)
+		if errors.Is(err, errTimerRunning) {
+			// Started from the command line since the issue was selected
+			adoptSharedTimer(ui, ui.SelectedIssue)
+			return
+		}
+		if err != nil {
+			log.Printf("Warning: Failed to save timer: %v", err)
+		}
+		if len(paused) > 0 {
+			ui.StatusLabel.SetText(fmt.Sprintf("⏱️ Time tracking started, pausing %s", strings.Join(paused, ", ")))
+		} else {
+			ui.StatusLabel.SetText("⏱️ Time tracking started")
+		}
 		
 		// Show start time, hide end time
 		ui.StartContainer.Show()
@@ -53,24 +214,47 @@ func createTimeButtons(ui *UIComponents) *fyne.Container {
 		// Clear previous values
 		*ui.Duration = 0
 		ui.StopText.SetText("")
+		showElapsed(ui)
 		
 		// Update log button state
 		updateLogButtonState(ui)
 	})
 	
 	stopButton := widget.NewButton("Stop", func() {
+		// The timer may have been started or stopped from the command line
+		shared, err := ui.Timer.Timer(ui.SelectedIssue)
+		if err != nil {
+			log.Printf("Warning: Failed to read timer: %v", err)
+		}
+		if shared != nil {
+			if _, err := ui.Timer.Stop(shared.Issue); err != nil {
+				log.Printf("Warning: Failed to clear timer: %v", err)
+			}
+			*ui.StartTime = shared.Start
+			ui.Pauses = shared.Pauses
+			ui.StartText.SetText(shared.Start.Format("15:04:05"))
+			ui.StartContainer.Show()
+		} else if err == nil && shared == nil && timerRunning(ui) {
+			resetTimerDisplay(ui)
+			ui.StatusLabel.SetText("ℹ️ The timer was stopped from the command line")
+			updateLogButtonState(ui)
+			return
+		}
+		
 		if ui.StartTime.IsZero() {
 			ui.StatusLabel.SetText("❌ Please start timing first")
 			return
 		}
 		
-		*ui.StopTime = time.Now()
+		*ui.StopTime = wallNow()
 		ui.StopText.SetText(ui.StopTime.Format("15:04:05"))
 		log.Println(ui.StopTime.Format(time.RFC3339))
-		*ui.Duration = ui.StopTime.Sub(*ui.StartTime)
+		*ui.Duration = windowTimer(ui).Elapsed(*ui.StopTime)
+		ui.PauseButton.SetText("Pause")
+		showElapsed(ui)
 		
-		// Format duration for entry field
-		durationStr := formatDurationForJira(*ui.Duration)
+		// Format duration for entry field, rounded as the profile asks
+		durationStr := formatDurationForJira(activeProfile.roundWorklog(*ui.Duration))
 		ui.DurationEntry.SetText(durationStr)
 		
 		// Show end time
@@ -82,18 +266,43 @@ func createTimeButtons(ui *UIComponents) *fyne.Container {
 		updateLogButtonState(ui)
 	})
 	
+	ui.PauseButton = widget.NewButton("Pause", func() {
+		if !timerRunning(ui) {
+			ui.StatusLabel.SetText("❌ Please start timing first")
+			return
+		}
+		
+		// Pause or resume the shared timer too, so the command line agrees
+		now := wallNow()
+		timer := windowTimer(ui)
+		if timer.Paused() {
+			timer.Resume(now)
+			ui.PauseButton.SetText("Pause")
+			ui.StatusLabel.SetText(fmt.Sprintf("▶️ Resumed, %s so far", formatDurationForJira(timer.Elapsed(now))))
+		} else {
+			timer.Pause(now)
+			ui.PauseButton.SetText("Resume")
+			ui.StatusLabel.SetText(fmt.Sprintf("⏸️ Paused after %s", formatDurationForJira(timer.Elapsed(now))))
+		}
+		ui.Pauses = timer.Pauses
+		_, err := ui.Timer.Update(ui.SelectedIssue, func(shared *ActiveTimer) {
+			shared.Pauses = timer.Pauses
+		})
+		if err != nil {
+			log.Printf("Warning: Failed to save timer: %v", err)
+		}
+		showElapsed(ui)
+	})
+	
 	resetButton := widget.NewButton("Reset", func() {
-		ui.StartText.SetText("")
-		ui.StopText.SetText("")
-		ui.DurationEntry.SetText("")
+		if ui.SelectedIssue == "" {
+			return
+		}
+		if _, err := ui.Timer.Stop(ui.SelectedIssue); err != nil {
+			log.Printf("Warning: Failed to clear timer: %v", err)
+		}
+		resetTimerDisplay(ui)
 		ui.StatusLabel.SetText("🔄 Timer reset")
-		*ui.Duration = 0
-		*ui.StartTime = time.Time{}
-		*ui.StopTime = time.Time{}
-		
-		// Hide start and end containers, but keep duration and buttons visible if issue is selected
-		ui.StartContainer.Hide()
-		ui.EndContainer.Hide()
 		
 		// Update log button state
 		updateLogButtonState(ui)
@@ -103,8 +312,7 @@ func createTimeButtons(ui *UIComponents) *fyne.Container {
 	ui.BrowserButton = widget.NewButton("🌐", func() {
 		if ui.SelectedIssue != "" {
 			// Construct Jira issue URL
-			baseURL := strings.Replace(jiraGraphQlBaseUri, "/gateway/api/graphql", "", 1)
-			issueURL := fmt.Sprintf("%s/browse/%s", baseURL, ui.SelectedIssue)
+			issueURL := fmt.Sprintf("%s/browse/%s", jiraApiFunctions.DefaultClient.BaseURL, ui.SelectedIssue)
 			
 			if err := openBrowser(issueURL); err != nil {
 				log.Printf("Error opening browser: %v", err)
@@ -117,12 +325,152 @@ func createTimeButtons(ui *UIComponents) *fyne.Container {
 	ui.BrowserButton.Hide() // Initially hidden until issue is selected
 	
 	// Create the container and store it for show/hide control
-	ui.TimeButtonsContainer = container.NewHBox(startButton, stopButton, resetButton, ui.BrowserButton)
+	ui.TimeButtonsContainer = container.NewHBox(startButton, ui.PauseButton, stopButton, resetButton, ui.BrowserButton)
 	ui.TimeButtonsContainer.Hide() // Initially hidden until issue is selected
 	
 	return ui.TimeButtonsContainer
 }
 
+// resetTimerDisplay clears the timer and the duration, hiding the start and
+// end times but keeping the duration and buttons visible if an issue is
+// selected.
+func resetTimerDisplay(ui *UIComponents) {
+	ui.StartText.SetText("")
+	ui.StopText.SetText("")
+	ui.DurationEntry.SetText("")
+	*ui.Duration = 0
+	*ui.StartTime = time.Time{}
+	*ui.StopTime = time.Time{}
+	ui.Pauses = nil
+	ui.PauseButton.SetText("Pause")
+	ui.StartContainer.Hide()
+	ui.EndContainer.Hide()
+	showElapsed(ui)
+}
+
+// timerRunning reports whether the window's timer has started and not yet
+// stopped. A paused timer is still running.
+func timerRunning(ui *UIComponents) bool {
+	return !ui.StartTime.IsZero() && ui.StopTime.Before(*ui.StartTime)
+}
+
+// windowTimer returns the window's timer, for working out its elapsed time
+// and segments.
+func windowTimer(ui *UIComponents) *ActiveTimer {
+	return &ActiveTimer{Issue: ui.SelectedIssue, Start: *ui.StartTime, Pauses: ui.Pauses}
+}
+
+// showElapsed shows the active time on the form's timer, leaving out its
+// pauses, after the form changed it. It hands the ticker a copy of the
+// timer to keep the time going from, and refreshes the timer list, which
+// the change shows in too.
+func showElapsed(ui *UIComponents) {
+	view := elapsedView{timer: *windowTimer(ui)}
+	view.timer.Pauses = append([]TimerPause(nil), ui.Pauses...)
+	if !ui.StartTime.IsZero() && !timerRunning(ui) {
+		view.stopped = *ui.StopTime
+	}
+	ui.mu.Lock()
+	ui.shownTimer = view
+	ui.mu.Unlock()
+	ui.ElapsedText.Set(view.text(wallNow()))
+	refreshTimerList(ui)
+}
+
+// adoptSharedTimer shows the timer on issueKey, which may have been started
+// from the command line or before another issue was selected, so it can be
+// stopped and logged from the window.
+func adoptSharedTimer(ui *UIComponents, issueKey string) {
+	shared, err := ui.Timer.Timer(issueKey)
+	if err != nil {
+		log.Printf("Warning: Failed to read timer: %v", err)
+		return
+	}
+	wasRunning := timerRunning(ui)
+	if shared == nil || !activeProfile.owns(shared.Profile) {
+		if wasRunning {
+			// The form showed another issue's timer, which runs on in the
+			// timer list with its comment
+			resetTimerDisplay(ui)
+			ui.CommentEntry.SetText("")
+		}
+		return
+	}
+	
+	*ui.StartTime = shared.Start
+	*ui.StopTime = time.Time{}
+	ui.Pauses = shared.Pauses
+	*ui.Duration = 0
+	ui.StartText.SetText(shared.Start.Format("15:04:05"))
+	ui.StopText.SetText("")
+	if shared.Paused() {
+		ui.PauseButton.SetText("Resume")
+	} else {
+		ui.PauseButton.SetText("Pause")
+	}
+	ui.StartContainer.Show()
+	ui.EndContainer.Hide()
+	showElapsed(ui)
+	if ui.CommentEntry.Text == "" || wasRunning {
+		ui.CommentEntry.SetText(shared.Comment)
+	}
+	ui.StatusLabel.SetText(fmt.Sprintf("⏱️ Timer on %s running since %s", issueKey, shared.Start.Format("15:04")))
+}
+
+// selectIssueOption selects issueKey in the issue list, which loads it.
+// It returns false when the issue isn't listed.
+func selectIssueOption(ui *UIComponents, issueKey string) bool {
+	for _, option := range ui.RecentSelect.Options {
+		if strings.HasPrefix(option, issueKey+" [") {
+			ui.RecentSelect.SetSelected(option)
+			return true
+		}
+	}
+	return false
+}
+
+// saveTimerComment keeps the draft comment with the running timer, so it
+// survives a restart along with the time.
+func saveTimerComment(ui *UIComponents, comment string) {
+	if ui.SelectedIssue == "" {
+		return
+	}
+	_, err := ui.Timer.Update(ui.SelectedIssue, func(timer *ActiveTimer) {
+		timer.Comment = comment
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to save timer comment: %v", err)
+	}
+}
+
+// touchTimer records that the timers were seen now, so after a crash they
+// can be stopped when work last happened.
+func touchTimer(ui *UIComponents) {
+	_, err := ui.Timer.UpdateAll(func(timer *ActiveTimer) {
+		timer.LastSeen = time.Now()
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to save timer: %v", err)
+	}
+}
+
+// startTimerHeartbeat touches the timers periodically until the
+// window closes.
+func startTimerHeartbeat(ui *UIComponents) {
+	go func() {
+		ticker := time.NewTicker(timerHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				touchTimer(ui)
+			case <-ui.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
 func updateLogButtonState(ui *UIComponents) {
 	if ui.LogButton != nil {
 		// Enable button if issue is selected and either:
@@ -181,30 +529,44 @@ func executeTransition(ui *UIComponents, transition Transition) {
 	if ui.SelectedIssue == "" {
 		return
 	}
+	issueKey := ui.SelectedIssue
+	ctx := currentIssueContext(ui)
 	
 	// Clear previous feedback messages
 	ui.StatusLabel.SetText("⏳ Executing transition...")
 	
 	// Execute transition in a goroutine to keep UI responsive
 	go func() {
-		err := ExecuteStatusTransition(ui.SelectedIssue, transition.ID)
+		err := ExecuteStatusTransition(ctx, issueKey, transition.ID)
+		if isCancelled(err) {
+			return
+		}
 		if err != nil {
 			// Display error message with failure details
-			ui.StatusLabel.SetText(fmt.Sprintf("❌ Failed to transition: %v", err))
+			ui.StatusLabel.SetText(fmt.Sprintf("❌ Failed to transition: %s", describeJiraError(err)))
 			log.Printf("Error executing transition: %v", err)
 			return
 		}
 		
-		// On success, refresh status display
-		newStatus, err := GetIssueStatus(ui.SelectedIssue)
+		// On success, reload the status and the transitions out of it
+		details, err := loadIssueDetails(ctx, issueKey)
+		if isCancelled(err) {
+			return
+		}
 		if err != nil {
-			ui.StatusLabel.SetText("⚠️ Transition succeeded but failed to refresh status")
+			if setLoadedIssue(ui, ctx, nil) {
+				ui.StatusLabel.SetText("⚠️ Transition succeeded but failed to refresh status")
+			}
 			log.Printf("Error refreshing status: %v", err)
 			return
 		}
 		
-		// Update current status and display
-		ui.CurrentStatus = newStatus
+		// Update current status and display, unless another issue was
+		// selected meanwhile
+		if !setLoadedIssue(ui, ctx, details) {
+			return
+		}
+		newStatus := &details.Status
 		ui.StatusDisplayLabel.SetText(newStatus.Name)
 		
 		// Display success message for 3+ seconds
@@ -212,13 +574,15 @@ func executeTransition(ui *UIComponents, transition Transition) {
 		
 		// After 3 seconds, restore the default ready message
 		time.AfterFunc(3*time.Second, func() {
-			ui.StatusLabel.SetText(fmt.Sprintf("✅ Ready to track time on %s", ui.SelectedIssue))
+			if ctx.Err() == nil {
+				ui.StatusLabel.SetText(fmt.Sprintf("✅ Ready to track time on %s", issueKey))
+			}
 		})
 	}()
 }
 
 // showTransitionsMenu displays a popup menu with available status transitions
-func showTransitionsMenu(ui *UIComponents, transitions []Transition) {
+func showTransitionsMenu(ui *UIComponents, issueKey string, transitions []Transition) {
 	if len(transitions) == 0 {
 		ui.StatusLabel.SetText("ℹ️ No transitions available for this issue")
 		return
@@ -259,8 +623,8 @@ func showTransitionsMenu(ui *UIComponents, transitions []Transition) {
 	popupMenu.ShowAtPosition(fyne.NewPos(buttonPos.X, buttonPos.Y+buttonSize.Height))
 	
 	// Clear the loading message
-	if ui.CurrentStatus != nil {
-		ui.StatusLabel.SetText(fmt.Sprintf("✅ Ready to track time on %s", ui.SelectedIssue))
+	if _, status := loadedIssue(ui); status != nil {
+		ui.StatusLabel.SetText(fmt.Sprintf("✅ Ready to track time on %s", issueKey))
 	}
 }
 
@@ -297,6 +661,13 @@ func resizeWindowToContent(ui *UIComponents) {
 		baseHeight += 50 // Log button
 	}
 	
+	if ui.TimerList != nil && !ui.TimerList.Hidden {
+		ui.mu.Lock()
+		rows := len(ui.timerRows)
+		ui.mu.Unlock()
+		baseHeight += 80 + 45*float32(rows) // Timer list and setting
+	}
+	
 	// Add some padding
 	baseHeight += 40
 	
@@ -320,18 +691,30 @@ func createIssueSelector(ui *UIComponents) *fyne.Container {
 	// Initialize status change button with text
 	ui.StatusChangeButton = widget.NewButton("Update Status", func() {
 		// This will be implemented in subtask 5.2
-		if ui.SelectedIssue == "" || ui.CurrentStatus == nil {
+		details, status := loadedIssue(ui)
+		if ui.SelectedIssue == "" || status == nil {
+			return
+		}
+		
+		// The transitions usually came with the issue
+		issueKey := ui.SelectedIssue
+		if details != nil && details.Key == issueKey {
+			showTransitionsMenu(ui, issueKey, details.Transitions)
 			return
 		}
 		
 		// Show loading state
 		ui.StatusLabel.SetText("⏳ Fetching available transitions...")
+		ctx := currentIssueContext(ui)
 		
 		// Fetch and display transitions
 		go func() {
-			transitions, err := GetAvailableTransitions(ui.SelectedIssue)
+			transitions, err := GetAvailableTransitions(ctx, issueKey)
+			if isCancelled(err) {
+				return
+			}
 			if err != nil {
-				ui.StatusLabel.SetText(fmt.Sprintf("❌ Failed to fetch transitions: %v", err))
+				ui.StatusLabel.SetText(fmt.Sprintf("❌ Failed to fetch transitions: %s", describeJiraError(err)))
 				log.Printf("Error fetching transitions: %v", err)
 				return
 			}
@@ -342,7 +725,7 @@ func createIssueSelector(ui *UIComponents) *fyne.Container {
 			}
 			
 			// Display transitions menu (will be implemented in subtask 5.2)
-			showTransitionsMenu(ui, transitions)
+			showTransitionsMenu(ui, issueKey, transitions)
 		}()
 	})
 	ui.StatusChangeButton.Disable() // Initially disabled until issue is selected
@@ -363,73 +746,65 @@ func createIssueSelector(ui *UIComponents) *fyne.Container {
 		log.Println("Fetching Jira issue:", issueKey)
 		ui.StatusLabel.SetText("🔍 Fetching issue...")
 		
-		jiraItem := getJiraItem(issueKey, jiraApiKey)
-		if jiraItem == "" {
-			ui.StatusLabel.SetText("❌ Failed to fetch issue")
-			return
-		}
-		
-		var issueData map[string]interface{}
-		if err := json.Unmarshal([]byte(jiraItem), &issueData); err != nil {
-			ui.StatusLabel.SetText("❌ Error parsing response")
-			log.Println("JSON parse error:", err)
-			return
-		}
+		// Abandon anything still loading for the previous issue, whose
+		// status no longer applies
+		ctx := selectIssueContext(ui)
+		ui.SelectedIssue = issueKey
+		ui.StatusDisplayLabel.SetText("")
+		ui.StatusChangeButton.Disable()
 		
-		// Check for error response
-		if errorMsg, exists := issueData["errorMessage"]; exists {
-			ui.StatusLabel.SetText(fmt.Sprintf("❌ API Error: %v", errorMsg))
-			return
-		}
+		// Pick up a timer started on this issue from the command line. It
+		// only needs the timer file, so it's done here rather than by the
+		// goroutine, which leaves the form alone.
+		adoptSharedTimer(ui, issueKey)
+		updateLogButtonState(ui)
 		
-		// Extract summary from fields (we don't display it, just validate the response)
-		if fields, ok := issueData["fields"].(map[string]interface{}); ok {
-			if _, ok := fields["summary"].(string); ok {
-				ui.StatusLabel.SetText(fmt.Sprintf("✅ Ready to track time on %s", issueKey))
-				ui.SelectedIssue = issueKey
-				
-				// Fetch and display issue status
-				go func() {
-					status, err := GetIssueStatus(issueKey)
-					if err != nil {
-						ui.StatusDisplayLabel.SetText("Error fetching status")
-						log.Printf("Error fetching status: %v", err)
-					} else {
-						ui.CurrentStatus = status
-						ui.StatusDisplayLabel.SetText(status.Name)
-						
-						// Enable status change button when status is loaded
-						ui.StatusChangeButton.Enable()
-					}
-					
-					// Show status container
-					ui.StatusContainer.Show()
-					resizeWindowToContent(ui)
-				}()
-				
-				// Show time buttons, duration field, comment section and browser button when issue is selected
-				ui.TimeButtonsContainer.Show()
-				ui.DurationContainer.Show()
-				ui.CommentContainer.Show()
-				if ui.BrowserButton != nil {
-					ui.BrowserButton.Show()
-				}
-				
-				// Resize window to fit new content
-				resizeWindowToContent(ui)
-				
-				// Update log button state
-				updateLogButtonState(ui)
-			} else {
+		// Fetch in the background so a slow response doesn't block the UI
+		go func() {
+			// One request brings the summary, status, transitions and time tracking
+			details, err := loadIssueDetails(ctx, issueKey)
+			if isCancelled(err) || ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				ui.StatusLabel.SetText(fmt.Sprintf("❌ Failed to fetch %s: %s", issueKey, describeJiraError(err)))
+				return
+			}
+			
+			// We don't display the summary, just check the issue came back
+			if details.Summary == "" {
 				ui.StatusLabel.SetText("⚠️ Summary not found")
+				return
 			}
-		} else {
-			ui.StatusLabel.SetText("❌ Unexpected data format")
-		}
+			ready := fmt.Sprintf("✅ Ready to track time on %s", issueKey)
+			if tracked := details.describeTimeTracking(); tracked != "" {
+				ready += fmt.Sprintf(" (%s)", tracked)
+			}
+			if !setLoadedIssue(ui, ctx, details) {
+				return // Another issue was selected meanwhile
+			}
+			ui.StatusLabel.SetText(ready)
+			
+			// Enable status change button now the status is loaded
+			ui.StatusDisplayLabel.SetText(details.Status.Name)
+			ui.StatusChangeButton.Enable()
+			ui.StatusContainer.Show()
+			
+			// Show time buttons, duration field, comment section and browser button when issue is selected
+			ui.TimeButtonsContainer.Show()
+			ui.DurationContainer.Show()
+			ui.CommentContainer.Show()
+			if ui.BrowserButton != nil {
+				ui.BrowserButton.Show()
+			}
+			
+			// Resize window to fit new content
+			resizeWindowToContent(ui)
+		}()
 	}
 	
 	// Load recent issues for dropdown
-	recentIssues := getRecentIssues(20)
+	recentIssues := getRecentIssues(ui.ctx, 20)
 	var recentOptions []string
 	recentMap := make(map[string]string)
 	
@@ -453,12 +828,12 @@ func createIssueSelector(ui *UIComponents) *fyne.Container {
 		}
 	})
 	
-	// Create refresh button (icon only)
-	refreshButton := widget.NewButton("🔄", func() {
+	// Reload the issue list, e.g. after switching profile
+	refreshIssues := func() {
 		ui.StatusLabel.SetText("🔄 Refreshing issues...")
 		
-		// Reload recent issues
-		newRecentIssues := getRecentIssues(20)
+		// Reload recent issues, asking Jira rather than the response cache
+		newRecentIssues := getRecentIssues(jiraApiFunctions.WithCacheRefresh(ui.ctx), 20)
 		var newOptions []string
 		newMap := make(map[string]string)
 		
@@ -484,11 +859,38 @@ func createIssueSelector(ui *UIComponents) *fyne.Container {
 		}
 		ui.RecentSelect.Refresh()
 		ui.StatusLabel.SetText("✅ Issues refreshed")
-	})
+	}
+	
+	// Create refresh button (icon only)
+	refreshButton := widget.NewButton("🔄", refreshIssues)
+	
+	// Profile selector, only worth showing with more than one site
+	var profileSelect *widget.Select
+	if jiraProfiles != nil && len(jiraProfiles.Profiles) > 1 {
+		profileSelect = widget.NewSelect(jiraProfiles.ProfileNames(), nil)
+		profileSelect.SetSelected(activeProfile.Name)
+		profileSelect.OnChanged = func(name string) {
+			if name == activeProfile.Name {
+				return
+			}
+			if err := switchProfile(ui, name); err != nil {
+				ui.StatusLabel.SetText(fmt.Sprintf("❌ %v", err))
+				profileSelect.SetSelected(activeProfile.Name)
+				return
+			}
+			refreshIssues()
+			ui.StatusLabel.SetText(fmt.Sprintf("🔀 Switched to %s", name))
+		}
+	}
 	
 	// Create horizontal container for dropdown and refresh button
-	// Layout: [Issue Dropdown (fills row)] [Refresh Button]
-	selectorRow := container.NewBorder(nil, nil, nil, refreshButton, ui.RecentSelect)
+	// Layout: [Profile] [Issue Dropdown (fills row)] [Refresh Button]
+	var selectorRow *fyne.Container
+	if profileSelect != nil {
+		selectorRow = container.NewBorder(nil, nil, profileSelect, refreshButton, ui.RecentSelect)
+	} else {
+		selectorRow = container.NewBorder(nil, nil, nil, refreshButton, ui.RecentSelect)
+	}
 	
 	return container.NewVBox(selectorRow)
 }
@@ -504,7 +906,7 @@ func createJiraItemContainer(ui *UIComponents) *fyne.Container {
 	}
 	
 	// Time tracking section - will be updated dynamically with minimal spacing
-	ui.StartContainer = container.NewHBox(widget.NewLabel("Start"), ui.StartText)
+	ui.StartContainer = container.NewHBox(widget.NewLabel("Start"), ui.StartText, ui.DurationText)
 	ui.EndContainer = container.NewHBox(widget.NewLabel("End"), ui.StopText)
 	
 	// Create duration container with more space for the entry field
@@ -531,6 +933,9 @@ func createJiraItemContainer(ui *UIComponents) *fyne.Container {
 	ui.CommentEntry = widget.NewMultiLineEntry()
 	ui.CommentEntry.Wrapping = fyne.TextWrapWord
 	ui.CommentEntry.SetMinRowsVisible(3)
+	ui.CommentEntry.OnChanged = func(text string) {
+		saveTimerComment(ui, text)
+	}
 	
 	// Create comment container that can be hidden
 	ui.CommentContainer = container.NewVBox(commentLabel, ui.CommentEntry)
@@ -548,6 +953,7 @@ func createMainForm(ui *UIComponents) *fyne.Container {
 	buttonContainer := createTimeButtons(ui)
 	issueSelectorContainer := createIssueSelector(ui)
 	jiraItemContainer := createJiraItemContainer(ui)
+	timerListContainer := createTimerList(ui)
 	
 	// Create log button
 	ui.LogButton = widget.NewButton("Log Time", func() {
@@ -571,8 +977,8 @@ func createMainForm(ui *UIComponents) *fyne.Container {
 			timeSpent = ui.DurationEntry.Text
 		} else if ui.Duration.Seconds() > 0 {
 			// Use timer duration
-			finalDuration = *ui.Duration
-			timeSpent = formatDurationForJira(*ui.Duration)
+			finalDuration = activeProfile.roundWorklog(*ui.Duration)
+			timeSpent = formatDurationForJira(finalDuration)
 		} else {
 			ui.StatusLabel.SetText("❌ Please enter a duration")
 			return
@@ -580,10 +986,7 @@ func createMainForm(ui *UIComponents) *fyne.Container {
 		
 		ui.StatusLabel.SetText("⏳ Logging work to Jira...")
 		
-		comment := ui.CommentEntry.Text
-		if comment == "" {
-			comment = "Time tracked via JiraTimeWidget"
-		}
+		comment := activeProfile.worklogComment(ui.CommentEntry.Text)
 		
 		// Use current time if no start time was recorded
 		startTime := *ui.StartTime
@@ -593,49 +996,39 @@ func createMainForm(ui *UIComponents) *fyne.Container {
 			endTime = time.Now()
 		}
 		
-		// Save to local log first
 		logEntry := TimeLogEntry{
-			JiraID:    ui.SelectedIssue,
-			Summary:   "", // Summary removed from UI
-			StartTime: startTime,
-			EndTime:   endTime,
-			Duration:  timeSpent,
-			Comment:   comment,
-			LoggedAt:  time.Now(),
+			ID:         newTimeLogEntryID(),
+			JiraID:     ui.SelectedIssue,
+			Summary:    "", // Summary removed from UI
+			StartTime:  startTime,
+			EndTime:    endTime,
+			Duration:   timeSpent,
+			Comment:    comment,
+			LoggedAt:   time.Now(),
+			SyncStatus: SyncPending,
+			Profile:    activeProfile.Name,
 		}
-		
-		if err := saveTimeLogEntry(logEntry); err != nil {
-			log.Printf("Warning: Failed to save local log: %v", err)
+		if !ui.StartTime.IsZero() && !timerRunning(ui) && len(ui.Pauses) > 0 {
+			// Keep the breakdown of a paused and resumed timer
+			logEntry.Segments = windowTimer(ui).Segments(endTime)
 		}
 		
-		// Log to Jira
-		err := logWorkToJira(ui.SelectedIssue, timeSpent, comment, startTime)
+		// Log to Jira, keeping the entry locally and queueing it if Jira is unavailable
+		_, queued, err := submitWorklog(ui.ctx, ui.TimeLog, ui.Outbox, logEntry)
+		showWorklogResult(ui, logEntry, queued, err)
 		if err != nil {
-			ui.StatusLabel.SetText(fmt.Sprintf("❌ Failed to log work: %v", err))
-			log.Printf("Error logging work: %v", err)
-		} else {
-			ui.StatusLabel.SetText(fmt.Sprintf("✅ Logged %s to %s", timeSpent, ui.SelectedIssue))
-			log.Printf("Successfully logged %s to %s", timeSpent, ui.SelectedIssue)
-			
-			// Reset the timer but keep the issue selected and duration field visible
-			ui.StartText.SetText("")
-			ui.StopText.SetText("")
-			ui.DurationEntry.SetText("")
-			ui.CommentEntry.SetText("")
-			*ui.Duration = 0
-			*ui.StartTime = time.Time{}
-			*ui.StopTime = time.Time{}
-			
-			// Hide start/end containers but keep duration visible
-			ui.StartContainer.Hide()
-			ui.EndContainer.Hide()
-			
-			// Resize window after hiding containers
-			resizeWindowToContent(ui)
-			
-			// Update button state
-			updateLogButtonState(ui)
+			return
 		}
+		
+		// Reset the timer but keep the issue selected and duration field visible
+		resetTimerDisplay(ui)
+		ui.CommentEntry.SetText("")
+		
+		// Resize window after hiding containers
+		resizeWindowToContent(ui)
+		
+		// Update button state
+		updateLogButtonState(ui)
 	})
 	
 	// Initially disable the log button
@@ -651,6 +1044,7 @@ func createMainForm(ui *UIComponents) *fyne.Container {
 		ui.StatusContainer,
 		jiraItemContainer,
 		bottomContainer,
+		timerListContainer,
 	)
 	
 	// Add margins - reduced padding (this will apply to all content including the button)
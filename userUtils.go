@@ -1,61 +1,52 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
-	"io"
-	"log"
-	"net/http"
-	"os"
+	"context"
 	"jiraTimeWidget/jiraApiFunctions"
+	"log"
 )
 
-func loadJiraConfig() {
-	homeDir, _ := os.UserHomeDir()
-	data, err := os.ReadFile(homeDir + "/.jirarc")
+// loadJiraConfig reads .jirarc and switches to the profile chosen with
+// --profile, or the default one.
+func loadJiraConfig() error {
+	config, err := readJiraConfig()
 	if err != nil {
 		log.Println("Error reading .jirarc:", err)
-		return
+		return err
 	}
-	var config map[string]interface{}
-	json.Unmarshal(data, &config)
-	if jira, ok := config["jira"].(string); ok {
-		jiraApiKey = jira
-		jiraApiFunctions.JiraApiKey = jira
-		jiraApiFunctions.JiraGraphQlBaseUri = jiraGraphQlBaseUri
+	profile, err := config.Profile(selectedProfileName)
+	if err != nil {
+		log.Println("Error reading .jirarc:", err)
+		return err
 	}
-	// Also check for email if provided
-	if email, ok := config["email"].(string); ok {
-		jiraApiFunctions.JiraEmail = email
+	if err := resolveProfileTokens(config); err != nil {
+		log.Println("Error reading Jira tokens:", err)
+		return err
 	}
+	jiraProfiles = config
+	applyProfile(profile)
+	return nil
+}
+
+// graphQLClient returns a client for the active site's GraphQL gateway,
+// using the profile's credentials whether Basic, Bearer or OAuth.
+func graphQLClient() *jiraApiFunctions.Client {
+	return currentSite().graphQLClient()
+}
+
+// graphQLClient returns a client for the site's GraphQL gateway.
+func (s jiraSite) graphQLClient() *jiraApiFunctions.Client {
+	client := *s.client
+	client.GraphQLEndpoint = s.graphQLURL
+	return &client
 }
 
 func getCurrentUser() *JiraResponse {
-	query := map[string]string{"query": CurrentUserQuery}
-	queryData, _ := json.Marshal(query)
-	
-	req, err := http.NewRequest("POST", jiraGraphQlBaseUri, bytes.NewBuffer(queryData))
-	if err != nil {
-		log.Println("Error building request:", err)
-		return nil
-	}
-	
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+jiraApiKey)
-	
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	var data Data
+	response, err := graphQLClient().GraphQLQuery(context.Background(), "currentUser", nil, &data)
 	if err != nil {
 		log.Println("Error getting current user:", err)
 		return nil
 	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode == http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		var userResponse JiraResponse
-		json.Unmarshal(body, &userResponse)
-		return &userResponse
-	}
-	return nil
+	return &JiraResponse{Data: data, Extensions: response.Extensions}
 }
\ No newline at end of file
@@ -148,4 +148,19 @@ func TestGetCurrentUser_Unauthorized(t *testing.T) {
 	if result != nil {
 		t.Errorf("Expected nil result for unauthorized, got %v", result)
 	}
-}
\ No newline at end of file
+}
+
+func TestGetCurrentUser_GraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"me":null},"errors":[{"message":"Unauthenticated","path":["me"]}]}`))
+	}))
+	defer server.Close()
+
+	originalUri := jiraGraphQlBaseUri
+	jiraGraphQlBaseUri = server.URL
+	defer func() { jiraGraphQlBaseUri = originalUri }()
+
+	if result := getCurrentUser(); result != nil {
+		t.Errorf("Expected nil result when the response has errors, got %+v", result)
+	}
+}
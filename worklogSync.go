@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"jiraTimeWidget/jiraApiFunctions"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+// RemoteWorklog is the part of a Jira worklog needed to reconcile it with
+// the local time log.
+type RemoteWorklog struct {
+	ID string
+	// AuthorID is the author's accountId on Cloud and username on Data
+	// Center.
+	AuthorID         string
+	Started          time.Time
+	TimeSpentSeconds int
+}
+
+// SyncProblem classifies a disagreement between the local log and Jira.
+type SyncProblem string
+
+const (
+	// SyncMissing means Jira has no worklog for a local entry.
+	SyncMissing SyncProblem = "missing"
+	// SyncDuplicated means Jira has more than one worklog for a local entry.
+	SyncDuplicated SyncProblem = "duplicated"
+	// SyncDiverging means Jira's worklog differs from the local entry, or
+	// the local sync status doesn't match what Jira has.
+	SyncDiverging SyncProblem = "diverging"
+)
+
+// SyncFinding is one problem reported by reconcileWorklogs.
+type SyncFinding struct {
+	Problem  SyncProblem
+	Entry    TimeLogEntry
+	Worklogs []RemoteWorklog
+	Detail   string
+}
+
+// reconcileWorklogs compares local entries for one issue with the worklogs
+// Jira has for it. Remote worklogs are matched by ID when the entry knows
+// its worklog ID and otherwise by start minute and duration.
+func reconcileWorklogs(entries []TimeLogEntry, remote []RemoteWorklog) []SyncFinding {
+	var findings []SyncFinding
+	byID := make(map[string]RemoteWorklog, len(remote))
+	for _, worklog := range remote {
+		byID[worklog.ID] = worklog
+	}
+	// Worklogs already claimed by ID can't also match an unsynced entry
+	claimed := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.WorklogID != "" {
+			claimed[entry.WorklogID] = true
+		}
+	}
+
+	for _, entry := range entries {
+		localSeconds := int(parseDuration(entry.Duration).Seconds())
+
+		if entry.WorklogID != "" {
+			worklog, found := byID[entry.WorklogID]
+			if !found {
+				findings = append(findings, SyncFinding{
+					Problem: SyncMissing,
+					Entry:   entry,
+					Detail:  fmt.Sprintf("worklog %s no longer exists in Jira", entry.WorklogID),
+				})
+				continue
+			}
+			if diff := describeWorklogDifference(entry, localSeconds, worklog); diff != "" {
+				findings = append(findings, SyncFinding{
+					Problem:  SyncDiverging,
+					Entry:    entry,
+					Worklogs: []RemoteWorklog{worklog},
+					Detail:   diff,
+				})
+			}
+			continue
+		}
+
+		var matches []RemoteWorklog
+		for _, worklog := range remote {
+			if claimed[worklog.ID] {
+				continue
+			}
+			if sameMinute(worklog.Started, entry.StartTime) && worklog.TimeSpentSeconds == localSeconds {
+				matches = append(matches, worklog)
+			}
+		}
+
+		switch {
+		case len(matches) == 0:
+			findings = append(findings, SyncFinding{
+				Problem: SyncMissing,
+				Entry:   entry,
+				Detail:  describeLocalStatus(entry),
+			})
+		case len(matches) > 1:
+			findings = append(findings, SyncFinding{
+				Problem:  SyncDuplicated,
+				Entry:    entry,
+				Worklogs: matches,
+				Detail:   fmt.Sprintf("%d worklogs in Jira match this entry", len(matches)),
+			})
+		case entry.SyncStatus != SyncSynced:
+			findings = append(findings, SyncFinding{
+				Problem:  SyncDiverging,
+				Entry:    entry,
+				Worklogs: matches,
+				Detail:   fmt.Sprintf("recorded locally as %s but Jira has worklog %s", statusOrUnknown(entry.SyncStatus), matches[0].ID),
+			})
+		}
+	}
+
+	// The same worklog ID recorded against several local entries means one
+	// post was counted twice locally.
+	seen := make(map[string]TimeLogEntry)
+	for _, entry := range entries {
+		if entry.WorklogID == "" {
+			continue
+		}
+		if first, exists := seen[entry.WorklogID]; exists {
+			findings = append(findings, SyncFinding{
+				Problem:  SyncDuplicated,
+				Entry:    entry,
+				Worklogs: []RemoteWorklog{byID[entry.WorklogID]},
+				Detail:   fmt.Sprintf("worklog %s is also recorded by entry %s", entry.WorklogID, first.ID),
+			})
+			continue
+		}
+		seen[entry.WorklogID] = entry
+	}
+
+	return findings
+}
+
+func describeWorklogDifference(entry TimeLogEntry, localSeconds int, worklog RemoteWorklog) string {
+	var diffs []string
+	if worklog.TimeSpentSeconds != localSeconds {
+		diffs = append(diffs, fmt.Sprintf("time spent %s locally vs %s in Jira",
+			entry.Duration, formatDurationForJira(time.Duration(worklog.TimeSpentSeconds)*time.Second)))
+	}
+	if !sameMinute(worklog.Started, entry.StartTime) {
+		diffs = append(diffs, fmt.Sprintf("started %s locally vs %s in Jira",
+			entry.StartTime.Format("2006-01-02 15:04"), worklog.Started.Local().Format("2006-01-02 15:04")))
+	}
+	return strings.Join(diffs, "; ")
+}
+
+func describeLocalStatus(entry TimeLogEntry) string {
+	switch entry.SyncStatus {
+	case SyncFailed:
+		return "post failed: " + entry.LastError
+	case SyncSynced:
+		return "recorded locally as synced but no matching worklog in Jira"
+	default:
+		return fmt.Sprintf("recorded locally as %s and not found in Jira", statusOrUnknown(entry.SyncStatus))
+	}
+}
+
+func statusOrUnknown(status SyncStatus) string {
+	if status == "" {
+		return "unknown"
+	}
+	return string(status)
+}
+
+func sameMinute(a, b time.Time) bool {
+	return a.Truncate(time.Minute).Equal(b.Truncate(time.Minute))
+}
+
+// fetchIssueWorklogs loads every worklog on an issue, following pagination.
+func fetchIssueWorklogs(ctx context.Context, issueKey string) ([]RemoteWorklog, error) {
+	client := currentSite().client
+	it := client.Worklogs(ctx, issueKey, jiraApiFunctions.PageOptions{PageSize: 1000})
+
+	var worklogs []RemoteWorklog
+	for it.Next() {
+		w := it.Value()
+		worklog := RemoteWorklog{
+			ID:               w.ID,
+			Started:          w.Started.Time,
+			TimeSpentSeconds: w.TimeSpentSeconds,
+		}
+		if w.Author != nil {
+			worklog.AuthorID = client.UserID(w.Author.AccountID, w.Author.Name)
+		}
+		worklogs = append(worklogs, worklog)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return worklogs, nil
+}
+
+// currentUserID returns the ID of the configured user as worklog authors
+// are identified on the site, or an empty string if it can't be determined.
+func currentUserID(ctx context.Context) string {
+	client := currentSite().client
+	user, err := client.Myself(ctx)
+	if err != nil {
+		return ""
+	}
+	return client.UserID(user.AccountID, user.Name)
+}
+
+// runSyncCommand implements `jira-time sync`, which reports local entries
+// whose worklogs are missing, duplicated or different in Jira.
+func runSyncCommand(store TimeLogStore, args []string) int {
+	flags := flag.NewFlagSet("sync", flag.ContinueOnError)
+	days := flags.Int("days", 30, "only check entries logged in the last `N` days")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	entries, err := store.Entries()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading time logs: %v\n", err)
+		return 1
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -*days)
+	byIssue := make(map[string][]TimeLogEntry)
+	var issueKeys []string
+	for _, entry := range entries {
+		// Other profiles' entries live on other sites
+		if entry.LoggedAt.Before(cutoff) || !activeProfile.owns(entry.Profile) {
+			continue
+		}
+		if _, exists := byIssue[entry.JiraID]; !exists {
+			issueKeys = append(issueKeys, entry.JiraID)
+		}
+		byIssue[entry.JiraID] = append(byIssue[entry.JiraID], entry)
+	}
+
+	if len(issueKeys) == 0 {
+		fmt.Printf("No time entries logged in the last %d days.\n", *days)
+		return 0
+	}
+
+	// Stop cleanly on Ctrl-C instead of leaving requests hanging
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	userID := currentUserID(ctx)
+
+	problems := 0
+	for _, issueKey := range issueKeys {
+		remote, err := fetchIssueWorklogs(ctx, issueKey)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error fetching worklogs for %s: %v\n", issueKey, err)
+			problems++
+			continue
+		}
+
+		// Only compare against our own worklogs when we know who we are
+		if userID != "" {
+			var own []RemoteWorklog
+			for _, worklog := range remote {
+				if worklog.AuthorID == userID {
+					own = append(own, worklog)
+				}
+			}
+			remote = own
+		}
+
+		for _, finding := range reconcileWorklogs(byIssue[issueKey], remote) {
+			problems++
+			fmt.Printf("%-10s %s  %s %s (%s)\n",
+				strings.ToUpper(string(finding.Problem)),
+				finding.Entry.JiraID,
+				finding.Entry.StartTime.Format("2006-01-02 15:04"),
+				finding.Entry.Duration,
+				finding.Detail)
+		}
+	}
+
+	if problems == 0 {
+		fmt.Printf("All %d issues are in sync with Jira.\n", len(issueKeys))
+		return 0
+	}
+	fmt.Printf("\n%d problem(s) found across %d issues.\n", problems, len(issueKeys))
+	return 1
+}
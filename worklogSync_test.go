@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReconcileWorklogs(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	entries := []TimeLogEntry{
+		// In sync: matched by ID with the same duration and start
+		{ID: "ok", JiraID: "TEST-1", StartTime: start, Duration: "1h", SyncStatus: SyncSynced, WorklogID: "100"},
+		// Synced locally but deleted in Jira
+		{ID: "gone", JiraID: "TEST-1", StartTime: start, Duration: "30m", SyncStatus: SyncSynced, WorklogID: "999"},
+		// Edited in Jira after logging
+		{ID: "edited", JiraID: "TEST-1", StartTime: start.Add(2 * time.Hour), Duration: "45m", SyncStatus: SyncSynced, WorklogID: "101"},
+		// Post reported failure but actually reached Jira
+		{ID: "failed", JiraID: "TEST-1", StartTime: start.Add(4 * time.Hour), Duration: "15m", SyncStatus: SyncFailed, LastError: "timeout"},
+		// Retried after an unclear failure and ended up in Jira twice
+		{ID: "twice", JiraID: "TEST-1", StartTime: start.Add(6 * time.Hour), Duration: "20m", SyncStatus: SyncFailed},
+		// Never reached Jira
+		{ID: "lost", JiraID: "TEST-1", StartTime: start.Add(8 * time.Hour), Duration: "10m", SyncStatus: SyncFailed, LastError: "offline"},
+	}
+	remote := []RemoteWorklog{
+		{ID: "100", Started: start, TimeSpentSeconds: 3600},
+		{ID: "101", Started: start.Add(2 * time.Hour), TimeSpentSeconds: 3600},
+		{ID: "102", Started: start.Add(4 * time.Hour), TimeSpentSeconds: 900},
+		{ID: "103", Started: start.Add(6 * time.Hour), TimeSpentSeconds: 1200},
+		{ID: "104", Started: start.Add(6 * time.Hour), TimeSpentSeconds: 1200},
+	}
+
+	findings := reconcileWorklogs(entries, remote)
+
+	expected := map[string]SyncProblem{
+		"gone":   SyncMissing,
+		"edited": SyncDiverging,
+		"failed": SyncDiverging,
+		"twice":  SyncDuplicated,
+		"lost":   SyncMissing,
+	}
+	if len(findings) != len(expected) {
+		t.Fatalf("Expected %d findings, got %d: %+v", len(expected), len(findings), findings)
+	}
+	for _, finding := range findings {
+		want, ok := expected[finding.Entry.ID]
+		if !ok {
+			t.Errorf("Unexpected finding for entry %s: %s", finding.Entry.ID, finding.Detail)
+			continue
+		}
+		if finding.Problem != want {
+			t.Errorf("Entry %s: expected %s, got %s (%s)", finding.Entry.ID, want, finding.Problem, finding.Detail)
+		}
+	}
+}
+
+func TestReconcileWorklogs_SameWorklogRecordedTwice(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	entries := []TimeLogEntry{
+		{ID: "a", StartTime: start, Duration: "1h", SyncStatus: SyncSynced, WorklogID: "100"},
+		{ID: "b", StartTime: start, Duration: "1h", SyncStatus: SyncSynced, WorklogID: "100"},
+	}
+	remote := []RemoteWorklog{{ID: "100", Started: start, TimeSpentSeconds: 3600}}
+
+	findings := reconcileWorklogs(entries, remote)
+
+	if len(findings) != 1 || findings[0].Problem != SyncDuplicated || findings[0].Entry.ID != "b" {
+		t.Errorf("Expected entry b to be reported as duplicated, got %+v", findings)
+	}
+}